@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"io/fs"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -19,7 +21,9 @@ import (
 	"github.com/loganlanou/Financing-101/internal/handlers"
 	"github.com/loganlanou/Financing-101/internal/ingest"
 	"github.com/loganlanou/Financing-101/internal/logging"
+	"github.com/loganlanou/Financing-101/internal/macro"
 	"github.com/loganlanou/Financing-101/internal/mail"
+	"github.com/loganlanou/Financing-101/internal/options"
 	"github.com/loganlanou/Financing-101/internal/payments"
 	"github.com/loganlanou/Financing-101/internal/server"
 	"github.com/loganlanou/Financing-101/internal/services"
@@ -49,7 +53,8 @@ func run(ctx context.Context, cfg config.Config, log *slog.Logger) error {
 		return err
 	}
 
-	db, err := sql.Open("sqlite", cfg.DatabasePath)
+	// Background jobs write while pages read; wait on locks instead of failing.
+	db, err := sql.Open("sqlite", cfg.DatabasePath+"?_pragma=busy_timeout(5000)")
 	if err != nil {
 		return err
 	}
@@ -85,11 +90,46 @@ func run(ctx context.Context, cfg config.Config, log *slog.Logger) error {
 		return err
 	}
 
+	// Configured coins beyond the built-in list are crypto too.
+	services.AddCryptoSymbols(cfg.CryptoSymbols)
+
 	newsService := services.NewNewsService(log, queries)
 	stockService := services.NewStockService(log, queries)
 	tradeService := services.NewTradeService(log, queries)
 	recService := services.NewRecommendationService(log, queries)
 	learnService := services.NewLearnService(log, queries)
+	fundamentalsService := services.NewFundamentalsService(log, queries)
+	symbolService := services.NewSymbolService(log, queries)
+	macroService := macro.NewService(log, queries)
+	yieldCurveService := services.NewYieldCurveService(log, queries)
+	earningsService := services.NewEarningsService(log, queries)
+	optionsService := options.NewService(log, queries)
+	fxService := services.NewFXService(log, queries)
+	etfService := services.NewETFService(log, queries)
+
+	quoteProviders, err := services.NewQuoteProviders(log, nil, cfg.QuoteProviders, map[string]services.ProviderSettings{
+		"finnhub":      {APIKey: cfg.FinnhubKey, BaseURL: cfg.FinnhubBaseURL, RatePerMinute: cfg.FinnhubRateLimit},
+		"alphavantage": {APIKey: cfg.AlphaVantageKey, BaseURL: cfg.AlphaVantageBaseURL, RatePerMinute: cfg.AlphaVantageRateLimit},
+		"yahoo":        {BaseURL: cfg.YahooBaseURL, RatePerMinute: cfg.YahooRateLimit},
+		"coinbase":     {BaseURL: cfg.CoinbaseBaseURL, RatePerMinute: cfg.CoinbaseRateLimit},
+	}, services.BreakerSettings{
+		FailureThreshold: cfg.ProviderFailureLimit,
+		Cooldown:         cfg.ProviderBreakerCooldown,
+	})
+	if err != nil {
+		return err
+	}
+	marketData := services.NewMarketDataService(log, queries, quoteProviders, cfg.QuoteConcurrency)
+	riskService := services.NewRiskService(log, db, queries, cfg.RiskFreeRate)
+
+	moverUniverse := cfg.MoverUniverse
+	if symbols, err := ingest.ReadUniverse(cfg.MoverUniverseFile); err == nil && len(symbols) > 0 {
+		moverUniverse = symbols
+	} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Warn("movers universe file unreadable, using MOVERS_UNIVERSE", slog.String("file", cfg.MoverUniverseFile), slog.Any("err", err))
+	}
+	moversService := services.NewMoversService(log, marketData, fundamentalsService, uniqueSymbols(moverUniverse, cfg.CryptoSymbols), cfg.MoverBatchSize, cfg.MoverRefreshInterval)
+	sectorService := services.NewSectorService(log, marketData, cfg.SectorBenchmark)
 
 	newsIngestor := ingest.NewNewsIngestor(log, queries, cfg.NewsFeeds)
 	if err := newsIngestor.Refresh(ctx, 20); err != nil {
@@ -113,10 +153,133 @@ func run(ctx context.Context, cfg config.Config, log *slog.Logger) error {
 		}
 	}()
 
+	backfillSymbols := uniqueSymbols(cfg.PriceSymbols, cfg.SnapshotBenchmarks, cfg.SnapshotUniverse, sectorService.Symbols(), cfg.CryptoSymbols)
+	priceBackfiller := ingest.NewPriceBackfiller(log, queries, marketData, backfillSymbols, time.Duration(cfg.PriceHistoryDays)*24*time.Hour)
+	snapshotBuilder := ingest.NewSnapshotBuilder(log, queries, marketData, riskService, fxService, cfg.SnapshotUniverse, cfg.SnapshotBenchmarks)
+	actionImporter := ingest.NewCorporateActionImporter(log, marketData, cfg.CorporateActionsDir)
+	fxImporter := ingest.NewFXRateImporter(log, fxService, cfg.FXRatesDir)
+
+	// Backfill runs in the background so a slow vendor never delays boot.
+	go func() {
+		// Imported actions and exchange rates must be stored before the first
+		// snapshot adjusts returns with them.
+		if err := actionImporter.Import(ctx); err != nil {
+			log.Warn("corporate action import failed", slog.Any("err", err))
+		}
+		if err := fxImporter.Import(ctx); err != nil {
+			log.Warn("fx rate import failed", slog.Any("err", err))
+		}
+		ticker := time.NewTicker(cfg.PriceBackfillInterval)
+		defer ticker.Stop()
+		for {
+			if err := priceBackfiller.Backfill(ctx); err != nil {
+				log.Warn("price backfill failed", slog.Any("err", err))
+			}
+			if err := snapshotBuilder.Build(ctx); err != nil {
+				log.Warn("snapshot build failed", slog.Any("err", err))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	// Filings and listings change slowly; importing at boot picks up newly dropped files.
+	factsImporter := ingest.NewCompanyFactsImporter(log, queries, cfg.FundamentalsDir)
+	symbolImporter := ingest.NewSymbolImporter(log, db, queries, cfg.SymbolListingsDir)
+	macroImporter := ingest.NewMacroImporter(log, macroService, cfg.MacroDir)
+	yieldImporter := ingest.NewTreasuryYieldImporter(log, yieldCurveService, cfg.TreasuryYieldsDir)
+	earningsImporter := ingest.NewEarningsImporter(log, earningsService, cfg.EarningsDir)
+	optionsImporter := ingest.NewOptionChainImporter(log, optionsService, cfg.OptionChainsDir)
+	etfImporter := ingest.NewETFHoldingsImporter(log, etfService, cfg.ETFHoldingsDir)
+	go func() {
+		if err := symbolImporter.Import(ctx); err != nil {
+			log.Warn("symbol listing import failed", slog.Any("err", err))
+		}
+		symbolService.Invalidate()
+		if err := factsImporter.Import(ctx); err != nil {
+			log.Warn("company facts import failed", slog.Any("err", err))
+		}
+		if err := macroImporter.Import(ctx); err != nil {
+			log.Warn("macro import failed", slog.Any("err", err))
+		}
+		if err := yieldImporter.Import(ctx); err != nil {
+			log.Warn("treasury yield import failed", slog.Any("err", err))
+		}
+		if err := earningsImporter.Import(ctx); err != nil {
+			log.Warn("earnings import failed", slog.Any("err", err))
+		}
+		if err := optionsImporter.Import(ctx); err != nil {
+			log.Warn("option chain import failed", slog.Any("err", err))
+		}
+		if err := etfImporter.Import(ctx); err != nil {
+			log.Warn("etf holdings import failed", slog.Any("err", err))
+		}
+	}()
+
+	quoteHub := services.NewQuoteHub(log, marketData, cfg.QuoteStreamInterval, cfg.QuoteStreamClosedInterval)
+	go quoteHub.Run(ctx)
+	go marketData.Bars().Run(ctx)
+	go moversService.Run(ctx)
+
 	srv := server.New(cfg, log)
 
-	pagesHandler := handlers.NewPagesHandler(log, newsService, stockService, tradeService, recService, learnService)
+	pagesHandler := handlers.NewPagesHandler(log, newsService, stockService, tradeService, recService, learnService, marketData, fundamentalsService, symbolService, riskService, moversService, sectorService, macroService, yieldCurveService, earningsService, optionsService, fxService, etfService, cfg.CryptoSymbols)
 	pagesHandler.RegisterRoutes(srv.Echo())
 
+	streamHandler := handlers.NewStreamHandler(log, quoteHub, fxService, symbolService)
+	streamHandler.RegisterRoutes(srv.Echo())
+
+	healthHandler := handlers.NewHealthHandler(log, marketData)
+	healthHandler.RegisterRoutes(srv.Echo())
+
+	symbolsHandler := handlers.NewSymbolsHandler(log, symbolService)
+	symbolsHandler.RegisterRoutes(srv.Echo())
+
+	indicatorsHandler := handlers.NewIndicatorsHandler(log, marketData)
+	indicatorsHandler.RegisterRoutes(srv.Echo())
+
+	riskHandler := handlers.NewRiskHandler(log, riskService)
+	riskHandler.RegisterRoutes(srv.Echo())
+
+	moversHandler := handlers.NewMoversHandler(log, moversService)
+	moversHandler.RegisterRoutes(srv.Echo())
+
+	sectorsHandler := handlers.NewSectorsHandler(log, sectorService)
+	sectorsHandler.RegisterRoutes(srv.Echo())
+
+	macroHandler := handlers.NewMacroHandler(log, macroService)
+	macroHandler.RegisterRoutes(srv.Echo())
+
+	ratesHandler := handlers.NewRatesHandler(log, yieldCurveService)
+	ratesHandler.RegisterRoutes(srv.Echo())
+
+	optionsHandler := handlers.NewOptionsHandler(log, optionsService, marketData, yieldCurveService)
+	optionsHandler.RegisterRoutes(srv.Echo())
+
+	fxHandler := handlers.NewFXHandler(log, fxService)
+	fxHandler.RegisterRoutes(srv.Echo())
+
+	etfHandler := handlers.NewETFHandler(log, etfService)
+	etfHandler.RegisterRoutes(srv.Echo())
+
 	return srv.Start(ctx)
 }
+
+// uniqueSymbols merges symbol lists, keeping the first occurrence of each.
+func uniqueSymbols(lists ...[]string) []string {
+	seen := map[string]struct{}{}
+	var out []string
+	for _, list := range lists {
+		for _, symbol := range list {
+			if _, ok := seen[symbol]; ok {
+				continue
+			}
+			seen[symbol] = struct{}{}
+			out = append(out, symbol)
+		}
+	}
+	return out
+}
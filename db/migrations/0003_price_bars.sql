@@ -0,0 +1,18 @@
+-- +goose Up
+
+-- OHLCV history cached from quote providers, one row per bar
+CREATE TABLE IF NOT EXISTS price_bars (
+    symbol TEXT NOT NULL,
+    interval TEXT NOT NULL,
+    bar_time DATETIME NOT NULL,
+    open REAL NOT NULL,
+    high REAL NOT NULL,
+    low REAL NOT NULL,
+    close REAL NOT NULL,
+    volume INTEGER NOT NULL DEFAULT 0,
+    source TEXT NOT NULL,
+    PRIMARY KEY (symbol, interval, bar_time)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS price_bars;
@@ -0,0 +1,43 @@
+-- +goose Up
+
+-- Issuer metadata, keyed by ticker; cik links back to SEC filings
+CREATE TABLE IF NOT EXISTS company_profiles (
+    symbol TEXT PRIMARY KEY,
+    cik TEXT NOT NULL,
+    name TEXT NOT NULL,
+    sector TEXT,
+    industry TEXT,
+    source TEXT NOT NULL,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Quarterly statement lines taken from filings; NULL when a filer does not report a line
+CREATE TABLE IF NOT EXISTS fundamentals (
+    symbol TEXT NOT NULL,
+    period_end DATETIME NOT NULL,
+    fiscal_year INTEGER NOT NULL,
+    fiscal_period TEXT NOT NULL,
+    form TEXT NOT NULL,
+    filed_at DATETIME NOT NULL,
+    -- income statement
+    revenue REAL,
+    gross_profit REAL,
+    operating_income REAL,
+    net_income REAL,
+    eps_diluted REAL,
+    -- balance sheet
+    total_assets REAL,
+    total_liabilities REAL,
+    stockholders_equity REAL,
+    cash REAL,
+    long_term_debt REAL,
+    shares_outstanding REAL,
+    -- cash flow
+    operating_cash_flow REAL,
+    capital_expenditures REAL,
+    PRIMARY KEY (symbol, period_end)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS fundamentals;
+DROP TABLE IF EXISTS company_profiles;
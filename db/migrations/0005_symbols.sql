@@ -0,0 +1,20 @@
+-- +goose Up
+
+-- Symbol master: every ticker the app can quote, search or tag news with
+CREATE TABLE IF NOT EXISTS symbols (
+    symbol TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    exchange TEXT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('stock', 'etf', 'index', 'fund', 'crypto')),
+    sector TEXT,
+    -- comma-separated alternate names, e.g. "Google" for GOOGL
+    aliases TEXT NOT NULL DEFAULT '',
+    active BOOLEAN NOT NULL DEFAULT 1,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_symbols_exchange ON symbols(exchange, updated_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_symbols_exchange;
+DROP TABLE IF EXISTS symbols;
@@ -0,0 +1,23 @@
+-- +goose Up
+
+-- Trailing risk statistics per symbol, rebuilt with stock_snapshots from
+-- the daily bars in price_bars. Percent columns are in percent (12.5 = 12.5%).
+CREATE TABLE IF NOT EXISTS risk_stats (
+    symbol TEXT NOT NULL,
+    window_days INTEGER NOT NULL,
+    benchmark TEXT NOT NULL,
+    observations INTEGER NOT NULL,
+    volatility REAL NOT NULL,
+    max_drawdown REAL NOT NULL,
+    -- NULL when the benchmark or risk-free comparison is undefined
+    beta REAL,
+    correlation REAL,
+    sharpe REAL,
+    sortino REAL,
+    as_of DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (symbol, window_days)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS risk_stats;
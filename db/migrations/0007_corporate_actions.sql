@@ -0,0 +1,24 @@
+-- +goose Up
+
+-- Splits, cash dividends and ticker changes, used to adjust price_bars into
+-- total-return series. Each action takes effect at the open of ex_date.
+CREATE TABLE IF NOT EXISTS corporate_actions (
+    symbol TEXT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('split', 'dividend', 'symbol_change')),
+    ex_date DATETIME NOT NULL,
+    -- split: new shares per old share, 4 for a 4-for-1 and 0.1 for a 1-for-10 reverse split
+    ratio REAL NOT NULL DEFAULT 0,
+    -- dividend: cash paid per share as of ex_date
+    amount REAL NOT NULL DEFAULT 0,
+    -- symbol_change: the ticker the security trades under from ex_date
+    new_symbol TEXT NOT NULL DEFAULT '',
+    source TEXT NOT NULL,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (symbol, type, ex_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_corporate_actions_new_symbol ON corporate_actions(new_symbol);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_corporate_actions_new_symbol;
+DROP TABLE IF EXISTS corporate_actions;
@@ -0,0 +1,51 @@
+-- +goose Up
+
+-- Economic time series such as CPI and the unemployment rate, imported from
+-- FRED-format CSV files. id is the FRED series ID (CPIAUCSL, UNRATE, ...).
+CREATE TABLE IF NOT EXISTS macro_series (
+    id TEXT PRIMARY KEY,
+    title TEXT NOT NULL,
+    units TEXT NOT NULL DEFAULT '',
+    frequency TEXT NOT NULL DEFAULT '',
+    source TEXT NOT NULL,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One row per series per observation date; FRED dates the start of the period
+-- (2026-08-01 is the August reading).
+CREATE TABLE IF NOT EXISTS macro_observations (
+    series_id TEXT NOT NULL,
+    obs_date DATETIME NOT NULL,
+    value REAL NOT NULL,
+    PRIMARY KEY (series_id, obs_date)
+);
+
+-- Scheduled data releases shown on the economic calendar. series_id links a
+-- release to the series it updates and is empty when none is stored.
+CREATE TABLE IF NOT EXISTS macro_releases (
+    name TEXT NOT NULL,
+    release_at DATETIME NOT NULL,
+    series_id TEXT NOT NULL DEFAULT '',
+    period TEXT NOT NULL DEFAULT '',
+    source TEXT NOT NULL,
+    PRIMARY KEY (name, release_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_macro_releases_release_at ON macro_releases(release_at);
+
+-- Seed data: 2026 FOMC rate decisions, announced at 2:00 PM Eastern
+INSERT INTO macro_releases (name, release_at, series_id, period, source) VALUES
+    ('FOMC Rate Decision', '2026-01-28 19:00:00 +0000 UTC', 'FEDFUNDS', 'January meeting', 'seed'),
+    ('FOMC Rate Decision', '2026-03-18 18:00:00 +0000 UTC', 'FEDFUNDS', 'March meeting', 'seed'),
+    ('FOMC Rate Decision', '2026-04-29 18:00:00 +0000 UTC', 'FEDFUNDS', 'April meeting', 'seed'),
+    ('FOMC Rate Decision', '2026-06-17 18:00:00 +0000 UTC', 'FEDFUNDS', 'June meeting', 'seed'),
+    ('FOMC Rate Decision', '2026-07-29 18:00:00 +0000 UTC', 'FEDFUNDS', 'July meeting', 'seed'),
+    ('FOMC Rate Decision', '2026-09-16 18:00:00 +0000 UTC', 'FEDFUNDS', 'September meeting', 'seed'),
+    ('FOMC Rate Decision', '2026-10-28 18:00:00 +0000 UTC', 'FEDFUNDS', 'October meeting', 'seed'),
+    ('FOMC Rate Decision', '2026-12-09 19:00:00 +0000 UTC', 'FEDFUNDS', 'December meeting', 'seed');
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_macro_releases_release_at;
+DROP TABLE IF EXISTS macro_releases;
+DROP TABLE IF EXISTS macro_observations;
+DROP TABLE IF EXISTS macro_series;
@@ -0,0 +1,18 @@
+-- +goose Up
+
+-- Treasury daily par yield curve rates, one row per tenor per business day.
+-- tenor_months orders the curve: 1.5 for the six-week bill, 120 for 10 years.
+CREATE TABLE IF NOT EXISTS treasury_yields (
+    curve_date DATETIME NOT NULL,
+    tenor_months REAL NOT NULL,
+    -- par yield in percent (4.25 = 4.25%)
+    yield REAL NOT NULL,
+    source TEXT NOT NULL,
+    PRIMARY KEY (curve_date, tenor_months)
+);
+
+CREATE INDEX IF NOT EXISTS idx_treasury_yields_tenor ON treasury_yields(tenor_months, curve_date);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_treasury_yields_tenor;
+DROP TABLE IF EXISTS treasury_yields;
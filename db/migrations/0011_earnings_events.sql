@@ -0,0 +1,26 @@
+-- +goose Up
+
+-- Quarterly earnings reports, scheduled and past, imported from calendar dumps.
+-- Estimates are the consensus going into the report; actuals are NULL until
+-- the company reports.
+CREATE TABLE IF NOT EXISTS earnings_events (
+    symbol TEXT NOT NULL,
+    report_date DATETIME NOT NULL,
+    -- bmo: before market open, amc: after market close, dmh: during market hours
+    timing TEXT NOT NULL DEFAULT '' CHECK (timing IN ('', 'bmo', 'amc', 'dmh')),
+    -- e.g. Q3 2026; fiscal quarters need not match calendar quarters
+    fiscal_period TEXT NOT NULL DEFAULT '',
+    eps_estimate REAL,
+    eps_actual REAL,
+    revenue_estimate REAL,
+    revenue_actual REAL,
+    source TEXT NOT NULL,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (symbol, report_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_earnings_events_report_date ON earnings_events(report_date);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_earnings_events_report_date;
+DROP TABLE IF EXISTS earnings_events;
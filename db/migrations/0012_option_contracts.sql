@@ -0,0 +1,23 @@
+-- +goose Up
+
+-- Listed option quotes, one row per contract, replaced by each chain import.
+-- Strikes and prices are per share; one contract covers 100 shares.
+CREATE TABLE IF NOT EXISTS option_contracts (
+    symbol TEXT NOT NULL,
+    expiration DATETIME NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('call', 'put')),
+    strike REAL NOT NULL,
+    bid REAL,
+    ask REAL,
+    last REAL,
+    volume INTEGER NOT NULL DEFAULT 0,
+    open_interest INTEGER NOT NULL DEFAULT 0,
+    -- vendor implied volatility as a fraction (0.25 = 25%), when supplied
+    implied_vol REAL,
+    as_of DATETIME NOT NULL,
+    source TEXT NOT NULL,
+    PRIMARY KEY (symbol, expiration, kind, strike)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS option_contracts;
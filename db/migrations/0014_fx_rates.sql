@@ -0,0 +1,27 @@
+-- +goose Up
+
+-- Daily foreign exchange reference rates against the US dollar. rate is units
+-- of currency per 1 USD (EUR 0.92, JPY 151.3), so converting between any two
+-- currencies goes through the dollar.
+CREATE TABLE IF NOT EXISTS fx_rates (
+    currency TEXT NOT NULL,
+    rate_date DATETIME NOT NULL,
+    rate REAL NOT NULL CHECK (rate > 0),
+    source TEXT NOT NULL,
+    PRIMARY KEY (currency, rate_date)
+);
+
+-- Snapshots record the listing currency; change_* stay in that currency and
+-- usd_change_* convert them to dollars, NULL when no FX history covers the
+-- window. vs_sp500_* compare the dollar returns.
+ALTER TABLE stock_snapshots ADD COLUMN currency TEXT NOT NULL DEFAULT 'USD';
+ALTER TABLE stock_snapshots ADD COLUMN usd_change_30 REAL;
+ALTER TABLE stock_snapshots ADD COLUMN usd_change_90 REAL;
+ALTER TABLE stock_snapshots ADD COLUMN usd_change_365 REAL;
+
+-- +goose Down
+ALTER TABLE stock_snapshots DROP COLUMN usd_change_365;
+ALTER TABLE stock_snapshots DROP COLUMN usd_change_90;
+ALTER TABLE stock_snapshots DROP COLUMN usd_change_30;
+ALTER TABLE stock_snapshots DROP COLUMN currency;
+DROP TABLE IF EXISTS fx_rates;
@@ -0,0 +1,23 @@
+-- +goose Up
+
+-- ETF constituents from issuer holdings files, one row per line of the file
+-- in file order, replaced by each import. symbol is empty for cash and other
+-- lines without a ticker; weight is the fraction of net assets (0.071 = 7.1%).
+CREATE TABLE IF NOT EXISTS etf_holdings (
+    etf_symbol TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    symbol TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL,
+    sector TEXT,
+    -- equity, cash, future, ... as labeled by the issuer
+    asset_class TEXT NOT NULL,
+    weight REAL NOT NULL,
+    shares REAL,
+    market_value REAL,
+    as_of DATETIME NOT NULL,
+    source TEXT NOT NULL,
+    PRIMARY KEY (etf_symbol, position)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS etf_holdings;
@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- Bars built from polled quotes move to their own interval key ("5m@quotes")
+-- so vendor bars of the same size no longer overwrite them, or they the
+-- vendor's. A built bar that already exists under the new key wins.
+UPDATE OR IGNORE price_bars SET interval = interval || '@quotes' WHERE source = 'quotes' AND interval IN ('1m', '5m', '15m');
+DELETE FROM price_bars WHERE source = 'quotes' AND interval IN ('1m', '5m', '15m');
+
+-- +goose Down
+DELETE FROM price_bars WHERE interval LIKE '%@quotes';
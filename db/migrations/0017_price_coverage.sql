@@ -0,0 +1,14 @@
+-- +goose Up
+
+-- How far back the price backfill has asked providers for bars. A vendor
+-- with no bars before a listing date leaves the earliest stored bar later
+-- than this, and the head of the range is not requested again.
+CREATE TABLE IF NOT EXISTS price_coverage (
+    symbol TEXT NOT NULL,
+    interval TEXT NOT NULL,
+    covered_from DATETIME NOT NULL,
+    PRIMARY KEY (symbol, interval)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS price_coverage;
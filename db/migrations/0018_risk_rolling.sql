@@ -0,0 +1,21 @@
+-- +goose Up
+
+-- Rolling risk statistics per symbol, one row per daily bar of the past
+-- year for each window of window_bars trading days. Rebuilt with
+-- risk_stats; percent columns are in percent as there.
+CREATE TABLE IF NOT EXISTS risk_rolling (
+    symbol TEXT NOT NULL,
+    window_bars INTEGER NOT NULL,
+    bar_time DATETIME NOT NULL,
+    benchmark TEXT NOT NULL,
+    volatility REAL NOT NULL,
+    max_drawdown REAL NOT NULL,
+    beta REAL,
+    correlation REAL,
+    sharpe REAL,
+    sortino REAL,
+    PRIMARY KEY (symbol, window_bars, bar_time)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS risk_rolling;
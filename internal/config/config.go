@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -24,6 +25,68 @@ type Config struct {
 	RequestTimeout    time.Duration
 	NewsFeeds         []string
 	NewsPollInterval  time.Duration
+	AlphaVantageKey   string
+	FinnhubKey        string
+	// QuoteProviders is the market data fallback order, highest priority first.
+	QuoteProviders      []string
+	FinnhubBaseURL      string
+	AlphaVantageBaseURL string
+	YahooBaseURL        string
+	CoinbaseBaseURL     string
+	// CryptoSymbols are coin pairs listed, ranked and backfilled alongside equities.
+	CryptoSymbols []string
+	// PriceSymbols are kept backfilled in the local price_bars store.
+	PriceSymbols          []string
+	PriceBackfillInterval time.Duration
+	PriceHistoryDays      int
+	// SnapshotUniverse is rebuilt into stock_snapshots after each backfill.
+	SnapshotUniverse []string
+	// SnapshotBenchmarks are tried in order. SPY leads because its bars are
+	// dividend-adjusted like the stocks'; ^GSPC is a price index and would
+	// credit every stock with the index's dividend yield.
+	SnapshotBenchmarks []string
+	// RiskFreeRate is the annual rate Sharpe and Sortino ratios are measured against.
+	RiskFreeRate float64
+	// MoverUniverse is ranked into gainers, losers and most active; a
+	// MoverUniverseFile that exists replaces it.
+	MoverUniverse     []string
+	MoverUniverseFile string
+	// MoverBatchSize symbols are quoted every MoverRefreshInterval, so the
+	// two set how much of the vendors' quote budget movers use.
+	MoverBatchSize       int
+	MoverRefreshInterval time.Duration
+	// SectorBenchmark is what sector fund relative strength is measured against.
+	SectorBenchmark string
+	// QuoteStreamInterval paces /stream/quotes polling during the regular session.
+	QuoteStreamInterval       time.Duration
+	QuoteStreamClosedInterval time.Duration
+	// Per-provider request budgets in calls per minute; zero disables the limit.
+	FinnhubRateLimit      float64
+	AlphaVantageRateLimit float64
+	YahooRateLimit        float64
+	CoinbaseRateLimit     float64
+	// QuoteConcurrency bounds parallel vendor lookups in batch quote requests.
+	QuoteConcurrency        int
+	ProviderFailureLimit    int
+	ProviderBreakerCooldown time.Duration
+	// FundamentalsDir holds SEC companyfacts JSON files imported at startup.
+	FundamentalsDir string
+	// SymbolListingsDir holds Nasdaq/NYSE listing files loaded into the symbols table.
+	SymbolListingsDir string
+	// CorporateActionsDir holds split, dividend and symbol change CSVs loaded at startup.
+	CorporateActionsDir string
+	// MacroDir holds FRED series CSVs and the release schedule loaded at startup.
+	MacroDir string
+	// TreasuryYieldsDir holds Treasury daily par yield curve CSVs loaded at startup.
+	TreasuryYieldsDir string
+	// EarningsDir holds earnings calendar CSV and JSON dumps loaded at startup.
+	EarningsDir string
+	// OptionChainsDir holds option chain CSV snapshots loaded at startup.
+	OptionChainsDir string
+	// FXRatesDir holds daily exchange rate CSVs loaded at startup.
+	FXRatesDir string
+	// ETFHoldingsDir holds issuer ETF holdings CSVs loaded at startup.
+	ETFHoldingsDir string
 }
 
 func Load() (Config, error) {
@@ -39,6 +102,8 @@ func Load() (Config, error) {
 		ShipStationAPIKey: getEnv("SHIPSTATION_API_KEY", ""),
 		ShipStationSecret: getEnv("SHIPSTATION_SECRET", ""),
 		SendGridAPIKey:    getEnv("SENDGRID_API_KEY", ""),
+		AlphaVantageKey:   getEnv("ALPHA_VANTAGE_KEY", ""),
+		FinnhubKey:        getEnv("FINNHUB_KEY", ""),
 		SigningKey:        getEnv("SIGNING_KEY", "insecure-local-key"),
 	}
 
@@ -60,6 +125,118 @@ func Load() (Config, error) {
 	}
 	cfg.NewsPollInterval = pollDuration
 
+	cfg.QuoteProviders = splitAndClean(getEnv("QUOTE_PROVIDERS", "finnhub,alphavantage,coinbase,yahoo"))
+	cfg.FinnhubBaseURL = getEnv("FINNHUB_BASE_URL", "")
+	cfg.AlphaVantageBaseURL = getEnv("ALPHA_VANTAGE_BASE_URL", "")
+	cfg.YahooBaseURL = getEnv("YAHOO_BASE_URL", "")
+	cfg.CoinbaseBaseURL = getEnv("COINBASE_BASE_URL", "")
+	cfg.CryptoSymbols = splitAndClean(getEnv("CRYPTO_SYMBOLS", "BTC-USD,ETH-USD"))
+
+	cfg.PriceSymbols = splitAndClean(getEnv("PRICE_SYMBOLS", "^GSPC,SPY,AAPL,MSFT,NVDA,GOOGL,AMZN,META,TSLA,BRK.B,JPM,V"))
+
+	backfillStr := getEnv("PRICE_BACKFILL_INTERVAL", "6h")
+	backfillInterval, err := time.ParseDuration(backfillStr)
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid PRICE_BACKFILL_INTERVAL: %w", err)
+	}
+	cfg.PriceBackfillInterval = backfillInterval
+
+	historyDays, err := strconv.Atoi(getEnv("PRICE_HISTORY_DAYS", "400"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid PRICE_HISTORY_DAYS: %w", err)
+	}
+	cfg.PriceHistoryDays = historyDays
+
+	cfg.SnapshotUniverse = splitAndClean(getEnv("SNAPSHOT_UNIVERSE", "AAPL,MSFT,NVDA,GOOGL,AMZN,META,TSLA,BRK.B,JPM,V"))
+	cfg.SnapshotBenchmarks = splitAndClean(getEnv("SNAPSHOT_BENCHMARK", "SPY,^GSPC"))
+
+	riskFreeRate, err := strconv.ParseFloat(getEnv("RISK_FREE_RATE", "0.04"), 64)
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid RISK_FREE_RATE: %w", err)
+	}
+	cfg.RiskFreeRate = riskFreeRate
+
+	cfg.MoverUniverse = splitAndClean(getEnv("MOVERS_UNIVERSE", "NVDA,AAPL,MSFT,GOOGL,AMZN,META,TSLA,AMD,NFLX,JPM"))
+	cfg.MoverUniverseFile = getEnv("MOVERS_UNIVERSE_FILE", "data/universe/sp500.csv")
+
+	moverBatch, err := strconv.Atoi(getEnv("MOVERS_BATCH_SIZE", "15"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid MOVERS_BATCH_SIZE: %w", err)
+	}
+	cfg.MoverBatchSize = moverBatch
+
+	moverInterval, err := time.ParseDuration(getEnv("MOVERS_REFRESH_INTERVAL", "1m"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid MOVERS_REFRESH_INTERVAL: %w", err)
+	}
+	cfg.MoverRefreshInterval = moverInterval
+
+	cfg.SectorBenchmark = getEnv("SECTOR_BENCHMARK", "SPY")
+
+	streamInterval, err := time.ParseDuration(getEnv("QUOTE_STREAM_INTERVAL", "15s"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid QUOTE_STREAM_INTERVAL: %w", err)
+	}
+	cfg.QuoteStreamInterval = streamInterval
+
+	closedInterval, err := time.ParseDuration(getEnv("QUOTE_STREAM_CLOSED_INTERVAL", "5m"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid QUOTE_STREAM_CLOSED_INTERVAL: %w", err)
+	}
+	cfg.QuoteStreamClosedInterval = closedInterval
+
+	finnhubRate, err := strconv.ParseFloat(getEnv("FINNHUB_RATE_LIMIT", "55"), 64)
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid FINNHUB_RATE_LIMIT: %w", err)
+	}
+	cfg.FinnhubRateLimit = finnhubRate
+
+	alphaVantageRate, err := strconv.ParseFloat(getEnv("ALPHA_VANTAGE_RATE_LIMIT", "5"), 64)
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid ALPHA_VANTAGE_RATE_LIMIT: %w", err)
+	}
+	cfg.AlphaVantageRateLimit = alphaVantageRate
+
+	yahooRate, err := strconv.ParseFloat(getEnv("YAHOO_RATE_LIMIT", "60"), 64)
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid YAHOO_RATE_LIMIT: %w", err)
+	}
+	cfg.YahooRateLimit = yahooRate
+
+	coinbaseRate, err := strconv.ParseFloat(getEnv("COINBASE_RATE_LIMIT", "300"), 64)
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid COINBASE_RATE_LIMIT: %w", err)
+	}
+	cfg.CoinbaseRateLimit = coinbaseRate
+
+	concurrency, err := strconv.Atoi(getEnv("QUOTE_CONCURRENCY", "4"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid QUOTE_CONCURRENCY: %w", err)
+	}
+	cfg.QuoteConcurrency = concurrency
+
+	failureLimit, err := strconv.Atoi(getEnv("PROVIDER_FAILURE_THRESHOLD", "5"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid PROVIDER_FAILURE_THRESHOLD: %w", err)
+	}
+	cfg.ProviderFailureLimit = failureLimit
+
+	cooldown, err := time.ParseDuration(getEnv("PROVIDER_BREAKER_COOLDOWN", "1m"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid PROVIDER_BREAKER_COOLDOWN: %w", err)
+	}
+	cfg.ProviderBreakerCooldown = cooldown
+
+	cfg.FundamentalsDir = getEnv("FUNDAMENTALS_DIR", "data/companyfacts")
+	cfg.SymbolListingsDir = getEnv("SYMBOL_LISTINGS_DIR", "data/listings")
+	cfg.CorporateActionsDir = getEnv("CORPORATE_ACTIONS_DIR", "data/corporate_actions")
+	cfg.MacroDir = getEnv("MACRO_DIR", "data/macro")
+	cfg.TreasuryYieldsDir = getEnv("TREASURY_YIELDS_DIR", "data/treasury")
+	cfg.EarningsDir = getEnv("EARNINGS_DIR", "data/earnings")
+	cfg.OptionChainsDir = getEnv("OPTION_CHAINS_DIR", "data/options")
+	cfg.FXRatesDir = getEnv("FX_RATES_DIR", "data/fx")
+	cfg.ETFHoldingsDir = getEnv("ETF_HOLDINGS_DIR", "data/etf_holdings")
+
 	return cfg, nil
 }
 
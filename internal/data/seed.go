@@ -3,9 +3,11 @@ package data
 import (
     "context"
     "database/sql"
+    "errors"
     "time"
 
     "github.com/loganlanou/Financing-101/internal/database"
+    "github.com/loganlanou/Financing-101/internal/services"
     "log/slog"
 )
 
@@ -23,6 +25,12 @@ func EnsureSeedData(ctx context.Context, queries *database.Queries, log *slog.Lo
     if err := seedRecommendations(ctx, queries, log, uuidFn); err != nil {
         return err
     }
+    if err := seedSymbols(ctx, queries, log); err != nil {
+        return err
+    }
+    if err := seedCryptoSymbols(ctx, queries, log); err != nil {
+        return err
+    }
     return nil
 }
 
@@ -237,6 +245,88 @@ func seedRecommendations(ctx context.Context, queries *database.Queries, log *sl
     return nil
 }
 
+// seedSymbols covers the tickers the app quotes out of the box until a full
+// exchange listing is imported.
+func seedSymbols(ctx context.Context, queries *database.Queries, log *slog.Logger) error {
+    count, err := queries.CountSymbols(ctx)
+    if err == nil && count > 0 {
+        return nil
+    }
+
+    now := time.Now().UTC()
+    symbols := []database.UpsertSymbolParams{
+        {Symbol: "^GSPC", Name: "S&P 500", Exchange: "INDEX", Type: "index", Aliases: "S&P,SPX"},
+        {Symbol: "^DJI", Name: "Dow Jones Industrial Average", Exchange: "INDEX", Type: "index", Aliases: "Dow"},
+        {Symbol: "^IXIC", Name: "NASDAQ Composite", Exchange: "INDEX", Type: "index"},
+        {Symbol: "^RUT", Name: "Russell 2000", Exchange: "INDEX", Type: "index"},
+        {Symbol: "^VIX", Name: "CBOE Volatility Index", Exchange: "INDEX", Type: "index", Aliases: "VIX"},
+        {Symbol: "SPY", Name: "SPDR S&P 500 ETF Trust", Exchange: "NYSE Arca", Type: "etf"},
+        {Symbol: "QQQ", Name: "Invesco QQQ Trust", Exchange: "NASDAQ", Type: "etf"},
+        {Symbol: "AAPL", Name: "Apple Inc.", Exchange: "NASDAQ", Type: "stock", Sector: sqlNullString("Technology"), Aliases: "Apple"},
+        {Symbol: "MSFT", Name: "Microsoft Corporation", Exchange: "NASDAQ", Type: "stock", Sector: sqlNullString("Technology"), Aliases: "Microsoft"},
+        {Symbol: "NVDA", Name: "NVIDIA Corporation", Exchange: "NASDAQ", Type: "stock", Sector: sqlNullString("Technology"), Aliases: "Nvidia"},
+        {Symbol: "GOOGL", Name: "Alphabet Inc. Class A", Exchange: "NASDAQ", Type: "stock", Sector: sqlNullString("Communication Services"), Aliases: "Google,Alphabet"},
+        {Symbol: "AMZN", Name: "Amazon.com, Inc.", Exchange: "NASDAQ", Type: "stock", Sector: sqlNullString("Consumer Discretionary"), Aliases: "Amazon"},
+        {Symbol: "META", Name: "Meta Platforms, Inc.", Exchange: "NASDAQ", Type: "stock", Sector: sqlNullString("Communication Services"), Aliases: "Facebook,Meta Platforms"},
+        {Symbol: "TSLA", Name: "Tesla, Inc.", Exchange: "NASDAQ", Type: "stock", Sector: sqlNullString("Consumer Discretionary"), Aliases: "Tesla"},
+        {Symbol: "NFLX", Name: "Netflix, Inc.", Exchange: "NASDAQ", Type: "stock", Sector: sqlNullString("Communication Services"), Aliases: "Netflix"},
+        {Symbol: "AMD", Name: "Advanced Micro Devices, Inc.", Exchange: "NASDAQ", Type: "stock", Sector: sqlNullString("Technology")},
+        {Symbol: "INTC", Name: "Intel Corporation", Exchange: "NASDAQ", Type: "stock", Sector: sqlNullString("Technology"), Aliases: "Intel"},
+        {Symbol: "AVGO", Name: "Broadcom Inc.", Exchange: "NASDAQ", Type: "stock", Sector: sqlNullString("Technology"), Aliases: "Broadcom"},
+        {Symbol: "ORCL", Name: "Oracle Corporation", Exchange: "NYSE", Type: "stock", Sector: sqlNullString("Technology"), Aliases: "Oracle"},
+        {Symbol: "BRK.B", Name: "Berkshire Hathaway Inc. Class B", Exchange: "NYSE", Type: "stock", Sector: sqlNullString("Financials"), Aliases: "Berkshire"},
+        {Symbol: "JPM", Name: "JPMorgan Chase & Co.", Exchange: "NYSE", Type: "stock", Sector: sqlNullString("Financials"), Aliases: "JPMorgan"},
+        {Symbol: "BAC", Name: "Bank of America Corporation", Exchange: "NYSE", Type: "stock", Sector: sqlNullString("Financials")},
+        {Symbol: "V", Name: "Visa Inc.", Exchange: "NYSE", Type: "stock", Sector: sqlNullString("Financials"), Aliases: "Visa"},
+        {Symbol: "XOM", Name: "Exxon Mobil Corporation", Exchange: "NYSE", Type: "stock", Sector: sqlNullString("Energy"), Aliases: "Exxon"},
+        {Symbol: "LMT", Name: "Lockheed Martin Corporation", Exchange: "NYSE", Type: "stock", Sector: sqlNullString("Industrials"), Aliases: "Lockheed"},
+    }
+
+    for _, symbol := range symbols {
+        symbol.Active = true
+        symbol.UpdatedAt = now
+        if err := queries.UpsertSymbol(ctx, symbol); err != nil {
+            return err
+        }
+    }
+
+    log.Info("seeded symbols")
+    return nil
+}
+
+// seedCryptoSymbols adds the known coins to the symbol master, including
+// databases seeded before coins were supported. Existing rows are kept.
+func seedCryptoSymbols(ctx context.Context, queries *database.Queries, log *slog.Logger) error {
+    now := time.Now().UTC()
+    added := 0
+    for _, asset := range services.CryptoAssets {
+        _, err := queries.GetSymbol(ctx, asset.Symbol)
+        if err == nil {
+            continue
+        }
+        if !errors.Is(err, sql.ErrNoRows) {
+            return err
+        }
+        if err := queries.UpsertSymbol(ctx, database.UpsertSymbolParams{
+            Symbol:    asset.Symbol,
+            Name:      asset.Name,
+            Exchange:  "CRYPTO",
+            Type:      "crypto",
+            Aliases:   asset.Name,
+            Active:    true,
+            UpdatedAt: now,
+        }); err != nil {
+            return err
+        }
+        added++
+    }
+
+    if added > 0 {
+        log.Info("seeded crypto symbols", slog.Int("symbols", added))
+    }
+    return nil
+}
+
 func sqlNullString(value string) sql.NullString {
     if value == "" {
         return sql.NullString{}
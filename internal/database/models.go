@@ -9,6 +9,16 @@ import (
 	"time"
 )
 
+type CompanyProfile struct {
+	Symbol    string
+	Cik       string
+	Name      string
+	Sector    sql.NullString
+	Industry  sql.NullString
+	Source    string
+	UpdatedAt time.Time
+}
+
 type CongressTrade struct {
 	ID             string
 	Member         string
@@ -23,6 +33,73 @@ type CongressTrade struct {
 	SourceUrl      sql.NullString
 }
 
+type CorporateAction struct {
+	Symbol    string
+	Type      string
+	ExDate    time.Time
+	Ratio     float64
+	Amount    float64
+	NewSymbol string
+	Source    string
+	UpdatedAt time.Time
+}
+
+type EarningsEvent struct {
+	Symbol          string
+	ReportDate      time.Time
+	Timing          string
+	FiscalPeriod    string
+	EpsEstimate     sql.NullFloat64
+	EpsActual       sql.NullFloat64
+	RevenueEstimate sql.NullFloat64
+	RevenueActual   sql.NullFloat64
+	Source          string
+	UpdatedAt       time.Time
+}
+
+type EtfHolding struct {
+	EtfSymbol   string
+	Position    int64
+	Symbol      string
+	Name        string
+	Sector      sql.NullString
+	AssetClass  string
+	Weight      float64
+	Shares      sql.NullFloat64
+	MarketValue sql.NullFloat64
+	AsOf        time.Time
+	Source      string
+}
+
+type Fundamental struct {
+	Symbol              string
+	PeriodEnd           time.Time
+	FiscalYear          int64
+	FiscalPeriod        string
+	Form                string
+	FiledAt             time.Time
+	Revenue             sql.NullFloat64
+	GrossProfit         sql.NullFloat64
+	OperatingIncome     sql.NullFloat64
+	NetIncome           sql.NullFloat64
+	EpsDiluted          sql.NullFloat64
+	TotalAssets         sql.NullFloat64
+	TotalLiabilities    sql.NullFloat64
+	StockholdersEquity  sql.NullFloat64
+	Cash                sql.NullFloat64
+	LongTermDebt        sql.NullFloat64
+	SharesOutstanding   sql.NullFloat64
+	OperatingCashFlow   sql.NullFloat64
+	CapitalExpenditures sql.NullFloat64
+}
+
+type FxRate struct {
+	Currency string
+	RateDate time.Time
+	Rate     float64
+	Source   string
+}
+
 type GlossaryTerm struct {
 	ID         string
 	Term       string
@@ -60,6 +137,29 @@ type Lesson struct {
 	CreatedAt time.Time
 }
 
+type MacroObservation struct {
+	SeriesID string
+	ObsDate  time.Time
+	Value    float64
+}
+
+type MacroRelease struct {
+	Name      string
+	ReleaseAt time.Time
+	SeriesID  string
+	Period    string
+	Source    string
+}
+
+type MacroSeries struct {
+	ID        string
+	Title     string
+	Units     string
+	Frequency string
+	Source    string
+	UpdatedAt time.Time
+}
+
 type NewsArticle struct {
 	ID             string
 	Title          string
@@ -72,6 +172,39 @@ type NewsArticle struct {
 	PublishedAt    time.Time
 }
 
+type OptionContract struct {
+	Symbol       string
+	Expiration   time.Time
+	Kind         string
+	Strike       float64
+	Bid          sql.NullFloat64
+	Ask          sql.NullFloat64
+	Last         sql.NullFloat64
+	Volume       int64
+	OpenInterest int64
+	ImpliedVol   sql.NullFloat64
+	AsOf         time.Time
+	Source       string
+}
+
+type PriceBar struct {
+	Symbol   string
+	Interval string
+	BarTime  time.Time
+	Open     float64
+	High     float64
+	Low      float64
+	Close    float64
+	Volume   int64
+	Source   string
+}
+
+type PriceCoverage struct {
+	Symbol      string
+	Interval    string
+	CoveredFrom time.Time
+}
+
 type Recommendation struct {
 	ID         string
 	Symbol     string
@@ -82,19 +215,69 @@ type Recommendation struct {
 	CreatedAt  time.Time
 }
 
+type RiskRolling struct {
+	Symbol      string
+	WindowBars  int64
+	BarTime     time.Time
+	Benchmark   string
+	Volatility  float64
+	MaxDrawdown float64
+	Beta        sql.NullFloat64
+	Correlation sql.NullFloat64
+	Sharpe      sql.NullFloat64
+	Sortino     sql.NullFloat64
+}
+
+type RiskStat struct {
+	Symbol       string
+	WindowDays   int64
+	Benchmark    string
+	Observations int64
+	Volatility   float64
+	MaxDrawdown  float64
+	Beta         sql.NullFloat64
+	Correlation  sql.NullFloat64
+	Sharpe       sql.NullFloat64
+	Sortino      sql.NullFloat64
+	AsOf         time.Time
+	UpdatedAt    time.Time
+}
+
 type StockSnapshot struct {
-	ID         string
-	Symbol     string
-	Name       string
-	Sector     sql.NullString
-	Industry   sql.NullString
-	Change30   float64
-	Change90   float64
-	Change365  float64
-	VsSp50030  float64
-	VsSp50090  float64
-	VsSp500365 float64
-	Conviction string
-	Thesis     string
-	UpdatedAt  time.Time
+	ID           string
+	Symbol       string
+	Name         string
+	Sector       sql.NullString
+	Industry     sql.NullString
+	Change30     float64
+	Change90     float64
+	Change365    float64
+	VsSp50030    float64
+	VsSp50090    float64
+	VsSp500365   float64
+	Conviction   string
+	Thesis       string
+	UpdatedAt    time.Time
+	Currency     string
+	UsdChange30  sql.NullFloat64
+	UsdChange90  sql.NullFloat64
+	UsdChange365 sql.NullFloat64
+}
+
+type Symbol struct {
+	Symbol    string
+	Name      string
+	Exchange  string
+	Type      string
+	Sector    sql.NullString
+	Aliases   string
+	Active    bool
+	UpdatedAt time.Time
+}
+
+type TreasuryYield struct {
+	CurveDate   time.Time
+	TenorMonths float64
+	Yield       float64
+	Source      string
 }
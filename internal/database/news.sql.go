@@ -90,3 +90,49 @@ func (q *Queries) ListLatestNews(ctx context.Context, limit int64) ([]NewsArticl
 	}
 	return items, nil
 }
+
+const listNewsBySymbol = `-- name: ListNewsBySymbol :many
+SELECT id, title, source, summary, sentiment_score, trend, tickers, url, published_at
+FROM news_articles
+WHERE ',' || REPLACE(tickers, ' ', '') || ',' LIKE '%,' || ?1 || ',%'
+ORDER BY published_at DESC
+LIMIT ?2
+`
+
+type ListNewsBySymbolParams struct {
+	Symbol interface{}
+	Limit  int64
+}
+
+func (q *Queries) ListNewsBySymbol(ctx context.Context, arg ListNewsBySymbolParams) ([]NewsArticle, error) {
+	rows, err := q.db.QueryContext(ctx, listNewsBySymbol, arg.Symbol, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NewsArticle
+	for rows.Next() {
+		var i NewsArticle
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Source,
+			&i.Summary,
+			&i.SentimentScore,
+			&i.Trend,
+			&i.Tickers,
+			&i.Url,
+			&i.PublishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
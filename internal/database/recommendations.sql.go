@@ -83,3 +83,47 @@ func (q *Queries) ListRecommendations(ctx context.Context, limit int64) ([]Recom
 	}
 	return items, nil
 }
+
+const listRecommendationsBySymbol = `-- name: ListRecommendationsBySymbol :many
+SELECT id, symbol, thesis, conviction, score, catalyst, created_at
+FROM recommendations
+WHERE symbol = ?1
+ORDER BY created_at DESC
+LIMIT ?2
+`
+
+type ListRecommendationsBySymbolParams struct {
+	Symbol string
+	Limit  int64
+}
+
+func (q *Queries) ListRecommendationsBySymbol(ctx context.Context, arg ListRecommendationsBySymbolParams) ([]Recommendation, error) {
+	rows, err := q.db.QueryContext(ctx, listRecommendationsBySymbol, arg.Symbol, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Recommendation
+	for rows.Next() {
+		var i Recommendation
+		if err := rows.Scan(
+			&i.ID,
+			&i.Symbol,
+			&i.Thesis,
+			&i.Conviction,
+			&i.Score,
+			&i.Catalyst,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
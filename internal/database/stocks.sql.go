@@ -11,11 +11,48 @@ import (
 	"time"
 )
 
+const getStockSnapshotBySymbol = `-- name: GetStockSnapshotBySymbol :one
+SELECT id, symbol, name, sector, industry, change_30, change_90, change_365,
+       vs_sp500_30, vs_sp500_90, vs_sp500_365, conviction, thesis, updated_at,
+       currency, usd_change_30, usd_change_90, usd_change_365
+FROM stock_snapshots
+WHERE symbol = ?1
+ORDER BY updated_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetStockSnapshotBySymbol(ctx context.Context, symbol string) (StockSnapshot, error) {
+	row := q.db.QueryRowContext(ctx, getStockSnapshotBySymbol, symbol)
+	var i StockSnapshot
+	err := row.Scan(
+		&i.ID,
+		&i.Symbol,
+		&i.Name,
+		&i.Sector,
+		&i.Industry,
+		&i.Change30,
+		&i.Change90,
+		&i.Change365,
+		&i.VsSp50030,
+		&i.VsSp50090,
+		&i.VsSp500365,
+		&i.Conviction,
+		&i.Thesis,
+		&i.UpdatedAt,
+		&i.Currency,
+		&i.UsdChange30,
+		&i.UsdChange90,
+		&i.UsdChange365,
+	)
+	return i, err
+}
+
 const insertStockSnapshot = `-- name: InsertStockSnapshot :exec
 INSERT INTO stock_snapshots (
     id, symbol, name, sector, industry, change_30, change_90, change_365,
-    vs_sp500_30, vs_sp500_90, vs_sp500_365, conviction, thesis, updated_at
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    vs_sp500_30, vs_sp500_90, vs_sp500_365, conviction, thesis, updated_at,
+    currency, usd_change_30, usd_change_90, usd_change_365
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(id) DO UPDATE SET
     symbol=excluded.symbol,
     name=excluded.name,
@@ -29,24 +66,32 @@ ON CONFLICT(id) DO UPDATE SET
     vs_sp500_365=excluded.vs_sp500_365,
     conviction=excluded.conviction,
     thesis=excluded.thesis,
-    updated_at=excluded.updated_at
+    updated_at=excluded.updated_at,
+    currency=excluded.currency,
+    usd_change_30=excluded.usd_change_30,
+    usd_change_90=excluded.usd_change_90,
+    usd_change_365=excluded.usd_change_365
 `
 
 type InsertStockSnapshotParams struct {
-	ID         string
-	Symbol     string
-	Name       string
-	Sector     sql.NullString
-	Industry   sql.NullString
-	Change30   float64
-	Change90   float64
-	Change365  float64
-	VsSp50030  float64
-	VsSp50090  float64
-	VsSp500365 float64
-	Conviction string
-	Thesis     string
-	UpdatedAt  time.Time
+	ID           string
+	Symbol       string
+	Name         string
+	Sector       sql.NullString
+	Industry     sql.NullString
+	Change30     float64
+	Change90     float64
+	Change365    float64
+	VsSp50030    float64
+	VsSp50090    float64
+	VsSp500365   float64
+	Conviction   string
+	Thesis       string
+	UpdatedAt    time.Time
+	Currency     string
+	UsdChange30  sql.NullFloat64
+	UsdChange90  sql.NullFloat64
+	UsdChange365 sql.NullFloat64
 }
 
 func (q *Queries) InsertStockSnapshot(ctx context.Context, arg InsertStockSnapshotParams) error {
@@ -65,13 +110,18 @@ func (q *Queries) InsertStockSnapshot(ctx context.Context, arg InsertStockSnapsh
 		arg.Conviction,
 		arg.Thesis,
 		arg.UpdatedAt,
+		arg.Currency,
+		arg.UsdChange30,
+		arg.UsdChange90,
+		arg.UsdChange365,
 	)
 	return err
 }
 
 const listStockSnapshots = `-- name: ListStockSnapshots :many
 SELECT id, symbol, name, sector, industry, change_30, change_90, change_365,
-       vs_sp500_30, vs_sp500_90, vs_sp500_365, conviction, thesis, updated_at
+       vs_sp500_30, vs_sp500_90, vs_sp500_365, conviction, thesis, updated_at,
+       currency, usd_change_30, usd_change_90, usd_change_365
 FROM stock_snapshots
 ORDER BY vs_sp500_90 DESC
 LIMIT ?1
@@ -101,6 +151,10 @@ func (q *Queries) ListStockSnapshots(ctx context.Context, limit int64) ([]StockS
 			&i.Conviction,
 			&i.Thesis,
 			&i.UpdatedAt,
+			&i.Currency,
+			&i.UsdChange30,
+			&i.UsdChange90,
+			&i.UsdChange365,
 		); err != nil {
 			return nil, err
 		}
@@ -100,3 +100,51 @@ func (q *Queries) ListRecentTrades(ctx context.Context, limit int64) ([]Congress
 	}
 	return items, nil
 }
+
+const listTradesBySymbol = `-- name: ListTradesBySymbol :many
+SELECT id, member, party, chamber, symbol, action, amount, executed_at, disclosure_date, sentiment, source_url
+FROM congress_trades
+WHERE symbol = ?1
+ORDER BY executed_at DESC
+LIMIT ?2
+`
+
+type ListTradesBySymbolParams struct {
+	Symbol string
+	Limit  int64
+}
+
+func (q *Queries) ListTradesBySymbol(ctx context.Context, arg ListTradesBySymbolParams) ([]CongressTrade, error) {
+	rows, err := q.db.QueryContext(ctx, listTradesBySymbol, arg.Symbol, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CongressTrade
+	for rows.Next() {
+		var i CongressTrade
+		if err := rows.Scan(
+			&i.ID,
+			&i.Member,
+			&i.Party,
+			&i.Chamber,
+			&i.Symbol,
+			&i.Action,
+			&i.Amount,
+			&i.ExecutedAt,
+			&i.DisclosureDate,
+			&i.Sentiment,
+			&i.SourceUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// maxPositions caps how many positions one look-through request may list
+const maxPositions = 50
+
+// ETFHandler serves stored ETF holdings and look-through exposure
+type ETFHandler struct {
+	log  *slog.Logger
+	etfs *services.ETFService
+}
+
+func NewETFHandler(log *slog.Logger, etfs *services.ETFService) *ETFHandler {
+	return &ETFHandler{log: log, etfs: etfs}
+}
+
+func (h *ETFHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/etfs", h.list)
+	e.GET("/api/etfs/lookthrough", h.lookThrough)
+	e.GET("/api/etfs/:symbol/holdings", h.holdings)
+}
+
+// list returns the funds with a stored holdings file
+func (h *ETFHandler) list(c echo.Context) error {
+	funds, err := h.etfs.List(c.Request().Context())
+	if err != nil {
+		h.log.Error("failed to list etfs", slog.Any("err", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "etf holdings unavailable")
+	}
+	return c.JSON(http.StatusOK, map[string]any{"funds": funds})
+}
+
+// holdings returns one fund's holdings, largest weight first
+func (h *ETFHandler) holdings(c echo.Context) error {
+	symbol := strings.ToUpper(strings.TrimSpace(c.Param("symbol")))
+
+	fund, err := h.etfs.Holdings(c.Request().Context(), symbol)
+	if errors.Is(err, services.ErrNoHoldings) {
+		return echo.NewHTTPError(http.StatusNotFound, "no holdings stored for "+symbol)
+	}
+	if err != nil {
+		h.log.Error("failed to load etf holdings", slog.String("symbol", symbol), slog.Any("err", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "etf holdings unavailable")
+	}
+	return c.JSON(http.StatusOK, fund)
+}
+
+// lookThrough decomposes ?positions=SPY:6000,QQQ:4000,AAPL:1500 into the
+// underlying stock and sector exposure
+func (h *ETFHandler) lookThrough(c echo.Context) error {
+	positions, err := parsePositions(c.QueryParam("positions"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if len(positions) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "positions is required")
+	}
+
+	exposure, err := h.etfs.LookThrough(c.Request().Context(), positions)
+	if err != nil {
+		h.log.Error("look-through failed", slog.Any("err", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "look-through unavailable")
+	}
+	return c.JSON(http.StatusOK, exposure)
+}
+
+// parsePositions reads positions written SYMBOL:VALUE or SYMBOL VALUE,
+// separated by commas or new lines. A symbol without a value counts as 1,
+// so a bare list of symbols is weighted equally
+func parsePositions(raw string) ([]services.Position, error) {
+	var positions []services.Position
+	for _, line := range strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || r == '\n' || r == ';' }) {
+		fields := strings.Fields(strings.ReplaceAll(line, ":", " "))
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) > 2 {
+			return nil, fmt.Errorf("position %q must be a symbol and a value", strings.TrimSpace(line))
+		}
+
+		p := services.Position{Symbol: strings.ToUpper(fields[0]), Value: 1}
+		if len(fields) == 2 {
+			v, err := strconv.ParseFloat(strings.TrimPrefix(fields[1], "$"), 64)
+			if err != nil || math.IsNaN(v) || math.IsInf(v, 0) || v <= 0 {
+				return nil, fmt.Errorf("position %q must have a positive value", strings.TrimSpace(line))
+			}
+			p.Value = v
+		}
+		positions = append(positions, p)
+		if len(positions) > maxPositions {
+			return nil, fmt.Errorf("at most %d positions are allowed", maxPositions)
+		}
+	}
+	return positions, nil
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// currencyCookie remembers the display currency last picked with ?currency=
+const currencyCookie = "currency"
+
+// FXHandler serves stored exchange rates
+type FXHandler struct {
+	log *slog.Logger
+	fx  *services.FXService
+}
+
+func NewFXHandler(log *slog.Logger, fx *services.FXService) *FXHandler {
+	return &FXHandler{log: log, fx: fx}
+}
+
+func (h *FXHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/fx/rates", h.rates)
+}
+
+// rates returns the latest rate per currency on ?date=YYYY-MM-DD (today by
+// default) in units per one ?base= (USD by default)
+func (h *FXHandler) rates(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	base := services.USD
+	if raw := c.QueryParam("base"); raw != "" {
+		code, ok := services.NormalizeCurrency(raw)
+		if !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, "base must be a three-letter currency code")
+		}
+		base = code
+	}
+	asOf := time.Now()
+	if raw := c.QueryParam("date"); raw != "" {
+		t, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "date must be a YYYY-MM-DD date")
+		}
+		asOf = t
+	}
+
+	latest, err := h.fx.Latest(ctx, asOf)
+	if err != nil {
+		h.log.Error("failed to list fx rates", slog.Any("err", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "fx rates unavailable")
+	}
+	if base != services.USD {
+		baseRate, err := h.fx.Rate(ctx, services.USD, base, asOf)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusNotFound, "no fx rate for "+base)
+		}
+		for i := range latest {
+			latest[i].Rate /= baseRate
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"base":  base,
+		"asOf":  asOf.Format("2006-01-02"),
+		"rates": latest,
+	})
+}
+
+// displayCurrency is the currency pages show prices in: ?currency= when
+// given, which is remembered in a cookie, then the cookie, then dollars
+func displayCurrency(c echo.Context) string {
+	if raw := c.QueryParam("currency"); raw != "" {
+		if code, ok := services.NormalizeCurrency(raw); ok {
+			c.SetCookie(&http.Cookie{
+				Name:     currencyCookie,
+				Value:    code,
+				Path:     "/",
+				MaxAge:   int((365 * 24 * time.Hour).Seconds()),
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+			return code
+		}
+	}
+	if cookie, err := c.Cookie(currencyCookie); err == nil {
+		if code, ok := services.NormalizeCurrency(cookie.Value); ok {
+			return code
+		}
+	}
+	return services.USD
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// HealthHandler exposes dependency health for monitoring
+type HealthHandler struct {
+	log        *slog.Logger
+	marketData *services.MarketDataService
+}
+
+func NewHealthHandler(log *slog.Logger, marketData *services.MarketDataService) *HealthHandler {
+	return &HealthHandler{log: log, marketData: marketData}
+}
+
+func (h *HealthHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/healthz/market-data", h.marketDataHealth)
+}
+
+// marketDataHealth reports "ok" while every provider's circuit is closed,
+// "degraded" while at least one is, and "down" with none available (503)
+func (h *HealthHandler) marketDataHealth(c echo.Context) error {
+	providers := h.marketData.ProviderHealth()
+
+	closed := 0
+	for _, p := range providers {
+		if p.State == services.CircuitClosed {
+			closed++
+		}
+	}
+
+	status, code := "ok", http.StatusOK
+	switch {
+	case closed == 0:
+		status, code = "down", http.StatusServiceUnavailable
+	case closed < len(providers):
+		status = "degraded"
+	}
+
+	return c.JSON(code, map[string]any{
+		"status":       status,
+		"marketStatus": h.marketData.GetMarketStatus(),
+		"providers":    providers,
+		"checkedAt":    time.Now().UTC(),
+	})
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/loganlanou/Financing-101/internal/indicators"
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// defaultIndicatorSet is computed when ?set= is omitted
+const defaultIndicatorSet = "sma20,sma50,rsi14,macd"
+
+// IndicatorsHandler serves technical indicators computed over price history
+type IndicatorsHandler struct {
+	log        *slog.Logger
+	marketData *services.MarketDataService
+}
+
+func NewIndicatorsHandler(log *slog.Logger, marketData *services.MarketDataService) *IndicatorsHandler {
+	return &IndicatorsHandler{log: log, marketData: marketData}
+}
+
+func (h *IndicatorsHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/stocks/:symbol/indicators", h.indicators)
+}
+
+// indicators computes ?set= (e.g. "sma20,ema50,bb20,rsi,macd,atr,vwap,obv")
+// over the ?period= chart range, which defaults to 1Y
+func (h *IndicatorsHandler) indicators(c echo.Context) error {
+	symbol := strings.ToUpper(strings.TrimSpace(c.Param("symbol")))
+
+	period := strings.ToUpper(c.QueryParam("period"))
+	if period == "" {
+		period = "1Y"
+	}
+	if !services.IsHistoryPeriod(period) {
+		return echo.NewHTTPError(http.StatusBadRequest, "period must be one of 1D, 5D, 1M, 3M, 6M, 1Y, 5Y")
+	}
+
+	set := c.QueryParam("set")
+	if strings.TrimSpace(set) == "" {
+		set = defaultIndicatorSet
+	}
+	specs, err := indicators.ParseSet(set)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	history, err := h.marketData.GetHistoricalData(c.Request().Context(), symbol, period)
+	if err != nil {
+		h.log.Warn("indicator history unavailable", slog.String("symbol", symbol), slog.Any("err", err))
+		return echo.NewHTTPError(http.StatusNotFound, "no price history for "+symbol)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"symbol":     symbol,
+		"period":     period,
+		"bars":       len(history),
+		"indicators": indicators.Compute(history, specs),
+	})
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/loganlanou/Financing-101/internal/macro"
+)
+
+// defaultMacroYears is how much history a series request returns without ?from=
+const defaultMacroYears = 5
+
+// MacroHandler serves imported economic time series
+type MacroHandler struct {
+	log   *slog.Logger
+	macro *macro.Service
+}
+
+func NewMacroHandler(log *slog.Logger, service *macro.Service) *MacroHandler {
+	return &MacroHandler{log: log, macro: service}
+}
+
+func (h *MacroHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/macro/:series", h.series)
+}
+
+// series returns a FRED series such as CPIAUCSL between ?from= and ?to=
+// (YYYY-MM-DD), defaulting to the last five years
+func (h *MacroHandler) series(c echo.Context) error {
+	id := strings.ToUpper(strings.TrimSpace(c.Param("series")))
+
+	to := time.Now().UTC()
+	if raw := c.QueryParam("to"); raw != "" {
+		t, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "to must be a YYYY-MM-DD date")
+		}
+		to = t
+	}
+	from := to.AddDate(-defaultMacroYears, 0, 0)
+	if raw := c.QueryParam("from"); raw != "" {
+		t, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "from must be a YYYY-MM-DD date")
+		}
+		from = t
+	}
+	if from.After(to) {
+		return echo.NewHTTPError(http.StatusBadRequest, "from must not be after to")
+	}
+
+	data, err := h.macro.Get(c.Request().Context(), id, from, to)
+	if errors.Is(err, macro.ErrUnknownSeries) {
+		return echo.NewHTTPError(http.StatusNotFound, "unknown series "+id)
+	}
+	if err != nil {
+		h.log.Error("load macro series failed", slog.String("series", id), slog.Any("err", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "macro series unavailable")
+	}
+
+	return c.JSON(http.StatusOK, data)
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// MoversHandler serves the ranked market movers as JSON
+type MoversHandler struct {
+	log    *slog.Logger
+	movers *services.MoversService
+}
+
+func NewMoversHandler(log *slog.Logger, movers *services.MoversService) *MoversHandler {
+	return &MoversHandler{log: log, movers: movers}
+}
+
+func (h *MoversHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/market/movers", h.list)
+}
+
+// list returns gainers, losers and most active from the latest refresh;
+// ?cap= picks a market cap bucket and ?limit= sizes each list
+func (h *MoversHandler) list(c echo.Context) error {
+	filter := services.MoverFilter{Cap: c.QueryParam("cap")}
+	if filter.Cap != "" {
+		if _, ok := services.FindCapBucket(filter.Cap); !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, "cap must be one of mega, large, mid, small, micro")
+		}
+	}
+	if raw := c.QueryParam("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "limit must be a positive integer")
+		}
+		filter.Limit = min(n, services.MaxMoverLimit)
+	}
+
+	movers, err := h.movers.Movers(c.Request().Context(), filter)
+	if err != nil {
+		h.log.Error("market movers failed", slog.Any("err", err))
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "market movers unavailable")
+	}
+
+	return c.JSON(http.StatusOK, movers)
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/loganlanou/Financing-101/internal/options"
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// OptionsHandler serves stored option chains with Black-Scholes analytics
+type OptionsHandler struct {
+	log        *slog.Logger
+	options    *options.Service
+	marketData *services.MarketDataService
+	curves     *services.YieldCurveService
+}
+
+func NewOptionsHandler(log *slog.Logger, chains *options.Service, marketData *services.MarketDataService, curves *services.YieldCurveService) *OptionsHandler {
+	return &OptionsHandler{log: log, options: chains, marketData: marketData, curves: curves}
+}
+
+func (h *OptionsHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/options/:symbol", h.chain)
+}
+
+// chain returns one expiration (?expiration=YYYY-MM-DD, the nearest by
+// default) with each contract's implied volatility and greeks, solved
+// against the current quote and the 3-month bill rate
+func (h *OptionsHandler) chain(c echo.Context) error {
+	ctx := c.Request().Context()
+	symbol := strings.ToUpper(strings.TrimSpace(c.Param("symbol")))
+
+	var expiration time.Time
+	if raw := c.QueryParam("expiration"); raw != "" {
+		t, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "expiration must be a YYYY-MM-DD date")
+		}
+		expiration = t
+	}
+
+	quote, err := h.marketData.GetQuote(ctx, symbol)
+	if err != nil {
+		h.log.Warn("failed to quote option underlying", slog.String("symbol", symbol), slog.Any("err", err))
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "no quote for "+symbol)
+	}
+
+	chain, err := h.options.Chain(ctx, symbol, expiration, quote.Price, riskFreeRate(ctx, h.log, h.curves))
+	if errors.Is(err, options.ErrNoChain) {
+		return echo.NewHTTPError(http.StatusNotFound, "no option chain for "+symbol)
+	}
+	if err != nil {
+		h.log.Error("load option chain failed", slog.String("symbol", symbol), slog.Any("err", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "option chain unavailable")
+	}
+
+	return c.JSON(http.StatusOK, chain)
+}
+
+// riskFreeRate is the latest 3-month Treasury bill yield as a fraction, or
+// options.DefaultRate when no curve is stored
+func riskFreeRate(ctx context.Context, log *slog.Logger, curves *services.YieldCurveService) float64 {
+	rate, ok, err := curves.BillRate(ctx)
+	if err != nil {
+		log.Warn("failed to get bill rate", slog.Any("err", err))
+	}
+	if !ok {
+		return options.DefaultRate
+	}
+	return rate / 100
+}
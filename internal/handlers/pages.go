@@ -1,10 +1,23 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/loganlanou/Financing-101/internal/indicators"
+	"github.com/loganlanou/Financing-101/internal/macro"
+	"github.com/loganlanou/Financing-101/internal/marketcalendar"
+	"github.com/loganlanou/Financing-101/internal/options"
 	"github.com/loganlanou/Financing-101/internal/services"
 	"github.com/loganlanou/Financing-101/web/components"
 	"github.com/loganlanou/Financing-101/web/components/pages"
@@ -19,6 +32,20 @@ type PagesHandler struct {
 	tradeService *services.TradeService
 	recService   *services.RecommendationService
 	learnService *services.LearnService
+	marketData   *services.MarketDataService
+	fundamentals *services.FundamentalsService
+	symbols      *services.SymbolService
+	risk         *services.RiskService
+	movers       *services.MoversService
+	sectors      *services.SectorService
+	macro        *macro.Service
+	yieldCurve   *services.YieldCurveService
+	earnings     *services.EarningsService
+	options      *options.Service
+	fx           *services.FXService
+	etfs         *services.ETFService
+	// crypto are coin pairs listed on /stocks after the equities.
+	crypto []string
 }
 
 func NewPagesHandler(
@@ -28,6 +55,19 @@ func NewPagesHandler(
 	tradeService *services.TradeService,
 	recService *services.RecommendationService,
 	learnService *services.LearnService,
+	marketData *services.MarketDataService,
+	fundamentals *services.FundamentalsService,
+	symbols *services.SymbolService,
+	risk *services.RiskService,
+	movers *services.MoversService,
+	sectors *services.SectorService,
+	macroService *macro.Service,
+	yieldCurve *services.YieldCurveService,
+	earnings *services.EarningsService,
+	optionChains *options.Service,
+	fx *services.FXService,
+	etfs *services.ETFService,
+	crypto []string,
 ) *PagesHandler {
 	return &PagesHandler{
 		log:          log,
@@ -36,6 +76,19 @@ func NewPagesHandler(
 		tradeService: tradeService,
 		recService:   recService,
 		learnService: learnService,
+		marketData:   marketData,
+		fundamentals: fundamentals,
+		symbols:      symbols,
+		risk:         risk,
+		movers:       movers,
+		sectors:      sectors,
+		macro:        macroService,
+		yieldCurve:   yieldCurve,
+		earnings:     earnings,
+		options:      optionChains,
+		fx:           fx,
+		etfs:         etfs,
+		crypto:       crypto,
 	}
 }
 
@@ -43,25 +96,55 @@ func (h *PagesHandler) RegisterRoutes(e *echo.Echo) {
 	e.GET("/", h.dashboard)
 	e.GET("/markets", h.markets)
 	e.GET("/stocks", h.stocks)
+	e.GET("/stocks/:symbol", h.stockDetail)
+	e.GET("/etfs/:symbol", h.etfDetail)
+	e.GET("/earnings", h.earningsCalendar)
 	e.GET("/news", h.news)
 	e.GET("/congress", h.congress)
 	e.GET("/learn", h.learn)
 	e.GET("/learn/glossary", h.glossary)
 	e.GET("/learn/:moduleID", h.moduleDetail)
 	e.GET("/ai", h.aiInsights)
+	e.GET("/tools", h.tools)
+	e.GET("/tools/options", h.optionsTool)
+	e.GET("/tools/lookthrough", h.lookThroughTool)
 }
 
 func (h *PagesHandler) dashboard(c echo.Context) error {
 	reqCtx := c.Request().Context()
 
 	var (
-		news   []services.NewsHeadline
-		trades []services.Trade
-		recs   []services.Recommendation
+		news            []services.NewsHeadline
+		trades          []services.Trade
+		recs            []services.Recommendation
+		indices         []services.IndexQuote
+		gainers, losers []services.StockQuote
+		coverage        services.QuoteCoverage
 	)
 
 	g, ctx := errgroup.WithContext(reqCtx)
 
+	// Market data failures degrade to empty panels instead of failing the page
+	g.Go(func() error {
+		data, err := h.marketData.GetIndices(ctx)
+		if err != nil {
+			h.log.Warn("failed to get indices", slog.Any("err", err))
+			return nil
+		}
+		indices = data
+		return nil
+	})
+
+	g.Go(func() error {
+		movers, err := h.movers.Movers(ctx, services.MoverFilter{Limit: 5})
+		if err != nil {
+			h.log.Warn("failed to get market movers", slog.Any("err", err))
+			return nil
+		}
+		gainers, losers, coverage = movers.Gainers, movers.Losers, movers.Coverage
+		return nil
+	})
+
 	g.Go(func() error {
 		data, err := h.newsService.Latest(ctx, 3)
 		if err != nil {
@@ -93,9 +176,7 @@ func (h *PagesHandler) dashboard(c echo.Context) error {
 		h.log.Error("dashboard aggregation failed", slog.Any("err", err))
 	}
 
-	indices := getMockIndices()
-	movers := getMockGainersLosers()
-	marketStatus := getMarketStatus()
+	marketStatus := marketcalendar.Status(time.Now())
 
 	// Get today's learning tip
 	var learningTip components.LearningTip
@@ -120,14 +201,15 @@ func (h *PagesHandler) dashboard(c echo.Context) error {
 
 	data := pages.DashboardData{
 		Indices:         indices,
-		TopGainers:      movers.gainers,
-		TopLosers:       movers.losers,
+		TopGainers:      gainers,
+		TopLosers:       losers,
 		RecentNews:      news,
 		CongressTrades:  trades,
 		Recommendations: recs,
 		MarketStatus:    marketStatus,
-		LastUpdated:     time.Now(),
+		LastUpdated:     time.Now().In(marketcalendar.Location()),
 		LearningTip:     learningTip,
+		DataSource:      describeDataSource(indices, append(gainers, losers...), coverage),
 	}
 
 	page := pages.DashboardPage(data)
@@ -138,17 +220,53 @@ func (h *PagesHandler) dashboard(c echo.Context) error {
 func (h *PagesHandler) markets(c echo.Context) error {
 	reqCtx := c.Request().Context()
 
-	indices := getMockIndices()
-	sectors := getMockSectors()
-	movers := getMockGainersLosers()
+	// ?cap= narrows the movers to one market cap bucket
+	capBucket := c.QueryParam("cap")
+	if _, ok := services.FindCapBucket(capBucket); !ok {
+		capBucket = ""
+	}
+
+	overview, err := h.marketData.GetMarketOverview(reqCtx)
+	if err != nil {
+		h.log.Warn("failed to get market overview", slog.Any("err", err))
+		overview = &services.MarketOverview{}
+	}
+
+	movers, err := h.movers.Movers(reqCtx, services.MoverFilter{Cap: capBucket})
+	if err != nil {
+		h.log.Warn("failed to get market movers", slog.Any("err", err))
+		movers = &services.MarketMovers{}
+	}
+
+	rotation, err := h.sectors.Rotation(reqCtx)
+	if err != nil {
+		h.log.Warn("failed to get sector rotation", slog.Any("err", err))
+		rotation = &services.SectorRotation{}
+	}
+
+	releases, err := h.macro.Upcoming(reqCtx, time.Now(), calendarReleases)
+	if err != nil {
+		h.log.Warn("failed to get economic calendar", slog.Any("err", err))
+	}
+
+	curve, err := h.yieldCurve.View(reqCtx)
+	if err != nil {
+		h.log.Warn("failed to get yield curve", slog.Any("err", err))
+		curve = &services.YieldCurveView{}
+	}
 
 	data := pages.MarketsData{
-		Indices:      indices,
-		Sectors:      sectors,
-		TopGainers:   movers.gainers,
-		TopLosers:    movers.losers,
-		MostActive:   movers.active,
-		MarketStatus: getMarketStatus(),
+		Indices:      overview.Indices,
+		Sectors:      overview.SectorPerf,
+		TopGainers:   movers.Gainers,
+		TopLosers:    movers.Losers,
+		MostActive:   movers.MostActive,
+		Cap:          capBucket,
+		Rotation:     rotation,
+		Releases:     releases,
+		YieldCurve:   curve,
+		MarketStatus: marketcalendar.Status(time.Now()),
+		DataSource:   describeDataSource(overview.Indices, slices.Concat(movers.Gainers, movers.Losers, movers.MostActive), movers.Coverage),
 	}
 
 	page := pages.MarketsPage(data)
@@ -159,15 +277,51 @@ func (h *PagesHandler) markets(c echo.Context) error {
 func (h *PagesHandler) stocks(c echo.Context) error {
 	reqCtx := c.Request().Context()
 
-	stocks := getMockStocks()
+	// The search box submits here; send it on to the matching detail page,
+	// accepting company names as well as tickers
+	if query := strings.TrimSpace(c.QueryParam("symbol")); query != "" {
+		symbol, ok := h.symbols.Resolve(reqCtx, query)
+		if !ok {
+			symbol = strings.ToUpper(query)
+		}
+		return c.Redirect(http.StatusFound, "/stocks/"+url.PathEscape(symbol))
+	}
+
+	batch, err := h.marketData.GetMultipleQuotes(reqCtx, slices.Concat(defaultStockList, h.crypto))
+	if err != nil {
+		h.log.Warn("failed to get stock quotes", slog.Any("err", err))
+	}
+
+	// Keep the list in watchlist order rather than map order
+	stocks := batch.Ordered()
+	for i := range stocks {
+		h.fundamentals.Apply(reqCtx, &stocks[i])
+		h.risk.Apply(reqCtx, &stocks[i])
+	}
+	currency := displayCurrency(c)
+	currencyNote := h.convertQuotes(reqCtx, stocks, currency)
+
 	var featured *services.StockQuote
 	if len(stocks) > 0 {
 		featured = &stocks[0]
 	}
 
+	var filings *services.CompanyFundamentals
+	if featured != nil {
+		filings, err = h.fundamentals.Get(reqCtx, featured.Symbol)
+		if err != nil {
+			h.log.Warn("failed to get fundamentals", slog.String("symbol", featured.Symbol), slog.Any("err", err))
+		}
+	}
+
 	data := pages.StocksData{
 		Stocks:        stocks,
 		FeaturedStock: featured,
+		Fundamentals:  filings,
+		Currency:      currency,
+		Currencies:    h.currencies(reqCtx, currency),
+		CurrencyNote:  currencyNote,
+		DataSource:    describeDataSource(nil, stocks, batch.Coverage()),
 	}
 
 	page := pages.StocksPage(data)
@@ -175,6 +329,384 @@ func (h *PagesHandler) stocks(c echo.Context) error {
 	return page.Render(reqCtx, c.Response())
 }
 
+// stockDetail combines everything stored about one symbol. Symbols that
+// are not in the symbol master, have no snapshot and cannot be quoted 404.
+func (h *PagesHandler) stockDetail(c echo.Context) error {
+	reqCtx := c.Request().Context()
+
+	symbol := strings.ToUpper(strings.TrimSpace(c.Param("symbol")))
+	period := strings.ToUpper(c.QueryParam("period"))
+	if !services.IsHistoryPeriod(period) {
+		period = "1Y"
+	}
+
+	// ?set= picks chart indicators with the same syntax as the indicators API
+	specs, err := indicators.ParseSet(c.QueryParam("set"))
+	if err != nil {
+		h.log.Debug("ignoring indicator set", slog.String("set", c.QueryParam("set")), slog.Any("err", err))
+		specs = nil
+	}
+	indicatorSet := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		indicatorSet = append(indicatorSet, spec.ID())
+	}
+
+	var (
+		info     *services.SymbolInfo
+		quote    *services.StockQuote
+		history  []services.HistoricalData
+		snapshot *services.StockSnapshot
+		filings  *services.CompanyFundamentals
+		news     []services.NewsHeadline
+		trades   []services.Trade
+		recs     []services.Recommendation
+		risk     []services.RiskStats
+		rolling  []services.RiskPoint
+		earnings *services.EarningsSummary
+		holdings *services.ETFSummary
+	)
+
+	g, ctx := errgroup.WithContext(reqCtx)
+
+	// Market data failures degrade to empty panels instead of failing the page
+	g.Go(func() error {
+		data, err := h.marketData.GetQuote(ctx, symbol)
+		if err != nil {
+			h.log.Warn("failed to quote symbol", slog.String("symbol", symbol), slog.Any("err", err))
+			return nil
+		}
+		q := *data
+		q.Symbol = symbol
+		quote = &q
+		return nil
+	})
+
+	g.Go(func() error {
+		data, err := h.marketData.GetHistoricalData(ctx, symbol, period)
+		if err != nil {
+			h.log.Warn("failed to get price history", slog.String("symbol", symbol), slog.Any("err", err))
+			return nil
+		}
+		history = data
+		return nil
+	})
+
+	g.Go(func() error {
+		data, err := h.symbols.Lookup(ctx, symbol)
+		if err != nil {
+			return err
+		}
+		info = data
+		return nil
+	})
+
+	g.Go(func() error {
+		data, err := h.stockService.Snapshot(ctx, symbol)
+		if err != nil {
+			return err
+		}
+		snapshot = data
+		return nil
+	})
+
+	g.Go(func() error {
+		data, err := h.fundamentals.Get(ctx, symbol)
+		if err != nil {
+			return err
+		}
+		filings = data
+		return nil
+	})
+
+	g.Go(func() error {
+		data, err := h.newsService.ForSymbol(ctx, symbol, 10)
+		if err != nil {
+			return err
+		}
+		news = data
+		return nil
+	})
+
+	g.Go(func() error {
+		data, err := h.tradeService.ForSymbol(ctx, symbol, 10)
+		if err != nil {
+			return err
+		}
+		trades = data
+		return nil
+	})
+
+	g.Go(func() error {
+		data, err := h.recService.ForSymbol(ctx, symbol, 5)
+		if err != nil {
+			return err
+		}
+		recs = data
+		return nil
+	})
+
+	g.Go(func() error {
+		data, err := h.risk.Get(ctx, symbol)
+		if err != nil {
+			return err
+		}
+		risk = data
+		return nil
+	})
+
+	g.Go(func() error {
+		_, data, err := h.risk.Rolling(ctx, symbol, services.DefaultRollingWindow)
+		if err != nil {
+			return err
+		}
+		rolling = data
+		return nil
+	})
+
+	g.Go(func() error {
+		data, err := h.earnings.ForSymbol(ctx, symbol)
+		if err != nil {
+			return err
+		}
+		earnings = data
+		return nil
+	})
+
+	g.Go(func() error {
+		data, err := h.etfs.Summary(ctx, symbol)
+		if errors.Is(err, services.ErrNoHoldings) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		holdings = data
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		h.log.Error("stock detail aggregation failed", slog.String("symbol", symbol), slog.Any("err", err))
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+
+	if info == nil && quote == nil && snapshot == nil {
+		c.Response().WriteHeader(http.StatusNotFound)
+		return pages.StockNotFoundPage(symbol).Render(reqCtx, c.Response())
+	}
+
+	var quotes []services.StockQuote
+	if quote != nil {
+		h.fundamentals.Apply(reqCtx, quote)
+		h.risk.Apply(reqCtx, quote)
+		quotes = append(quotes, *quote)
+	}
+
+	// The quote and chart convert together or not at all, so one panel never
+	// mixes currencies
+	currency := displayCurrency(c)
+	shown := services.USD
+	var currencyNote string
+	if quote != nil {
+		shown = quoteCurrency(quote)
+		converted, err := h.fx.ConvertQuote(reqCtx, quote, currency)
+		if err == nil {
+			var bars []services.HistoricalData
+			bars, err = h.fx.ConvertBars(reqCtx, history, shown, currency)
+			if err == nil {
+				quote, history, shown = converted, bars, currency
+			}
+		}
+		if err != nil {
+			h.log.Debug("quote not converted", slog.String("symbol", symbol), slog.String("currency", currency), slog.Any("err", err))
+			currencyNote = fmt.Sprintf("Stored %s exchange rates do not cover this quote and chart, so prices are in %s.", currency, shown)
+		}
+	}
+	if snapshot != nil {
+		converted, err := h.fx.ConvertSnapshot(reqCtx, snapshot, currency)
+		if err != nil {
+			h.log.Debug("snapshot not converted", slog.String("symbol", symbol), slog.String("currency", currency), slog.Any("err", err))
+		} else {
+			snapshot = converted
+		}
+	}
+
+	data := pages.StockDetailData{
+		Symbol:          symbol,
+		Name:            stockName(info, quote, snapshot),
+		Info:            info,
+		Quote:           quote,
+		History:         history,
+		Period:          period,
+		Indicators:      indicators.Compute(history, specs),
+		IndicatorSet:    indicatorSet,
+		Snapshot:        snapshot,
+		Fundamentals:    filings,
+		Risk:            risk,
+		RollingRisk:     rollingRiskSeries(rolling),
+		Earnings:        earnings,
+		News:            news,
+		Trades:          trades,
+		Recommendations: recs,
+		Currency:        shown,
+		Currencies:      h.currencies(reqCtx, currency),
+		CurrencyNote:    currencyNote,
+		MarketStatus:    services.MarketStatus(symbol, time.Now()),
+		Holdings:        holdings,
+		DataSource:      describeDataSource(nil, quotes, services.QuoteCoverage{}),
+	}
+
+	page := pages.StockDetailPage(data)
+	return page.Render(reqCtx, c.Response())
+}
+
+func (h *PagesHandler) earningsCalendar(c echo.Context) error {
+	reqCtx := c.Request().Context()
+
+	// ?week= is any date in the week to show; the current week by default
+	day := time.Now().In(marketcalendar.Location())
+	if raw := c.QueryParam("week"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "week must be a YYYY-MM-DD date")
+		}
+		day = parsed
+	}
+	week := weekStart(day)
+
+	reports, err := h.earnings.Calendar(reqCtx, week, week.AddDate(0, 0, 7))
+	if err != nil {
+		h.log.Error("failed to get earnings calendar", slog.Any("err", err))
+	}
+
+	data := pages.EarningsData{
+		Week: week,
+		Days: earningsDays(week, reports),
+	}
+
+	page := pages.EarningsPage(data)
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return page.Render(reqCtx, c.Response())
+}
+
+// weekStart is the Monday of t's week, at midnight UTC like stored report dates
+func weekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// earningsDays groups a week's reports by date, listing every weekday and
+// weekend days only when something reports on them
+func earningsDays(week time.Time, reports []services.EarningsEvent) []pages.EarningsDay {
+	var days []pages.EarningsDay
+	for i := range 7 {
+		day := pages.EarningsDay{Date: week.AddDate(0, 0, i)}
+		for _, report := range reports {
+			if report.ReportDate.Equal(day.Date) {
+				day.Reports = append(day.Reports, report)
+			}
+		}
+		if i < 5 || len(day.Reports) > 0 {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// convertQuotes restates quotes in currency in place. Quotes without a rate
+// keep their own currency, and the returned note names them.
+func (h *PagesHandler) convertQuotes(ctx context.Context, quotes []services.StockQuote, currency string) string {
+	var missing []string
+	for i := range quotes {
+		converted, err := h.fx.ConvertQuote(ctx, &quotes[i], currency)
+		if err != nil {
+			h.log.Debug("quote not converted", slog.String("symbol", quotes[i].Symbol), slog.String("currency", currency), slog.Any("err", err))
+			missing = append(missing, quotes[i].Symbol)
+			continue
+		}
+		quotes[i] = *converted
+	}
+	if len(missing) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("No %s exchange rate is stored for %s, so those prices are in their own currency.", currency, strings.Join(missing, ", "))
+}
+
+// currencies lists the display currencies to offer, always including the
+// selected one
+func (h *PagesHandler) currencies(ctx context.Context, selected string) []string {
+	out, err := h.fx.Currencies(ctx)
+	if err != nil {
+		h.log.Warn("failed to list currencies", slog.Any("err", err))
+		out = []string{services.USD}
+	}
+	if !slices.Contains(out, selected) {
+		out = append(out, selected)
+	}
+	return out
+}
+
+// quoteCurrency is the currency a quote is priced in, dollars when unknown
+func quoteCurrency(q *services.StockQuote) string {
+	if q.Currency == "" {
+		return services.USD
+	}
+	return q.Currency
+}
+
+// stockName picks the best available company name for a detail page
+func stockName(info *services.SymbolInfo, quote *services.StockQuote, snapshot *services.StockSnapshot) string {
+	switch {
+	case quote != nil && quote.Name != "":
+		return quote.Name
+	case info != nil && info.Name != "":
+		return info.Name
+	case snapshot != nil:
+		return snapshot.Name
+	}
+	return ""
+}
+
+// rollingRiskSeries turns rolling risk points into chart strips so they draw
+// like the indicator studies under the price chart
+func rollingRiskSeries(points []services.RiskPoint) []indicators.Series {
+	if len(points) == 0 {
+		return nil
+	}
+	var volatility, beta, sharpe []indicators.Point
+	for _, p := range points {
+		volatility = append(volatility, indicators.Point{Time: p.Time, Value: p.Volatility})
+		if p.Beta != nil {
+			beta = append(beta, indicators.Point{Time: p.Time, Value: *p.Beta})
+		}
+		if p.Sharpe != nil {
+			sharpe = append(sharpe, indicators.Point{Time: p.Time, Value: *p.Sharpe})
+		}
+	}
+
+	window := services.DefaultRollingWindow
+	out := []indicators.Series{{
+		ID:    "rolling-volatility",
+		Name:  fmt.Sprintf("Volatility %dD (%%)", window),
+		Lines: map[string][]indicators.Point{"value": volatility},
+	}}
+	if len(beta) > 1 {
+		out = append(out, indicators.Series{
+			ID:    "rolling-beta",
+			Name:  fmt.Sprintf("Beta %dD", window),
+			Lines: map[string][]indicators.Point{"value": beta},
+		})
+	}
+	if len(sharpe) > 1 {
+		out = append(out, indicators.Series{
+			ID:    "rolling-sharpe",
+			Name:  fmt.Sprintf("Sharpe %dD", window),
+			Lines: map[string][]indicators.Point{"value": sharpe},
+		})
+	}
+	return out
+}
+
 func (h *PagesHandler) news(c echo.Context) error {
 	reqCtx := c.Request().Context()
 
@@ -184,10 +716,16 @@ func (h *PagesHandler) news(c echo.Context) error {
 		news = []services.NewsHeadline{}
 	}
 
+	signals, err := h.yieldCurve.Signals(reqCtx)
+	if err != nil {
+		h.log.Warn("failed to get yield curve signals", slog.Any("err", err))
+	}
+
 	data := pages.NewsPageData{
 		News:         news,
 		FilterSource: c.QueryParam("source"),
 		FilterTicker: c.QueryParam("ticker"),
+		CurveSignals: signals,
 	}
 
 	page := pages.NewsPage(data)
@@ -312,6 +850,11 @@ func (h *PagesHandler) aiInsights(c echo.Context) error {
 		recs = []services.Recommendation{}
 	}
 
+	signals, err := h.yieldCurve.Signals(reqCtx)
+	if err != nil {
+		h.log.Warn("failed to get yield curve signals", slog.Any("err", err))
+	}
+
 	// Convert recommendations to insights with transparency context
 	insights := make([]pages.AIInsight, 0, len(recs))
 	for _, rec := range recs {
@@ -321,7 +864,7 @@ func (h *PagesHandler) aiInsights(c echo.Context) error {
 				Reasoning:   getReasoningForRec(rec),
 				DataSources: getDataSourcesForRec(rec),
 				Limitations: getStandardLimitations(),
-				RiskFactors: getRiskFactorsForRec(rec),
+				RiskFactors: append(getRiskFactorsForRec(rec), getCurveRiskFactors(signals)...),
 				Questions:   getQuestionsForRec(rec),
 			},
 		})
@@ -374,6 +917,18 @@ func getRiskFactorsForRec(rec services.Recommendation) []string {
 	return factors
 }
 
+// getCurveRiskFactors flags an inverted Treasury yield curve, a common
+// recession warning that weighs on every recommendation
+func getCurveRiskFactors(signals []services.CurveSignal) []string {
+	var factors []string
+	for _, signal := range signals {
+		if signal.Inverted {
+			factors = append(factors, signal.Summary()+" Inversions have preceded most US recessions.")
+		}
+	}
+	return factors
+}
+
 func getQuestionsForRec(rec services.Recommendation) []string {
 	return []string{
 		"Have I read multiple sources about this company?",
@@ -384,97 +939,255 @@ func getQuestionsForRec(rec services.Recommendation) []string {
 	}
 }
 
-// Helper functions
+func (h *PagesHandler) tools(c echo.Context) error {
+	page := pages.ToolsPage()
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return page.Render(c.Request().Context(), c.Response())
+}
+
+// Options tool inputs when neither the query nor a stored chain supplies them
+const (
+	defaultOptionSpot = 100.0
+	defaultOptionDays = 30.0
+	defaultOptionVol  = 0.25
+)
+
+func (h *PagesHandler) optionsTool(c echo.Context) error {
+	reqCtx := c.Request().Context()
+
+	template, ok := options.FindStrategy(c.QueryParam("strategy"))
+	if !ok {
+		template = options.Strategies[0]
+	}
+	data := pages.OptionsToolData{
+		Template:   template,
+		Symbol:     strings.ToUpper(strings.TrimSpace(c.QueryParam("symbol"))),
+		Days:       defaultOptionDays,
+		RateSource: "3-month Treasury bill",
+	}
+
+	rate, ok, err := h.yieldCurve.BillRate(reqCtx)
+	if err != nil {
+		h.log.Warn("failed to get bill rate", slog.Any("err", err))
+	}
+	in := options.Inputs{Spot: defaultOptionSpot, Rate: rate / 100, Vol: defaultOptionVol}
+	if !ok {
+		in.Rate = options.DefaultRate
+		data.RateSource = "default"
+	}
 
-func getMarketStatus() string {
-	now := time.Now()
-	hour := now.Hour()
-	weekday := now.Weekday()
+	// A symbol fills in its quote and, when a chain is stored, the nearest
+	// expiration, at-the-money strike and implied volatility
+	if data.Symbol != "" {
+		var expiration time.Time
+		if raw := c.QueryParam("expiration"); raw != "" {
+			t, err := time.Parse("2006-01-02", raw)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "expiration must be a YYYY-MM-DD date")
+			}
+			expiration = t
+		}
+
+		quote, err := h.marketData.GetQuote(reqCtx, data.Symbol)
+		if err != nil {
+			h.log.Warn("failed to quote option underlying", slog.String("symbol", data.Symbol), slog.Any("err", err))
+		} else {
+			in.Spot = quote.Price
+			chain, err := h.options.Chain(reqCtx, data.Symbol, expiration, quote.Price, in.Rate)
+			if err != nil && !errors.Is(err, options.ErrNoChain) {
+				h.log.Warn("failed to load option chain", slog.String("symbol", data.Symbol), slog.Any("err", err))
+			}
+			if chain != nil {
+				data.Chain = chain
+				data.Days = chain.Years * 365
+				if vol, ok := chain.ATMVol(); ok {
+					in.Vol = vol
+				}
+			}
+		}
+	}
 
-	if weekday == time.Saturday || weekday == time.Sunday {
-		return "closed"
+	// Query values override the defaults; vol and rate are in percent
+	fields := []struct {
+		name     string
+		dst      *float64
+		scale    float64
+		positive bool
+	}{
+		{"spot", &in.Spot, 1, true},
+		{"strike", &in.Strike, 1, true},
+		{"width", &data.Width, 1, true},
+		{"days", &data.Days, 1, false},
+		{"vol", &in.Vol, 100, true},
+		{"rate", &in.Rate, 100, false},
+	}
+	for _, f := range fields {
+		raw := c.QueryParam(f.name)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) || v < 0 || (f.positive && v == 0) {
+			return echo.NewHTTPError(http.StatusBadRequest, f.name+" must be a positive number")
+		}
+		*f.dst = v / f.scale
+		if f.name == "rate" {
+			data.RateSource = "entered"
+		}
 	}
-	if hour >= 9 && hour < 16 {
-		return "open"
+	if in.Strike == 0 {
+		in.Strike = defaultStrike(in.Spot, data.Chain)
 	}
-	if hour >= 4 && hour < 9 {
-		return "pre-market"
+	if data.Width == 0 {
+		data.Width = strikeStep(in.Spot)
 	}
-	if hour >= 16 && hour < 20 {
-		return "after-hours"
+	if template.ID == "bear-put-spread" && data.Width >= in.Strike {
+		return echo.NewHTTPError(http.StatusBadRequest, "width must be below the strike")
 	}
-	return "closed"
+	in.Years = data.Days / 365
+
+	data.Inputs = in
+	data.Strategy = template.Build(in, data.Width)
+
+	page := pages.OptionsToolPage(data)
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return page.Render(reqCtx, c.Response())
 }
 
-func getMockIndices() []services.IndexQuote {
-	return []services.IndexQuote{
-		{Symbol: "^GSPC", Name: "S&P 500", Price: 5998.74, Change: 63.77, ChangePercent: 1.07},
-		{Symbol: "^DJI", Name: "Dow Jones", Price: 43828.06, Change: 619.05, ChangePercent: 1.43},
-		{Symbol: "^IXIC", Name: "NASDAQ", Price: 19926.72, Change: 180.09, ChangePercent: 0.91},
-		{Symbol: "^RUT", Name: "Russell 2000", Price: 2346.90, Change: 51.27, ChangePercent: 2.23},
+// defaultStrike is the chain's at-the-money strike, or the spot rounded to
+// the nearest usual strike increment
+func defaultStrike(spot float64, chain *options.Chain) float64 {
+	if chain != nil && len(chain.Rows) > 0 {
+		return chain.ATMStrike()
 	}
+	step := strikeStep(spot)
+	return max(step, math.Round(spot/step)*step)
 }
 
-type mockMovers struct {
-	gainers []services.StockQuote
-	losers  []services.StockQuote
-	active  []services.StockQuote
+// strikeStep is a typical listed strike spacing for a stock at spot
+func strikeStep(spot float64) float64 {
+	switch {
+	case spot < 25:
+		return 1
+	case spot < 100:
+		return 2.5
+	case spot < 250:
+		return 5
+	case spot < 1000:
+		return 10
+	}
+	return 50
 }
 
-func getMockGainersLosers() mockMovers {
-	gainers := []services.StockQuote{
-		{Symbol: "NVDA", Name: "NVIDIA Corporation", Price: 134.25, Change: 8.42, ChangePercent: 6.69, Volume: 312500000, MarketCap: 3300000000000, PE: 65.2, Week52High: 140.76, Week52Low: 45.01},
-		{Symbol: "AMD", Name: "Advanced Micro Devices", Price: 137.89, Change: 5.23, ChangePercent: 3.94, Volume: 48200000, MarketCap: 223000000000, PE: 98.5, Week52High: 164.46, Week52Low: 93.12},
-		{Symbol: "TSLA", Name: "Tesla, Inc.", Price: 424.77, Change: 14.89, ChangePercent: 3.63, Volume: 89500000, MarketCap: 1350000000000, PE: 78.4, Week52High: 438.22, Week52Low: 138.80},
-		{Symbol: "GOOGL", Name: "Alphabet Inc.", Price: 192.96, Change: 5.67, ChangePercent: 3.03, Volume: 28400000, MarketCap: 2370000000000, PE: 24.8, Week52High: 193.31, Week52Low: 130.67},
-		{Symbol: "META", Name: "Meta Platforms", Price: 617.12, Change: 15.23, ChangePercent: 2.53, Volume: 14200000, MarketCap: 1560000000000, PE: 29.3, Week52High: 618.95, Week52Low: 326.89},
+// etfDetail lists a fund's stored holdings with the sector weights they add
+// up to. Funds without a holdings file 404
+func (h *PagesHandler) etfDetail(c echo.Context) error {
+	reqCtx := c.Request().Context()
+	symbol := strings.ToUpper(strings.TrimSpace(c.Param("symbol")))
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+
+	fund, err := h.etfs.Holdings(reqCtx, symbol)
+	if errors.Is(err, services.ErrNoHoldings) {
+		c.Response().WriteHeader(http.StatusNotFound)
+		return pages.ETFNotFoundPage(symbol).Render(reqCtx, c.Response())
+	}
+	if err != nil {
+		h.log.Error("failed to load etf holdings", slog.String("symbol", symbol), slog.Any("err", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "etf holdings unavailable")
+	}
+
+	exposure, err := h.etfs.LookThrough(reqCtx, []services.Position{{Symbol: symbol, Value: 1}})
+	if err != nil {
+		h.log.Warn("failed to look through etf", slog.String("symbol", symbol), slog.Any("err", err))
 	}
 
-	losers := []services.StockQuote{
-		{Symbol: "INTC", Name: "Intel Corporation", Price: 20.11, Change: -0.89, ChangePercent: -4.24, Volume: 67800000, MarketCap: 86000000000, PE: 0, Week52High: 51.28, Week52Low: 18.51},
-		{Symbol: "BA", Name: "Boeing Company", Price: 177.56, Change: -4.23, ChangePercent: -2.33, Volume: 8900000, MarketCap: 108000000000, PE: 0, Week52High: 267.54, Week52Low: 137.03},
-		{Symbol: "NKE", Name: "Nike, Inc.", Price: 75.89, Change: -1.67, ChangePercent: -2.15, Volume: 12300000, MarketCap: 113000000000, PE: 21.4, Week52High: 107.43, Week52Low: 70.75},
-		{Symbol: "DIS", Name: "Walt Disney Co.", Price: 112.34, Change: -2.12, ChangePercent: -1.85, Volume: 9800000, MarketCap: 205000000000, PE: 72.1, Week52High: 123.74, Week52Low: 83.91},
-		{Symbol: "PFE", Name: "Pfizer Inc.", Price: 25.67, Change: -0.43, ChangePercent: -1.65, Volume: 32100000, MarketCap: 145000000000, PE: 19.8, Week52High: 31.54, Week52Low: 24.48},
+	name := symbol
+	info, err := h.symbols.Lookup(reqCtx, symbol)
+	if err != nil {
+		h.log.Warn("failed to look up symbol", slog.String("symbol", symbol), slog.Any("err", err))
+	}
+	if info != nil {
+		name = info.Name
 	}
 
-	active := []services.StockQuote{
-		{Symbol: "NVDA", Name: "NVIDIA Corporation", Price: 134.25, Change: 8.42, ChangePercent: 6.69, Volume: 312500000, MarketCap: 3300000000000, PE: 65.2, Week52High: 140.76, Week52Low: 45.01},
-		{Symbol: "TSLA", Name: "Tesla, Inc.", Price: 424.77, Change: 14.89, ChangePercent: 3.63, Volume: 89500000, MarketCap: 1350000000000, PE: 78.4, Week52High: 438.22, Week52Low: 138.80},
-		{Symbol: "INTC", Name: "Intel Corporation", Price: 20.11, Change: -0.89, ChangePercent: -4.24, Volume: 67800000, MarketCap: 86000000000, PE: 0, Week52High: 51.28, Week52Low: 18.51},
-		{Symbol: "AMD", Name: "Advanced Micro Devices", Price: 137.89, Change: 5.23, ChangePercent: 3.94, Volume: 48200000, MarketCap: 223000000000, PE: 98.5, Week52High: 164.46, Week52Low: 93.12},
-		{Symbol: "AAPL", Name: "Apple Inc.", Price: 248.13, Change: 2.87, ChangePercent: 1.17, Volume: 45600000, MarketCap: 3780000000000, PE: 32.1, Week52High: 250.00, Week52Low: 164.08},
+	data := pages.ETFDetailData{
+		Symbol:   symbol,
+		Name:     name,
+		Fund:     fund,
+		Exposure: exposure,
+		ShowAll:  c.QueryParam("all") != "",
 	}
 
-	return mockMovers{gainers: gainers, losers: losers, active: active}
+	page := pages.ETFDetailPage(data)
+	return page.Render(reqCtx, c.Response())
 }
 
-func getMockSectors() []services.SectorPerf {
-	return []services.SectorPerf{
-		{Sector: "Technology", ChangePercent: 2.34},
-		{Sector: "Healthcare", ChangePercent: 1.12},
-		{Sector: "Financials", ChangePercent: 0.89},
-		{Sector: "Energy", ChangePercent: -0.45},
-		{Sector: "Consumer Cyclical", ChangePercent: 1.67},
-		{Sector: "Consumer Defensive", ChangePercent: 0.23},
-		{Sector: "Industrials", ChangePercent: 0.78},
-		{Sector: "Materials", ChangePercent: -0.12},
-		{Sector: "Utilities", ChangePercent: -0.34},
-		{Sector: "Real Estate", ChangePercent: 0.56},
+// lookThroughTool decomposes the ?positions= entered into stock and sector
+// exposure, looking through funds with stored holdings
+func (h *PagesHandler) lookThroughTool(c echo.Context) error {
+	reqCtx := c.Request().Context()
+
+	data := pages.LookThroughToolData{Positions: strings.TrimSpace(c.QueryParam("positions"))}
+	funds, err := h.etfs.List(reqCtx)
+	if err != nil {
+		h.log.Warn("failed to list etfs", slog.Any("err", err))
 	}
+	data.Funds = funds
+
+	positions, err := parsePositions(data.Positions)
+	if err != nil {
+		data.Error = err.Error()
+	} else if len(positions) > 0 {
+		exposure, err := h.etfs.LookThrough(reqCtx, positions)
+		if err != nil {
+			h.log.Error("look-through failed", slog.Any("err", err))
+			return echo.NewHTTPError(http.StatusInternalServerError, "look-through unavailable")
+		}
+		data.Exposure = exposure
+	}
+
+	page := pages.LookThroughToolPage(data)
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return page.Render(reqCtx, c.Response())
 }
 
-func getMockStocks() []services.StockQuote {
-	return []services.StockQuote{
-		{Symbol: "AAPL", Name: "Apple Inc.", Price: 248.13, Change: 2.87, ChangePercent: 1.17, Open: 245.50, High: 249.25, Low: 244.80, PrevClose: 245.26, Volume: 45600000, MarketCap: 3780000000000, PE: 32.1, Week52High: 250.00, Week52Low: 164.08},
-		{Symbol: "MSFT", Name: "Microsoft Corporation", Price: 446.95, Change: 3.12, ChangePercent: 0.70, Open: 443.50, High: 448.75, Low: 442.20, PrevClose: 443.83, Volume: 21400000, MarketCap: 3320000000000, PE: 36.8, Week52High: 468.35, Week52Low: 362.90},
-		{Symbol: "NVDA", Name: "NVIDIA Corporation", Price: 134.25, Change: 8.42, ChangePercent: 6.69, Open: 126.50, High: 135.50, Low: 125.80, PrevClose: 125.83, Volume: 312500000, MarketCap: 3300000000000, PE: 65.2, Week52High: 140.76, Week52Low: 45.01},
-		{Symbol: "GOOGL", Name: "Alphabet Inc.", Price: 192.96, Change: 5.67, ChangePercent: 3.03, Open: 188.00, High: 193.50, Low: 187.25, PrevClose: 187.29, Volume: 28400000, MarketCap: 2370000000000, PE: 24.8, Week52High: 193.31, Week52Low: 130.67},
-		{Symbol: "AMZN", Name: "Amazon.com, Inc.", Price: 227.03, Change: 4.23, ChangePercent: 1.90, Open: 223.50, High: 228.00, Low: 222.80, PrevClose: 222.80, Volume: 39800000, MarketCap: 2380000000000, PE: 46.2, Week52High: 233.00, Week52Low: 151.61},
-		{Symbol: "META", Name: "Meta Platforms", Price: 617.12, Change: 15.23, ChangePercent: 2.53, Open: 602.00, High: 618.95, Low: 600.50, PrevClose: 601.89, Volume: 14200000, MarketCap: 1560000000000, PE: 29.3, Week52High: 618.95, Week52Low: 326.89},
-		{Symbol: "TSLA", Name: "Tesla, Inc.", Price: 424.77, Change: 14.89, ChangePercent: 3.63, Open: 410.00, High: 426.50, Low: 408.25, PrevClose: 409.88, Volume: 89500000, MarketCap: 1350000000000, PE: 78.4, Week52High: 438.22, Week52Low: 138.80},
-		{Symbol: "BRK.B", Name: "Berkshire Hathaway", Price: 458.92, Change: -1.23, ChangePercent: -0.27, Open: 460.00, High: 461.50, Low: 457.80, PrevClose: 460.15, Volume: 3200000, MarketCap: 989000000000, PE: 9.8, Week52High: 491.66, Week52Low: 378.94},
-		{Symbol: "JPM", Name: "JPMorgan Chase", Price: 243.67, Change: 3.45, ChangePercent: 1.44, Open: 240.50, High: 244.50, Low: 239.80, PrevClose: 240.22, Volume: 8900000, MarketCap: 698000000000, PE: 12.5, Week52High: 254.31, Week52Low: 173.21},
-		{Symbol: "V", Name: "Visa Inc.", Price: 317.89, Change: 2.34, ChangePercent: 0.74, Open: 315.50, High: 318.75, Low: 314.80, PrevClose: 315.55, Volume: 6200000, MarketCap: 628000000000, PE: 30.2, Week52High: 321.62, Week52Low: 252.70},
+// Helper functions
+
+// defaultStockList is the set of names shown on /stocks
+var defaultStockList = []string{"AAPL", "MSFT", "NVDA", "GOOGL", "AMZN", "META", "TSLA", "BRK.B", "JPM", "V"}
+
+// calendarReleases is how many upcoming releases the markets page lists
+const calendarReleases = 8
+
+// staleAfter is how old market data can be before pages flag it as delayed
+const staleAfter = 15 * time.Minute
+
+// describeDataSource summarizes the provenance of the quotes a page renders
+// and how many requested symbols could not be quoted
+func describeDataSource(indices []services.IndexQuote, quotes []services.StockQuote, coverage services.QuoteCoverage) components.DataSource {
+	ds := components.DataSource{
+		Missing:     len(indices) == 0 && len(quotes) == 0,
+		Requested:   coverage.Requested,
+		Unavailable: len(coverage.Unavailable),
+	}
+
+	observe := func(source string, updated time.Time) {
+		if source == "demo" {
+			ds.Demo = true
+		}
+		if ds.AsOf.IsZero() || updated.Before(ds.AsOf) {
+			ds.AsOf = updated
+			ds.Source = source
+		}
+	}
+	for _, idx := range indices {
+		observe(idx.Source, idx.UpdatedAt)
 	}
+	for _, q := range quotes {
+		observe(q.Source, q.UpdatedAt)
+		ds.Stale = ds.Stale || q.Stale
+	}
+
+	ds.Stale = ds.Stale || (!ds.AsOf.IsZero() && time.Since(ds.AsOf) > staleAfter)
+	return ds
 }
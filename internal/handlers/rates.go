@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// RatesHandler serves the Treasury yield curve and its inversion signals
+type RatesHandler struct {
+	log    *slog.Logger
+	curves *services.YieldCurveService
+}
+
+func NewRatesHandler(log *slog.Logger, curves *services.YieldCurveService) *RatesHandler {
+	return &RatesHandler{log: log, curves: curves}
+}
+
+func (h *RatesHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/rates/yield-curve", h.yieldCurve)
+}
+
+// yieldCurve returns the latest par yield curve, the curves a month and a
+// year earlier, and the 2s10s and 3m10y inversion signals
+func (h *RatesHandler) yieldCurve(c echo.Context) error {
+	view, err := h.curves.View(c.Request().Context())
+	if err != nil {
+		h.log.Error("load yield curve failed", slog.Any("err", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "yield curve unavailable")
+	}
+	if view.Current == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "no Treasury yields imported")
+	}
+	return c.JSON(http.StatusOK, view)
+}
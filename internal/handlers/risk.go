@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// RiskHandler serves risk and return statistics computed from stored bars
+type RiskHandler struct {
+	log  *slog.Logger
+	risk *services.RiskService
+}
+
+func NewRiskHandler(log *slog.Logger, risk *services.RiskService) *RiskHandler {
+	return &RiskHandler{log: log, risk: risk}
+}
+
+func (h *RiskHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/stocks/:symbol/risk", h.stats)
+}
+
+// stats returns the stored 30/90/365-day statistics plus the stored rolling
+// statistics over ?window= trading days (default 63) for the past year
+func (h *RiskHandler) stats(c echo.Context) error {
+	ctx := c.Request().Context()
+	symbol := strings.ToUpper(strings.TrimSpace(c.Param("symbol")))
+
+	window := services.DefaultRollingWindow
+	if raw := c.QueryParam("window"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || !slices.Contains(services.RollingWindows, n) {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("window must be one of %v trading days", services.RollingWindows))
+		}
+		window = n
+	}
+
+	stats, err := h.risk.Get(ctx, symbol)
+	if err != nil {
+		h.log.Error("load risk stats failed", slog.String("symbol", symbol), slog.Any("err", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "risk statistics unavailable")
+	}
+
+	benchmark, rolling, err := h.risk.Rolling(ctx, symbol, window)
+	if err != nil {
+		h.log.Error("load rolling risk failed", slog.String("symbol", symbol), slog.Any("err", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "risk statistics unavailable")
+	}
+
+	if len(stats) == 0 && len(rolling) == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "no stored price history for "+symbol)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"symbol": symbol,
+		"stats":  stats,
+		"rolling": map[string]any{
+			"window":    window,
+			"benchmark": benchmark,
+			"points":    rolling,
+		},
+	})
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// SectorsHandler serves sector fund returns and rotation graph data
+type SectorsHandler struct {
+	log     *slog.Logger
+	sectors *services.SectorService
+}
+
+func NewSectorsHandler(log *slog.Logger, sectors *services.SectorService) *SectorsHandler {
+	return &SectorsHandler{log: log, sectors: sectors}
+}
+
+func (h *SectorsHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/sectors/rotation", h.rotation)
+}
+
+// rotation returns each sector's trailing returns, relative strength and
+// rotation graph position and tail
+func (h *SectorsHandler) rotation(c echo.Context) error {
+	rotation, err := h.sectors.Rotation(c.Request().Context())
+	if err != nil {
+		h.log.Error("sector rotation failed", slog.Any("err", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "sector rotation failed")
+	}
+	return c.JSON(http.StatusOK, rotation)
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// maxStreamSymbols bounds how many quotes one stream connection can watch
+const maxStreamSymbols = 25
+
+// maxStreams and maxStreamsPerClient bound how many streams are open at
+// once, since every watched symbol is polled against the vendor budgets
+const (
+	maxStreams          = 200
+	maxStreamsPerClient = 4
+)
+
+// streamHeartbeat keeps idle connections alive through proxies
+const streamHeartbeat = 25 * time.Second
+
+// StreamHandler serves live quote updates as Server-Sent Events
+type StreamHandler struct {
+	log     *slog.Logger
+	hub     *services.QuoteHub
+	fx      *services.FXService
+	symbols *services.SymbolService
+
+	mu      sync.Mutex
+	open    int
+	clients map[string]int
+}
+
+func NewStreamHandler(log *slog.Logger, hub *services.QuoteHub, fx *services.FXService, symbols *services.SymbolService) *StreamHandler {
+	return &StreamHandler{log: log, hub: hub, fx: fx, symbols: symbols, clients: map[string]int{}}
+}
+
+func (h *StreamHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/stream/quotes", h.quotes)
+}
+
+// quotes streams a "quote" event per changed quote for ?symbols=AAPL,MSFT,
+// priced in ?currency= when given and a rate is stored. Symbols missing from
+// the symbol master are dropped.
+func (h *StreamHandler) quotes(c echo.Context) error {
+	symbols := parseSymbols(c.QueryParam("symbols"))
+	if len(symbols) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "symbols is required")
+	}
+	if len(symbols) > maxStreamSymbols {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("at most %d symbols per stream", maxStreamSymbols))
+	}
+	symbols, err := h.knownSymbols(c.Request().Context(), symbols)
+	if err != nil {
+		h.log.Error("failed to load symbols for stream", slog.Any("err", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "quote stream unavailable")
+	}
+	if len(symbols) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "no known symbols to stream")
+	}
+	var currency string
+	if raw := c.QueryParam("currency"); raw != "" {
+		code, ok := services.NormalizeCurrency(raw)
+		if !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, "currency must be a three-letter currency code")
+		}
+		currency = code
+	}
+
+	client := c.RealIP()
+	if !h.acquire(client) {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "too many open quote streams")
+	}
+	defer h.release(client)
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	sub := h.hub.Subscribe(symbols)
+	defer h.hub.Unsubscribe(sub)
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	ctx := c.Request().Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": ping\n\n"); err != nil {
+				return nil
+			}
+		case <-sub.Ready():
+			for _, quote := range sub.Drain() {
+				if currency != "" {
+					converted, err := h.fx.ConvertQuote(ctx, &quote, currency)
+					if err != nil {
+						h.log.Debug("streamed quote not converted", slog.String("symbol", quote.Symbol), slog.Any("err", err))
+					} else {
+						quote = *converted
+					}
+				}
+				payload, err := json.Marshal(quote)
+				if err != nil {
+					h.log.Warn("encode streamed quote failed", slog.String("symbol", quote.Symbol), slog.Any("err", err))
+					continue
+				}
+				if _, err := fmt.Fprintf(res, "event: quote\ndata: %s\n\n", payload); err != nil {
+					return nil
+				}
+			}
+		}
+		res.Flush()
+	}
+}
+
+// knownSymbols keeps the symbols in the symbol master plus configured coins
+func (h *StreamHandler) knownSymbols(ctx context.Context, symbols []string) ([]string, error) {
+	all, err := h.symbols.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]struct{}, len(all))
+	for _, info := range all {
+		known[info.Symbol] = struct{}{}
+	}
+
+	var out []string
+	for _, symbol := range symbols {
+		if _, ok := known[symbol]; ok || services.IsCrypto(symbol) {
+			out = append(out, symbol)
+		}
+	}
+	return out, nil
+}
+
+// acquire reserves a stream slot for client, refusing once either cap is hit
+func (h *StreamHandler) acquire(client string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.open >= maxStreams || h.clients[client] >= maxStreamsPerClient {
+		return false
+	}
+	h.open++
+	h.clients[client]++
+	return true
+}
+
+func (h *StreamHandler) release(client string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.open--
+	h.clients[client]--
+	if h.clients[client] <= 0 {
+		delete(h.clients, client)
+	}
+}
+
+// parseSymbols upper-cases and de-duplicates a comma-separated symbol list
+func parseSymbols(raw string) []string {
+	seen := map[string]struct{}{}
+	var out []string
+	for _, part := range strings.Split(raw, ",") {
+		symbol := strings.ToUpper(strings.TrimSpace(part))
+		if symbol == "" {
+			continue
+		}
+		if _, ok := seen[symbol]; ok {
+			continue
+		}
+		seen[symbol] = struct{}{}
+		out = append(out, symbol)
+	}
+	return out
+}
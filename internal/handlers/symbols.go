@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// maxSymbolResults caps one autocomplete response
+const maxSymbolResults = 50
+
+// SymbolsHandler serves symbol master lookups for search inputs
+type SymbolsHandler struct {
+	log     *slog.Logger
+	symbols *services.SymbolService
+}
+
+func NewSymbolsHandler(log *slog.Logger, symbols *services.SymbolService) *SymbolsHandler {
+	return &SymbolsHandler{log: log, symbols: symbols}
+}
+
+func (h *SymbolsHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/symbols/search", h.search)
+}
+
+// search ranks symbols for ?q= by ticker prefix, then name, then close
+// spellings; ?limit= defaults to 10
+func (h *SymbolsHandler) search(c echo.Context) error {
+	query := strings.TrimSpace(c.QueryParam("q"))
+	if query == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "q is required")
+	}
+
+	limit := 10
+	if raw := c.QueryParam("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "limit must be a positive integer")
+		}
+		limit = min(n, maxSymbolResults)
+	}
+
+	results, err := h.symbols.Search(c.Request().Context(), query, limit)
+	if err != nil {
+		h.log.Error("symbol search failed", slog.String("query", query), slog.Any("err", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "symbol search failed")
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"query":   query,
+		"results": results,
+	})
+}
@@ -0,0 +1,73 @@
+package indicators
+
+import "github.com/loganlanou/Financing-101/internal/services"
+
+// SMA is the simple moving average of closes over period bars.
+func SMA(bars []services.HistoricalData, period int) []Point {
+	return points(bars, sma(closes(bars), period))
+}
+
+// EMA is the exponential moving average of closes, seeded with the SMA of
+// the first period bars and weighted 2/(period+1) thereafter.
+func EMA(bars []services.HistoricalData, period int) []Point {
+	return points(bars, ema(closes(bars), period))
+}
+
+func sma(values []float64, period int) []float64 {
+	if period <= 0 || len(values) < period {
+		return nil
+	}
+	out := make([]float64, 0, len(values)-period+1)
+	var sum float64
+	for i, v := range values {
+		sum += v
+		if i >= period {
+			sum -= values[i-period]
+		}
+		if i >= period-1 {
+			out = append(out, sum/float64(period))
+		}
+	}
+	return out
+}
+
+func ema(values []float64, period int) []float64 {
+	if period <= 0 || len(values) < period {
+		return nil
+	}
+	k := 2 / float64(period+1)
+	out := make([]float64, 0, len(values)-period+1)
+
+	var seed float64
+	for _, v := range values[:period] {
+		seed += v
+	}
+	prev := seed / float64(period)
+	out = append(out, prev)
+	for _, v := range values[period:] {
+		prev = v*k + prev*(1-k)
+		out = append(out, prev)
+	}
+	return out
+}
+
+// wilder smooths with Wilder's 1/period weighting, seeded with the mean of
+// the first period values. RSI and ATR use it.
+func wilder(values []float64, period int) []float64 {
+	if period <= 0 || len(values) < period {
+		return nil
+	}
+	out := make([]float64, 0, len(values)-period+1)
+
+	var seed float64
+	for _, v := range values[:period] {
+		seed += v
+	}
+	prev := seed / float64(period)
+	out = append(out, prev)
+	for _, v := range values[period:] {
+		prev = (prev*float64(period-1) + v) / float64(period)
+		out = append(out, prev)
+	}
+	return out
+}
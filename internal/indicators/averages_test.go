@@ -0,0 +1,104 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// movingAverageCloses is the 30-day series from StockCharts' moving
+// average worked example, which carries the 10-day SMA and EMA to two
+// decimals.
+var movingAverageCloses = []float64{
+	22.27, 22.19, 22.08, 22.17, 22.18, 22.13, 22.23, 22.43, 22.24, 22.29,
+	22.15, 22.39, 22.38, 22.61, 23.36, 24.05, 23.75, 23.83, 23.95, 23.63,
+	23.82, 23.87, 23.65, 23.19, 23.10, 23.33, 22.68, 23.10, 22.40, 22.17,
+}
+
+func TestMovingAverages(t *testing.T) {
+	tests := []struct {
+		name   string
+		fn     func([]services.HistoricalData, int) []Point
+		closes []float64
+		period int
+		want   []float64
+		tol    float64
+	}{
+		{
+			name:   "sma reference",
+			fn:     SMA,
+			closes: movingAverageCloses,
+			period: 10,
+			want: []float64{
+				22.22, 22.21, 22.23, 22.26, 22.30, 22.42, 22.61, 22.77, 22.91, 23.08, 23.21,
+				23.38, 23.52, 23.65, 23.71, 23.68, 23.61, 23.51, 23.43, 23.28, 23.13,
+			},
+			tol: 0.006,
+		},
+		{
+			name:   "ema reference",
+			fn:     EMA,
+			closes: movingAverageCloses,
+			period: 10,
+			want: []float64{
+				22.22, 22.21, 22.24, 22.27, 22.33, 22.52, 22.80, 22.97, 23.13, 23.28, 23.34,
+				23.43, 23.51, 23.53, 23.47, 23.40, 23.39, 23.26, 23.23, 23.08, 22.92,
+			},
+			tol: 0.006,
+		},
+		{
+			name:   "sma exact window",
+			fn:     SMA,
+			closes: []float64{1, 2, 3, 4},
+			period: 4,
+			want:   []float64{2.5},
+			tol:    1e-9,
+		},
+		// Seeded with the SMA, an EMA of a straight line lags it by
+		// (period-1)/2 from the first point on.
+		{
+			name:   "ema of a line",
+			fn:     EMA,
+			closes: []float64{1, 2, 3, 4, 5, 6},
+			period: 3,
+			want:   []float64{2, 3, 4, 5},
+			tol:    1e-9,
+		},
+		{
+			name:   "sma too few bars",
+			fn:     SMA,
+			closes: []float64{1, 2, 3},
+			period: 4,
+			want:   nil,
+		},
+		{
+			name:   "ema too few bars",
+			fn:     EMA,
+			closes: []float64{1, 2, 3},
+			period: 4,
+			want:   nil,
+		},
+		{
+			name:   "zero period",
+			fn:     SMA,
+			closes: []float64{1, 2, 3},
+			period: 0,
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkValues(t, tt.fn(dailyBars(tt.closes...), tt.period), tt.want, tt.tol)
+		})
+	}
+}
+
+func TestPointsKeepBarTimes(t *testing.T) {
+	bars := dailyBars(1, 2, 3, 4, 5)
+	got := SMA(bars, 3)
+	for i, p := range got {
+		if want := bars[i+2].Timestamp; !p.Time.Equal(want) {
+			t.Errorf("point %d stamped %s, want %s", i, p.Time, want)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Package indicators computes technical analysis series over price history:
+// moving averages, momentum oscillators, volatility bands and volume studies.
+//
+// Every function takes bars oldest first and returns points only once its
+// lookback window is full, so a 20-bar SMA over 100 bars yields 81 points.
+package indicators
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// maxPeriod bounds user-supplied lookbacks.
+const maxPeriod = 500
+
+// Point is one indicator value, stamped with the bar it was computed at.
+type Point struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// Series is a computed indicator ready to chart. Overlays share the price
+// axis; the others are drawn on their own scale. Single-line indicators
+// store their values under "value".
+type Series struct {
+	ID      string             `json:"id"`
+	Name    string             `json:"name"`
+	Overlay bool               `json:"overlay"`
+	Lines   map[string][]Point `json:"lines"`
+}
+
+// Spec selects one indicator and its lookback.
+type Spec struct {
+	Kind   string
+	Period int
+}
+
+// ID is the spec in the form accepted by ParseSet, e.g. "sma20".
+func (s Spec) ID() string {
+	if s.Period == 0 {
+		return s.Kind
+	}
+	return s.Kind + strconv.Itoa(s.Period)
+}
+
+// kinds lists the supported indicators with their default lookbacks; zero
+// means the indicator takes no period.
+var kinds = map[string]struct {
+	name    string
+	period  int
+	overlay bool
+}{
+	"sma":  {"SMA", 20, true},
+	"ema":  {"EMA", 20, true},
+	"bb":   {"Bollinger Bands", 20, true},
+	"vwap": {"VWAP", 0, true},
+	"rsi":  {"RSI", 14, false},
+	"macd": {"MACD (12, 26, 9)", 0, false},
+	"atr":  {"ATR", 14, false},
+	"obv":  {"OBV", 0, false},
+}
+
+// ParseSet reads a comma-separated list such as "sma20,ema50,rsi,macd".
+// A kind without a number uses its default lookback.
+func ParseSet(raw string) ([]Spec, error) {
+	var specs []Spec
+	seen := map[string]bool{}
+	for _, token := range strings.Split(raw, ",") {
+		token = strings.ToLower(strings.TrimSpace(token))
+		if token == "" {
+			continue
+		}
+
+		kind := strings.TrimRight(token, "0123456789")
+		info, ok := kinds[kind]
+		if !ok {
+			return nil, fmt.Errorf("unknown indicator %q", token)
+		}
+
+		spec := Spec{Kind: kind, Period: info.period}
+		if digits := token[len(kind):]; digits != "" {
+			if info.period == 0 {
+				return nil, fmt.Errorf("indicator %q takes no period", kind)
+			}
+			n, err := strconv.Atoi(digits)
+			if err != nil || n < 2 || n > maxPeriod {
+				return nil, fmt.Errorf("indicator %q: period must be between 2 and %d", token, maxPeriod)
+			}
+			spec.Period = n
+		}
+
+		if !seen[spec.ID()] {
+			seen[spec.ID()] = true
+			specs = append(specs, spec)
+		}
+	}
+	return specs, nil
+}
+
+// Compute evaluates each spec over bars.
+func Compute(bars []services.HistoricalData, specs []Spec) []Series {
+	out := make([]Series, 0, len(specs))
+	for _, spec := range specs {
+		info := kinds[spec.Kind]
+		series := Series{
+			ID:      spec.ID(),
+			Name:    info.name,
+			Overlay: info.overlay,
+		}
+		if spec.Period != 0 && spec.Kind != "macd" {
+			series.Name = fmt.Sprintf("%s (%d)", info.name, spec.Period)
+		}
+
+		switch spec.Kind {
+		case "sma":
+			series.Lines = map[string][]Point{"value": SMA(bars, spec.Period)}
+		case "ema":
+			series.Lines = map[string][]Point{"value": EMA(bars, spec.Period)}
+		case "bb":
+			bands := Bollinger(bars, spec.Period, 2)
+			series.Lines = map[string][]Point{"upper": bands.Upper, "middle": bands.Middle, "lower": bands.Lower}
+		case "vwap":
+			series.Lines = map[string][]Point{"value": VWAP(bars)}
+		case "rsi":
+			series.Lines = map[string][]Point{"value": RSI(bars, spec.Period)}
+		case "macd":
+			m := MACD(bars, 12, 26, 9)
+			series.Lines = map[string][]Point{"macd": m.MACD, "signal": m.Signal, "histogram": m.Histogram}
+		case "atr":
+			series.Lines = map[string][]Point{"value": ATR(bars, spec.Period)}
+		case "obv":
+			series.Lines = map[string][]Point{"value": OBV(bars)}
+		}
+		out = append(out, series)
+	}
+	return out
+}
+
+func closes(bars []services.HistoricalData) []float64 {
+	out := make([]float64, len(bars))
+	for i, bar := range bars {
+		out[i] = bar.Close
+	}
+	return out
+}
+
+// points pairs values with the timestamps of the last len(values) bars.
+func points(bars []services.HistoricalData, values []float64) []Point {
+	offset := len(bars) - len(values)
+	out := make([]Point, len(values))
+	for i, v := range values {
+		out[i] = Point{Time: bars[offset+i].Timestamp, Value: v}
+	}
+	return out
+}
@@ -0,0 +1,82 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// dailyBars builds one bar per day from closes, with a one-point range
+// around each close and unit volume.
+func dailyBars(closes ...float64) []services.HistoricalData {
+	start := time.Date(2026, 1, 5, 21, 0, 0, 0, time.UTC)
+	bars := make([]services.HistoricalData, len(closes))
+	for i, c := range closes {
+		bars[i] = services.HistoricalData{
+			Timestamp: start.AddDate(0, 0, i),
+			Open:      c,
+			High:      c + 0.5,
+			Low:       c - 0.5,
+			Close:     c,
+			Volume:    1,
+		}
+	}
+	return bars
+}
+
+// checkValues compares an indicator's values with want to within tol.
+func checkValues(t *testing.T, got []Point, want []float64, tol float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d points, want %d", len(got), len(want))
+	}
+	for i, p := range got {
+		if math.Abs(p.Value-want[i]) > tol {
+			t.Errorf("point %d = %.4f, want %.4f", i, p.Value, want[i])
+		}
+	}
+}
+
+func TestParseSet(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    []string
+		wantErr bool
+	}{
+		{raw: "sma20,ema50,rsi,macd", want: []string{"sma20", "ema50", "rsi14", "macd"}},
+		{raw: " SMA , sma20 ", want: []string{"sma20"}},
+		{raw: "", want: nil},
+		{raw: "foo", wantErr: true},
+		{raw: "macd9", wantErr: true},
+		{raw: "sma1", wantErr: true},
+		{raw: "sma501", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			specs, err := ParseSet(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseSet(%q) returned no error", tt.raw)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSet(%q): %v", tt.raw, err)
+			}
+			var ids []string
+			for _, s := range specs {
+				ids = append(ids, s.ID())
+			}
+			if len(ids) != len(tt.want) {
+				t.Fatalf("ParseSet(%q) = %v, want %v", tt.raw, ids, tt.want)
+			}
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Fatalf("ParseSet(%q) = %v, want %v", tt.raw, ids, tt.want)
+				}
+			}
+		})
+	}
+}
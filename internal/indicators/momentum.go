@@ -0,0 +1,72 @@
+package indicators
+
+import "github.com/loganlanou/Financing-101/internal/services"
+
+// RSI is Wilder's relative strength index: 100 - 100/(1 + average gain /
+// average loss) over period close-to-close changes.
+func RSI(bars []services.HistoricalData, period int) []Point {
+	c := closes(bars)
+	if period <= 0 || len(c) <= period {
+		return nil
+	}
+
+	gains := make([]float64, len(c)-1)
+	losses := make([]float64, len(c)-1)
+	for i := 1; i < len(c); i++ {
+		if change := c[i] - c[i-1]; change > 0 {
+			gains[i-1] = change
+		} else {
+			losses[i-1] = -change
+		}
+	}
+
+	avgGain := wilder(gains, period)
+	avgLoss := wilder(losses, period)
+	values := make([]float64, len(avgGain))
+	for i := range avgGain {
+		if avgLoss[i] == 0 {
+			values[i] = 100
+			continue
+		}
+		values[i] = 100 - 100/(1+avgGain[i]/avgLoss[i])
+	}
+	return points(bars, values)
+}
+
+// MACDLines are the MACD line (fast EMA - slow EMA), its signal EMA and
+// the difference between the two.
+type MACDLines struct {
+	MACD      []Point
+	Signal    []Point
+	Histogram []Point
+}
+
+// MACD computes Appel's moving average convergence/divergence.
+func MACD(bars []services.HistoricalData, fast, slow, signal int) MACDLines {
+	c := closes(bars)
+	fastEMA := ema(c, fast)
+	slowEMA := ema(c, slow)
+	if slowEMA == nil || fastEMA == nil {
+		return MACDLines{}
+	}
+
+	// Both EMAs end on the last bar; align the fast one to the slow one.
+	fastEMA = fastEMA[len(fastEMA)-len(slowEMA):]
+	line := make([]float64, len(slowEMA))
+	for i := range slowEMA {
+		line[i] = fastEMA[i] - slowEMA[i]
+	}
+
+	signalLine := ema(line, signal)
+	histogram := make([]float64, len(signalLine))
+	offset := len(line) - len(signalLine)
+	for i, s := range signalLine {
+		histogram[i] = line[offset+i] - s
+	}
+
+	return MACDLines{
+		MACD:      points(bars, line),
+		Signal:    points(bars, signalLine),
+		Histogram: points(bars, histogram),
+	}
+}
@@ -0,0 +1,132 @@
+package indicators
+
+import "testing"
+
+// rsiCloses is the 33-day series from StockCharts' RSI worked example,
+// which follows Wilder's smoothing; its 14-day RSI is published below.
+var rsiCloses = []float64{
+	44.3389, 44.0902, 44.1497, 43.6124, 44.3278, 44.8264, 45.0955, 45.4245, 45.8433, 46.0826, 45.8931,
+	46.0328, 45.6140, 46.2820, 46.2820, 46.0028, 46.0328, 46.4116, 46.2222, 45.6439, 46.2122, 46.2521,
+	45.7137, 46.4515, 45.7835, 45.3548, 44.0288, 44.1783, 44.2181, 44.5672, 43.4205, 42.6628, 43.1314,
+}
+
+func TestRSI(t *testing.T) {
+	tests := []struct {
+		name   string
+		closes []float64
+		period int
+		want   []float64
+		tol    float64
+	}{
+		{
+			name:   "wilder reference",
+			closes: rsiCloses,
+			period: 14,
+			want: []float64{
+				70.53, 66.32, 66.55, 69.41, 66.36, 57.97, 62.93, 63.26, 56.06, 62.38,
+				54.71, 50.42, 39.99, 41.46, 41.87, 45.46, 37.30, 33.08, 37.77,
+			},
+			tol: 0.006,
+		},
+		// Changes +1 -1 +1 -1 +3: the first averages 2/3 and 1/3 give an
+		// RS of 2, then Wilder's smoothing gives 4/5 and 7/2.
+		{
+			name:   "hand worked",
+			closes: []float64{10, 11, 10, 11, 10, 13},
+			period: 3,
+			want:   []float64{200.0 / 3, 400.0 / 9, 700.0 / 9},
+			tol:    1e-9,
+		},
+		{
+			name:   "only gains",
+			closes: []float64{1, 2, 3, 4, 5},
+			period: 3,
+			want:   []float64{100, 100},
+			tol:    1e-9,
+		},
+		{
+			name:   "only losses",
+			closes: []float64{5, 4, 3, 2, 1},
+			period: 3,
+			want:   []float64{0, 0},
+			tol:    1e-9,
+		},
+		// RSI needs period changes, so period+1 closes.
+		{
+			name:   "too few bars",
+			closes: []float64{1, 2, 3},
+			period: 3,
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkValues(t, RSI(dailyBars(tt.closes...), tt.period), tt.want, tt.tol)
+		})
+	}
+}
+
+func TestMACD(t *testing.T) {
+	line := make([]float64, 60)
+	for i := range line {
+		line[i] = float64(100 + i)
+	}
+	flat := make([]float64, 60)
+	for i := range flat {
+		flat[i] = 50
+	}
+
+	tests := []struct {
+		name          string
+		closes        []float64
+		wantMACD      float64
+		wantPoints    int
+		wantSignalPts int
+	}{
+		// On a straight line each EMA lags by (period-1)/2, so MACD is
+		// 12.5 - 5.5 = 7 throughout and the histogram is zero.
+		{name: "straight line", closes: line, wantMACD: 7, wantPoints: 60 - 25, wantSignalPts: 60 - 25 - 8},
+		{name: "flat", closes: flat, wantMACD: 0, wantPoints: 60 - 25, wantSignalPts: 60 - 25 - 8},
+		{name: "too few bars", closes: line[:25], wantPoints: 0, wantSignalPts: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := MACD(dailyBars(tt.closes...), 12, 26, 9)
+			if len(m.MACD) != tt.wantPoints || len(m.Signal) != tt.wantSignalPts || len(m.Histogram) != tt.wantSignalPts {
+				t.Fatalf("got %d/%d/%d points, want %d/%d/%d", len(m.MACD), len(m.Signal), len(m.Histogram),
+					tt.wantPoints, tt.wantSignalPts, tt.wantSignalPts)
+			}
+			for _, p := range m.MACD {
+				if diff := p.Value - tt.wantMACD; diff > 1e-9 || diff < -1e-9 {
+					t.Fatalf("macd = %.6f, want %.6f", p.Value, tt.wantMACD)
+				}
+			}
+			for _, p := range m.Histogram {
+				if p.Value > 1e-9 || p.Value < -1e-9 {
+					t.Fatalf("histogram = %.6f, want 0", p.Value)
+				}
+			}
+		})
+	}
+}
+
+func TestMACDMatchesEMAs(t *testing.T) {
+	bars := dailyBars(rsiCloses...)
+	m := MACD(bars, 3, 6, 4)
+	fast, slow := EMA(bars, 3), EMA(bars, 6)
+	fast = fast[len(fast)-len(slow):]
+
+	want := make([]float64, len(slow))
+	for i := range slow {
+		want[i] = fast[i].Value - slow[i].Value
+	}
+	checkValues(t, m.MACD, want, 1e-9)
+
+	signal := ema(want, 4)
+	checkValues(t, m.Signal, signal, 1e-9)
+	for i, h := range m.Histogram {
+		if diff := h.Value - (want[len(want)-len(signal)+i] - signal[i]); diff > 1e-9 || diff < -1e-9 {
+			t.Fatalf("histogram %d off by %g", i, diff)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package indicators
+
+import (
+	"math"
+
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// Bands are Bollinger Bands: an SMA with lines k standard deviations above
+// and below it.
+type Bands struct {
+	Upper  []Point
+	Middle []Point
+	Lower  []Point
+}
+
+// Bollinger uses the population standard deviation of the window, as
+// Bollinger specifies.
+func Bollinger(bars []services.HistoricalData, period int, k float64) Bands {
+	c := closes(bars)
+	middle := sma(c, period)
+	if middle == nil {
+		return Bands{}
+	}
+
+	upper := make([]float64, len(middle))
+	lower := make([]float64, len(middle))
+	for i, mean := range middle {
+		var variance float64
+		for _, v := range c[i : i+period] {
+			variance += (v - mean) * (v - mean)
+		}
+		sd := math.Sqrt(variance / float64(period))
+		upper[i] = mean + k*sd
+		lower[i] = mean - k*sd
+	}
+
+	return Bands{
+		Upper:  points(bars, upper),
+		Middle: points(bars, middle),
+		Lower:  points(bars, lower),
+	}
+}
+
+// ATR is Wilder's average true range. The first bar has no prior close, so
+// its true range is its high-low range.
+func ATR(bars []services.HistoricalData, period int) []Point {
+	if len(bars) == 0 {
+		return nil
+	}
+	tr := make([]float64, len(bars))
+	tr[0] = bars[0].High - bars[0].Low
+	for i := 1; i < len(bars); i++ {
+		prevClose := bars[i-1].Close
+		tr[i] = max(
+			bars[i].High-bars[i].Low,
+			math.Abs(bars[i].High-prevClose),
+			math.Abs(bars[i].Low-prevClose),
+		)
+	}
+	return points(bars, wilder(tr, period))
+}
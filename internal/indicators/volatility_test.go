@@ -0,0 +1,86 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+func TestBollinger(t *testing.T) {
+	tests := []struct {
+		name       string
+		closes     []float64
+		period     int
+		k          float64
+		wantMiddle []float64
+		wantWidth  []float64
+	}{
+		// Closes 1..5 have mean 3 and population variance 2.
+		{name: "population deviation", closes: []float64{1, 2, 3, 4, 5}, period: 5, k: 2, wantMiddle: []float64{3}, wantWidth: []float64{2 * math.Sqrt2}},
+		{name: "textbook deviation", closes: []float64{2, 4, 4, 4, 5, 5, 7, 9}, period: 8, k: 1, wantMiddle: []float64{5}, wantWidth: []float64{2}},
+		{name: "zero range", closes: []float64{7, 7, 7, 7}, period: 3, k: 2, wantMiddle: []float64{7, 7}, wantWidth: []float64{0, 0}},
+		{name: "too few bars", closes: []float64{1, 2}, period: 3, k: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bands := Bollinger(dailyBars(tt.closes...), tt.period, tt.k)
+			checkValues(t, bands.Middle, tt.wantMiddle, 1e-9)
+
+			upper := make([]float64, len(tt.wantMiddle))
+			lower := make([]float64, len(tt.wantMiddle))
+			for i, m := range tt.wantMiddle {
+				upper[i] = m + tt.wantWidth[i]
+				lower[i] = m - tt.wantWidth[i]
+			}
+			checkValues(t, bands.Upper, upper, 1e-9)
+			checkValues(t, bands.Lower, lower, 1e-9)
+		})
+	}
+}
+
+func TestATR(t *testing.T) {
+	start := time.Date(2026, 1, 5, 21, 0, 0, 0, time.UTC)
+	ohlc := func(rows ...[3]float64) []services.HistoricalData {
+		bars := make([]services.HistoricalData, len(rows))
+		for i, r := range rows {
+			bars[i] = services.HistoricalData{Timestamp: start.AddDate(0, 0, i), Open: r[2], High: r[0], Low: r[1], Close: r[2]}
+		}
+		return bars
+	}
+
+	tests := []struct {
+		name   string
+		bars   []services.HistoricalData
+		period int
+		want   []float64
+	}{
+		// True ranges are 2 (first bar: high-low), 2, 3 (gap up from 10)
+		// and 3.5 (low 9 against the 12.5 close); the first ATR averages
+		// three, then Wilder smooths: (7/3*2 + 3.5)/3.
+		{
+			name:   "gaps use the prior close",
+			bars:   ohlc([3]float64{10, 8, 9}, [3]float64{11, 9, 10}, [3]float64{13, 12, 12.5}, [3]float64{12, 9, 9.5}),
+			period: 3,
+			want:   []float64{7.0 / 3, (7.0/3*2 + 3.5) / 3},
+		},
+		{
+			name:   "zero range",
+			bars:   ohlc([3]float64{5, 5, 5}, [3]float64{5, 5, 5}, [3]float64{5, 5, 5}),
+			period: 2,
+			want:   []float64{0, 0},
+		},
+		{
+			name:   "too few bars",
+			bars:   ohlc([3]float64{10, 8, 9}, [3]float64{11, 9, 10}),
+			period: 3,
+		},
+		{name: "no bars", period: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkValues(t, ATR(tt.bars, tt.period), tt.want, 1e-9)
+		})
+	}
+}
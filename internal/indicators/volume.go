@@ -0,0 +1,60 @@
+package indicators
+
+import (
+	"github.com/loganlanou/Financing-101/internal/marketcalendar"
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// VWAP is the volume-weighted average of typical price (high+low+close)/3.
+// Intraday bars reset at each session; daily and longer bars give a VWAP
+// anchored at the first bar.
+func VWAP(bars []services.HistoricalData) []Point {
+	intraday := isIntraday(bars)
+	values := make([]float64, len(bars))
+
+	var pv, volume float64
+	for i, bar := range bars {
+		if intraday && i > 0 && sessionDate(bar) != sessionDate(bars[i-1]) {
+			pv, volume = 0, 0
+		}
+		typical := (bar.High + bar.Low + bar.Close) / 3
+		pv += typical * float64(bar.Volume)
+		volume += float64(bar.Volume)
+		if volume == 0 {
+			values[i] = typical
+			continue
+		}
+		values[i] = pv / volume
+	}
+	return points(bars, values)
+}
+
+// OBV is Granville's on-balance volume: volume is added on up closes and
+// subtracted on down closes, starting from zero.
+func OBV(bars []services.HistoricalData) []Point {
+	values := make([]float64, len(bars))
+	for i := 1; i < len(bars); i++ {
+		values[i] = values[i-1]
+		switch {
+		case bars[i].Close > bars[i-1].Close:
+			values[i] += float64(bars[i].Volume)
+		case bars[i].Close < bars[i-1].Close:
+			values[i] -= float64(bars[i].Volume)
+		}
+	}
+	return points(bars, values)
+}
+
+// isIntraday reports whether any two consecutive bars share a session.
+func isIntraday(bars []services.HistoricalData) bool {
+	for i := 1; i < len(bars); i++ {
+		if sessionDate(bars[i]) == sessionDate(bars[i-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+func sessionDate(bar services.HistoricalData) string {
+	return bar.Timestamp.In(marketcalendar.Location()).Format("2006-01-02")
+}
@@ -0,0 +1,98 @@
+package indicators
+
+import (
+	"testing"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/marketcalendar"
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+func TestVWAP(t *testing.T) {
+	ny := marketcalendar.Location()
+	bar := func(ts time.Time, high, low, close float64, volume int64) services.HistoricalData {
+		return services.HistoricalData{Timestamp: ts.UTC(), Open: close, High: high, Low: low, Close: close, Volume: volume}
+	}
+	day1 := time.Date(2026, 3, 10, 9, 30, 0, 0, ny)
+	day2 := time.Date(2026, 3, 11, 9, 30, 0, 0, ny)
+
+	tests := []struct {
+		name string
+		bars []services.HistoricalData
+		want []float64
+	}{
+		// Typical prices 10 and 13 weighted 100:200, then the next
+		// session starts over from its own first bar.
+		{
+			name: "intraday resets each session",
+			bars: []services.HistoricalData{
+				bar(day1, 11, 9, 10, 100),
+				bar(day1.Add(5*time.Minute), 14, 12, 13, 200),
+				bar(day2, 21, 19, 20, 50),
+				bar(day2.Add(5*time.Minute), 23, 21, 22, 50),
+			},
+			want: []float64{10, 12, 20, 21},
+		},
+		// 16:00 and 20:30 New York fall on the same session although
+		// they straddle midnight UTC.
+		{
+			name: "session follows new york time",
+			bars: []services.HistoricalData{
+				bar(time.Date(2026, 3, 10, 16, 0, 0, 0, ny), 11, 9, 10, 100),
+				bar(time.Date(2026, 3, 10, 20, 30, 0, 0, ny), 14, 12, 13, 100),
+			},
+			want: []float64{10, 11.5},
+		},
+		// Daily bars never reset: the VWAP is anchored at the first bar.
+		{
+			name: "daily bars are anchored",
+			bars: []services.HistoricalData{
+				bar(day1, 11, 9, 10, 100),
+				bar(day2, 21, 19, 20, 100),
+				bar(day2.AddDate(0, 0, 1), 31, 29, 30, 200),
+			},
+			want: []float64{10, 15, 22.5},
+		},
+		{
+			name: "no volume uses the typical price",
+			bars: []services.HistoricalData{
+				bar(day1, 11, 9, 10, 0),
+				bar(day1.Add(5*time.Minute), 14, 12, 13, 0),
+			},
+			want: []float64{10, 13},
+		},
+		{name: "no bars"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkValues(t, VWAP(tt.bars), tt.want, 1e-9)
+		})
+	}
+}
+
+func TestOBV(t *testing.T) {
+	tests := []struct {
+		name    string
+		closes  []float64
+		volumes []int64
+		want    []float64
+	}{
+		{
+			name:    "adds up closes and subtracts down closes",
+			closes:  []float64{10, 11, 11, 10.5, 12},
+			volumes: []int64{100, 200, 300, 400, 500},
+			want:    []float64{0, 200, 200, -200, 300},
+		},
+		{name: "single bar", closes: []float64{10}, volumes: []int64{100}, want: []float64{0}},
+		{name: "no bars"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bars := dailyBars(tt.closes...)
+			for i := range bars {
+				bars[i].Volume = tt.volumes[i]
+			}
+			checkValues(t, OBV(bars), tt.want, 1e-9)
+		})
+	}
+}
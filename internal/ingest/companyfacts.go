@@ -0,0 +1,435 @@
+package ingest
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/database"
+)
+
+// companyTickersFile is SEC's ticker-to-CIK map, read from the import
+// directory when present so CIK-named files can be matched to symbols.
+const companyTickersFile = "company_tickers.json"
+
+// Quarter lengths vary with 13- and 14-week fiscal calendars.
+const (
+	minQuarterDays = 75
+	maxQuarterDays = 105
+)
+
+// CompanyFactsImporter loads SEC XBRL "companyfacts" JSON files into
+// company_profiles and quarterly fundamentals rows.
+type CompanyFactsImporter struct {
+	log     *slog.Logger
+	queries *database.Queries
+	dir     string
+}
+
+// NewCompanyFactsImporter reads files named CIK##########.json (as
+// downloaded from SEC) or SYMBOL.json from dir.
+func NewCompanyFactsImporter(log *slog.Logger, queries *database.Queries, dir string) *CompanyFactsImporter {
+	return &CompanyFactsImporter{log: log, queries: queries, dir: dir}
+}
+
+// companyFacts is the subset of the SEC companyfacts payload we read.
+type companyFacts struct {
+	CIK        int64                             `json:"cik"`
+	EntityName string                            `json:"entityName"`
+	Facts      map[string]map[string]factConcept `json:"facts"`
+}
+
+type factConcept struct {
+	Units map[string][]factValue `json:"units"`
+}
+
+type factValue struct {
+	Start string  `json:"start"`
+	End   string  `json:"end"`
+	Val   float64 `json:"val"`
+	FY    int64   `json:"fy"`
+	FP    string  `json:"fp"`
+	Form  string  `json:"form"`
+	Filed string  `json:"filed"`
+}
+
+// lineKind says how a statement line is reported across filings.
+type lineKind int
+
+const (
+	// instantLine is a balance at period end.
+	instantLine lineKind = iota
+	// flowLine accumulates over the period; 10-Q cash flow statements and
+	// 10-K filings only report year-to-date totals, so quarters are derived
+	// by subtracting the previous year-to-date figure.
+	flowLine
+	// perShareLine covers a period but cannot be differenced.
+	perShareLine
+)
+
+// statementLine maps us-gaap concepts, in preference order, onto a column.
+type statementLine struct {
+	concepts []string
+	unit     string
+	kind     lineKind
+	// anchor lines decide which period ends become quarters.
+	anchor bool
+	field  func(*database.UpsertFundamentalParams) *sql.NullFloat64
+}
+
+var statementLines = []statementLine{
+	{
+		concepts: []string{"RevenueFromContractWithCustomerExcludingAssessedTax", "Revenues", "SalesRevenueNet"},
+		unit:     "USD", kind: flowLine, anchor: true,
+		field: func(p *database.UpsertFundamentalParams) *sql.NullFloat64 { return &p.Revenue },
+	},
+	{
+		concepts: []string{"GrossProfit"},
+		unit:     "USD", kind: flowLine,
+		field: func(p *database.UpsertFundamentalParams) *sql.NullFloat64 { return &p.GrossProfit },
+	},
+	{
+		concepts: []string{"OperatingIncomeLoss"},
+		unit:     "USD", kind: flowLine,
+		field: func(p *database.UpsertFundamentalParams) *sql.NullFloat64 { return &p.OperatingIncome },
+	},
+	{
+		concepts: []string{"NetIncomeLoss"},
+		unit:     "USD", kind: flowLine, anchor: true,
+		field: func(p *database.UpsertFundamentalParams) *sql.NullFloat64 { return &p.NetIncome },
+	},
+	{
+		concepts: []string{"EarningsPerShareDiluted"},
+		unit:     "USD/shares", kind: perShareLine,
+		field: func(p *database.UpsertFundamentalParams) *sql.NullFloat64 { return &p.EpsDiluted },
+	},
+	{
+		concepts: []string{"Assets"},
+		unit:     "USD", kind: instantLine, anchor: true,
+		field: func(p *database.UpsertFundamentalParams) *sql.NullFloat64 { return &p.TotalAssets },
+	},
+	{
+		concepts: []string{"Liabilities"},
+		unit:     "USD", kind: instantLine,
+		field: func(p *database.UpsertFundamentalParams) *sql.NullFloat64 { return &p.TotalLiabilities },
+	},
+	{
+		concepts: []string{"StockholdersEquity"},
+		unit:     "USD", kind: instantLine,
+		field: func(p *database.UpsertFundamentalParams) *sql.NullFloat64 { return &p.StockholdersEquity },
+	},
+	{
+		concepts: []string{"CashAndCashEquivalentsAtCarryingValue"},
+		unit:     "USD", kind: instantLine,
+		field: func(p *database.UpsertFundamentalParams) *sql.NullFloat64 { return &p.Cash },
+	},
+	{
+		concepts: []string{"LongTermDebtNoncurrent", "LongTermDebt"},
+		unit:     "USD", kind: instantLine,
+		field: func(p *database.UpsertFundamentalParams) *sql.NullFloat64 { return &p.LongTermDebt },
+	},
+	{
+		concepts: []string{"CommonStockSharesOutstanding"},
+		unit:     "shares", kind: instantLine,
+		field: func(p *database.UpsertFundamentalParams) *sql.NullFloat64 { return &p.SharesOutstanding },
+	},
+	{
+		concepts: []string{"NetCashProvidedByUsedInOperatingActivities"},
+		unit:     "USD", kind: flowLine,
+		field: func(p *database.UpsertFundamentalParams) *sql.NullFloat64 { return &p.OperatingCashFlow },
+	},
+	{
+		concepts: []string{"PaymentsToAcquirePropertyPlantAndEquipment"},
+		unit:     "USD", kind: flowLine,
+		field: func(p *database.UpsertFundamentalParams) *sql.NullFloat64 { return &p.CapitalExpenditures },
+	},
+}
+
+// Import loads every companyfacts file in the directory. A missing
+// directory is not an error; the import is simply skipped.
+func (i *CompanyFactsImporter) Import(ctx context.Context) error {
+	entries, err := os.ReadDir(i.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		i.log.Debug("company facts directory not found", slog.String("dir", i.dir))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read company facts dir: %w", err)
+	}
+
+	tickers, err := loadCompanyTickers(filepath.Join(i.dir, companyTickersFile))
+	if err != nil {
+		return err
+	}
+
+	var files, imported, periods int
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || name == companyTickersFile || !strings.EqualFold(filepath.Ext(name), ".json") {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		files++
+
+		symbol, n, err := i.importFile(ctx, filepath.Join(i.dir, name), tickers)
+		if err != nil {
+			i.log.Warn("company facts import failed", slog.String("file", name), slog.Any("err", err))
+			continue
+		}
+		imported++
+		periods += n
+		i.log.Debug("company facts imported", slog.String("symbol", symbol), slog.Int("periods", n))
+	}
+
+	if files > 0 && imported == 0 {
+		return errors.New("company facts import failed for every file")
+	}
+
+	i.log.Info("company facts import complete", slog.Int("companies", imported), slog.Int("periods", periods))
+	return nil
+}
+
+func (i *CompanyFactsImporter) importFile(ctx context.Context, path string, tickers map[int64]string) (string, int, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", 0, err
+	}
+	var facts companyFacts
+	if err := json.Unmarshal(raw, &facts); err != nil {
+		return "", 0, fmt.Errorf("decode: %w", err)
+	}
+
+	symbol := tickers[facts.CIK]
+	if symbol == "" {
+		base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		if strings.HasPrefix(strings.ToUpper(base), "CIK") {
+			return "", 0, fmt.Errorf("no ticker for CIK %d; add %s to the import directory", facts.CIK, companyTickersFile)
+		}
+		symbol = normalizeTicker(base)
+	}
+
+	quarters := buildQuarters(facts.Facts["us-gaap"])
+	if len(quarters) == 0 {
+		return symbol, 0, errors.New("no quarterly statement data")
+	}
+
+	now := time.Now().UTC()
+	if err := i.queries.UpsertCompanyProfile(ctx, database.UpsertCompanyProfileParams{
+		Symbol:    symbol,
+		Cik:       fmt.Sprintf("%010d", facts.CIK),
+		Name:      facts.EntityName,
+		Source:    "sec",
+		UpdatedAt: now,
+	}); err != nil {
+		return symbol, 0, fmt.Errorf("upsert profile: %w", err)
+	}
+
+	for _, quarter := range quarters {
+		quarter.Symbol = symbol
+		if err := i.queries.UpsertFundamental(ctx, quarter); err != nil {
+			return symbol, 0, fmt.Errorf("upsert %s: %w", quarter.PeriodEnd.Format("2006-01-02"), err)
+		}
+	}
+
+	return symbol, len(quarters), nil
+}
+
+// factSpan identifies a reported figure by its period.
+type factSpan struct {
+	start time.Time
+	end   time.Time
+}
+
+// buildQuarters turns raw facts into one row per fiscal quarter end.
+func buildQuarters(gaap map[string]factConcept) []database.UpsertFundamentalParams {
+	// origin remembers the first filing that reported each period end; its
+	// fiscal year and period label the quarter.
+	origin := map[time.Time]factValue{}
+	noteOrigin := func(end time.Time, v factValue) {
+		if prev, ok := origin[end]; !ok || v.Filed < prev.Filed {
+			origin[end] = v
+		}
+	}
+
+	values := make([]map[time.Time]float64, len(statementLines))
+	for idx, line := range statementLines {
+		merged := map[time.Time]float64{}
+		for _, concept := range line.concepts {
+			spans := latestFacts(gaap[concept].Units[line.unit], noteOrigin)
+			for end, val := range quarterValues(spans, line.kind) {
+				if _, ok := merged[end]; !ok {
+					merged[end] = val
+				}
+			}
+		}
+		values[idx] = merged
+	}
+
+	// A quarter exists where we know its revenue, earnings or balance sheet.
+	ends := map[time.Time]struct{}{}
+	for idx, line := range statementLines {
+		if !line.anchor {
+			continue
+		}
+		for end := range values[idx] {
+			ends[end] = struct{}{}
+		}
+	}
+
+	quarters := make([]database.UpsertFundamentalParams, 0, len(ends))
+	for end := range ends {
+		src, ok := origin[end]
+		if !ok {
+			continue
+		}
+		filed, _ := time.Parse(time.DateOnly, src.Filed)
+		period := src.FP
+		if period == "FY" {
+			period = "Q4"
+		}
+		quarter := database.UpsertFundamentalParams{
+			PeriodEnd:    end,
+			FiscalYear:   src.FY,
+			FiscalPeriod: period,
+			Form:         src.Form,
+			FiledAt:      filed.UTC(),
+		}
+		for idx, line := range statementLines {
+			if val, ok := values[idx][end]; ok {
+				*line.field(&quarter) = sql.NullFloat64{Float64: val, Valid: true}
+			}
+		}
+		quarters = append(quarters, quarter)
+	}
+
+	sort.Slice(quarters, func(a, b int) bool {
+		return quarters[a].PeriodEnd.Before(quarters[b].PeriodEnd)
+	})
+	return quarters
+}
+
+// latestFacts keeps the most recently filed value for each period so
+// restatements replace the original figures. Only 10-K and 10-Q filings
+// (and their amendments) are read.
+func latestFacts(values []factValue, noteOrigin func(time.Time, factValue)) map[factSpan]factValue {
+	out := map[factSpan]factValue{}
+	for _, v := range values {
+		if !strings.HasPrefix(v.Form, "10-K") && !strings.HasPrefix(v.Form, "10-Q") {
+			continue
+		}
+		end, err := time.Parse(time.DateOnly, v.End)
+		if err != nil {
+			continue
+		}
+		var span factSpan
+		span.end = end.UTC()
+		if v.Start != "" {
+			start, err := time.Parse(time.DateOnly, v.Start)
+			if err != nil {
+				continue
+			}
+			span.start = start.UTC()
+		}
+		noteOrigin(span.end, v)
+		if prev, ok := out[span]; !ok || v.Filed > prev.Filed {
+			out[span] = v
+		}
+	}
+	return out
+}
+
+// quarterValues reduces reported spans to one value per quarter end.
+func quarterValues(spans map[factSpan]factValue, kind lineKind) map[time.Time]float64 {
+	out := map[time.Time]float64{}
+	if kind == instantLine {
+		for span, v := range spans {
+			if span.start.IsZero() {
+				out[span.end] = v.Val
+			}
+		}
+		return out
+	}
+
+	for span, v := range spans {
+		if !span.start.IsZero() && isQuarter(span.start, span.end) {
+			out[span.end] = v.Val
+		}
+	}
+	if kind == perShareLine {
+		return out
+	}
+
+	// Derive missing quarters from year-to-date totals sharing a start date.
+	for span, v := range spans {
+		if span.start.IsZero() || isQuarter(span.start, span.end) {
+			continue
+		}
+		if _, ok := out[span.end]; ok {
+			continue
+		}
+		for prev, pv := range spans {
+			if prev.start.Equal(span.start) && prev.end.Before(span.end) && isQuarter(prev.end, span.end) {
+				out[span.end] = v.Val - pv.Val
+				break
+			}
+		}
+	}
+	return out
+}
+
+func isQuarter(start, end time.Time) bool {
+	days := end.Sub(start).Hours() / 24
+	return days >= minQuarterDays && days <= maxQuarterDays
+}
+
+// loadCompanyTickers reads SEC's company_tickers.json, keeping the first
+// listed ticker for each CIK. A missing file yields an empty map.
+func loadCompanyTickers(path string) (map[int64]string, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[int64]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries map[string]struct {
+		CIK    int64  `json:"cik_str"`
+		Ticker string `json:"ticker"`
+	}
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", companyTickersFile, err)
+	}
+
+	// Entries are keyed "0", "1", ... in SEC's ranking order.
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		x, _ := strconv.Atoi(keys[a])
+		y, _ := strconv.Atoi(keys[b])
+		return x < y
+	})
+
+	tickers := make(map[int64]string, len(entries))
+	for _, key := range keys {
+		entry := entries[key]
+		if _, ok := tickers[entry.CIK]; !ok && entry.Ticker != "" {
+			tickers[entry.CIK] = normalizeTicker(entry.Ticker)
+		}
+	}
+	return tickers, nil
+}
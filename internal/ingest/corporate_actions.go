@@ -0,0 +1,209 @@
+package ingest
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// CorporateActionImporter loads splits, dividends and symbol changes from
+// CSV files, for history a provider does not publish events for.
+type CorporateActionImporter struct {
+	log    *slog.Logger
+	market *services.MarketDataService
+	dir    string
+}
+
+// NewCorporateActionImporter reads *.csv files from dir. Each file has a
+// header naming symbol, type, ex_date and whichever of ratio, amount and
+// new_symbol its rows use, e.g.
+//
+//	symbol,type,ex_date,ratio,amount,new_symbol
+//	NVDA,split,2024-06-10,10,,
+//	AAPL,dividend,2024-08-12,,0.25,
+//	FB,symbol_change,2022-06-09,,,META
+func NewCorporateActionImporter(log *slog.Logger, market *services.MarketDataService, dir string) *CorporateActionImporter {
+	return &CorporateActionImporter{log: log, market: market, dir: dir}
+}
+
+// actionColumns maps the header names accepted for each field.
+var actionColumns = map[string][]string{
+	"symbol":     {"symbol", "ticker"},
+	"type":       {"type", "action"},
+	"ex_date":    {"ex_date", "ex-date", "ex date", "date"},
+	"ratio":      {"ratio", "split ratio"},
+	"amount":     {"amount", "dividend"},
+	"new_symbol": {"new_symbol", "new symbol", "new ticker"},
+}
+
+// actionTypes maps the type column onto stored action types.
+var actionTypes = map[string]string{
+	"split":         services.ActionSplit,
+	"dividend":      services.ActionDividend,
+	"div":           services.ActionDividend,
+	"symbol_change": services.ActionSymbolChange,
+	"symbol change": services.ActionSymbolChange,
+	"rename":        services.ActionSymbolChange,
+}
+
+// Import loads every CSV in the directory; rows already stored are updated.
+func (i *CorporateActionImporter) Import(ctx context.Context) error {
+	entries, err := os.ReadDir(i.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		i.log.Debug("corporate actions directory not found", slog.String("dir", i.dir))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read corporate actions dir: %w", err)
+	}
+
+	var files, imported, total int
+	for _, entry := range entries {
+		if entry.IsDir() || strings.ToLower(filepath.Ext(entry.Name())) != ".csv" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		files++
+
+		actions, err := readActionsFile(filepath.Join(i.dir, entry.Name()))
+		if err != nil {
+			i.log.Warn("corporate action import failed", slog.String("file", entry.Name()), slog.Any("err", err))
+			continue
+		}
+		if err := i.market.StoreActions(ctx, "csv", actions); err != nil {
+			i.log.Warn("corporate action import failed", slog.String("file", entry.Name()), slog.Any("err", err))
+			continue
+		}
+		imported++
+		total += len(actions)
+	}
+
+	if files > 0 && imported == 0 {
+		return errors.New("corporate action import failed for every file")
+	}
+
+	i.log.Info("corporate action import complete", slog.Int("files", imported), slog.Int("actions", total))
+	return nil
+}
+
+// readActionsFile parses a whole file before anything is stored, so a bad
+// row leaves the file's actions untouched rather than half imported.
+func readActionsFile(path string) ([]services.CorporateAction, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	cols := mapColumns(header, actionColumns)
+	for _, key := range []string{"symbol", "type", "ex_date"} {
+		if _, ok := cols[key]; !ok {
+			return nil, fmt.Errorf("no %s column", key)
+		}
+	}
+
+	var actions []services.CorporateAction
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read actions: %w", err)
+		}
+
+		field := func(key string) string {
+			idx, ok := cols[key]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+		if field("symbol") == "" {
+			continue
+		}
+
+		action, err := parseAction(field)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
+func parseAction(field func(string) string) (services.CorporateAction, error) {
+	action := services.CorporateAction{Symbol: normalizeTicker(field("symbol"))}
+
+	actionType, ok := actionTypes[strings.ToLower(field("type"))]
+	if !ok {
+		return action, fmt.Errorf("unknown action type %q", field("type"))
+	}
+	action.Type = actionType
+
+	exDate, err := time.Parse("2006-01-02", field("ex_date"))
+	if err != nil {
+		return action, fmt.Errorf("invalid ex date %q", field("ex_date"))
+	}
+	action.ExDate = exDate
+
+	switch actionType {
+	case services.ActionSplit:
+		action.Ratio, err = parseSplitRatio(field("ratio"))
+		if err != nil {
+			return action, err
+		}
+	case services.ActionDividend:
+		action.Amount, err = strconv.ParseFloat(strings.TrimPrefix(field("amount"), "$"), 64)
+		if err != nil || action.Amount <= 0 {
+			return action, fmt.Errorf("invalid dividend amount %q", field("amount"))
+		}
+	case services.ActionSymbolChange:
+		action.NewSymbol = normalizeTicker(field("new_symbol"))
+		if action.NewSymbol == "" || action.NewSymbol == action.Symbol {
+			return action, fmt.Errorf("invalid new symbol %q", field("new_symbol"))
+		}
+	}
+
+	return action, nil
+}
+
+// parseSplitRatio accepts "4", "4:1", "4/1" and "4-for-1" as a 4-for-1 split.
+func parseSplitRatio(raw string) (float64, error) {
+	newShares, oldShares := raw, "1"
+	for _, sep := range []string{":", "/", "-for-"} {
+		if a, b, ok := strings.Cut(strings.ToLower(raw), sep); ok {
+			newShares, oldShares = a, b
+			break
+		}
+	}
+
+	n, errN := strconv.ParseFloat(strings.TrimSpace(newShares), 64)
+	d, errD := strconv.ParseFloat(strings.TrimSpace(oldShares), 64)
+	if errN != nil || errD != nil || n <= 0 || d <= 0 {
+		return 0, fmt.Errorf("invalid split ratio %q", raw)
+	}
+	return n / d, nil
+}
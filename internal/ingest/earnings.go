@@ -0,0 +1,286 @@
+package ingest
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// EarningsImporter loads earnings calendar dumps, scheduled reports and
+// results alike, from CSV and JSON files.
+type EarningsImporter struct {
+	log      *slog.Logger
+	earnings *services.EarningsService
+	dir      string
+}
+
+// NewEarningsImporter reads *.csv and *.json files from dir. CSVs have a
+// header naming symbol, date and whichever of timing, fiscal_period,
+// eps_estimate, eps_actual, revenue_estimate and revenue_actual they carry:
+//
+//	symbol,date,timing,fiscal_period,eps_estimate,eps_actual,revenue_estimate,revenue_actual
+//	AAPL,2026-10-29,amc,Q4 2026,1.77,,102200000000,
+//
+// JSON files are an array of objects with the same fields in camelCase, or
+// a Finnhub earnings calendar response ({"earningsCalendar": [...]}, with
+// hour, quarter and year).
+func NewEarningsImporter(log *slog.Logger, earnings *services.EarningsService, dir string) *EarningsImporter {
+	return &EarningsImporter{log: log, earnings: earnings, dir: dir}
+}
+
+// earningsColumns maps the header names accepted for each field.
+var earningsColumns = map[string][]string{
+	"symbol":           {"symbol", "ticker"},
+	"date":             {"date", "report_date", "report date", "earnings date"},
+	"timing":           {"timing", "time", "hour", "when"},
+	"fiscal_period":    {"fiscal_period", "fiscal period", "period", "quarter"},
+	"eps_estimate":     {"eps_estimate", "eps estimate", "epsestimate", "eps_est"},
+	"eps_actual":       {"eps_actual", "eps actual", "epsactual", "reported eps"},
+	"revenue_estimate": {"revenue_estimate", "revenue estimate", "revenueestimate", "rev_est"},
+	"revenue_actual":   {"revenue_actual", "revenue actual", "revenueactual", "reported revenue"},
+}
+
+// earningsTimings maps the timing values vendors use onto stored timings.
+var earningsTimings = map[string]string{
+	"":                    "",
+	"tns":                 "",
+	"time not supplied":   "",
+	"bmo":                 services.EarningsBeforeOpen,
+	"before open":         services.EarningsBeforeOpen,
+	"before market open":  services.EarningsBeforeOpen,
+	"pre-market":          services.EarningsBeforeOpen,
+	"amc":                 services.EarningsAfterClose,
+	"after close":         services.EarningsAfterClose,
+	"after market close":  services.EarningsAfterClose,
+	"post-market":         services.EarningsAfterClose,
+	"dmh":                 services.EarningsDuringHours,
+	"during market hours": services.EarningsDuringHours,
+	"during market":       services.EarningsDuringHours,
+}
+
+// Import loads every CSV and JSON file in the directory; reports already
+// stored are updated.
+func (i *EarningsImporter) Import(ctx context.Context) error {
+	entries, err := os.ReadDir(i.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		i.log.Debug("earnings directory not found", slog.String("dir", i.dir))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read earnings dir: %w", err)
+	}
+
+	var files, imported, total int
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".csv" && ext != ".json") {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		files++
+
+		path := filepath.Join(i.dir, entry.Name())
+		var events []services.EarningsEvent
+		if ext == ".json" {
+			events, err = readEarningsJSON(path)
+		} else {
+			events, err = readEarningsCSV(path)
+		}
+		if err != nil {
+			i.log.Warn("earnings import failed", slog.String("file", entry.Name()), slog.Any("err", err))
+			continue
+		}
+		if err := i.earnings.Store(ctx, "file", events); err != nil {
+			i.log.Warn("earnings import failed", slog.String("file", entry.Name()), slog.Any("err", err))
+			continue
+		}
+		imported++
+		total += len(events)
+	}
+
+	if files > 0 && imported == 0 {
+		return errors.New("earnings import failed for every file")
+	}
+
+	i.log.Info("earnings import complete", slog.Int("files", imported), slog.Int("events", total))
+	return nil
+}
+
+// readEarningsCSV parses a whole file before anything is stored.
+func readEarningsCSV(path string) ([]services.EarningsEvent, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	cols := mapColumns(header, earningsColumns)
+	for _, key := range []string{"symbol", "date"} {
+		if _, ok := cols[key]; !ok {
+			return nil, fmt.Errorf("no %s column", key)
+		}
+	}
+
+	var events []services.EarningsEvent
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read earnings: %w", err)
+		}
+
+		field := func(key string) string {
+			idx, ok := cols[key]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+		if field("symbol") == "" {
+			continue
+		}
+
+		event, err := parseEarnings(field)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// earningsRecord is one JSON calendar entry; Hour, Quarter and Year are
+// Finnhub's names.
+type earningsRecord struct {
+	Symbol          string   `json:"symbol"`
+	Date            string   `json:"date"`
+	Timing          string   `json:"timing"`
+	Hour            string   `json:"hour"`
+	FiscalPeriod    string   `json:"fiscalPeriod"`
+	Quarter         int      `json:"quarter"`
+	Year            int      `json:"year"`
+	EPSEstimate     *float64 `json:"epsEstimate"`
+	EPSActual       *float64 `json:"epsActual"`
+	RevenueEstimate *float64 `json:"revenueEstimate"`
+	RevenueActual   *float64 `json:"revenueActual"`
+}
+
+func readEarningsJSON(path string) ([]services.EarningsEvent, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []earningsRecord
+	if err := json.Unmarshal(raw, &records); err != nil {
+		var wrapped struct {
+			EarningsCalendar []earningsRecord `json:"earningsCalendar"`
+		}
+		if err := json.Unmarshal(raw, &wrapped); err != nil {
+			return nil, fmt.Errorf("decode earnings: %w", err)
+		}
+		records = wrapped.EarningsCalendar
+	}
+
+	events := make([]services.EarningsEvent, 0, len(records))
+	for n, record := range records {
+		if strings.TrimSpace(record.Symbol) == "" {
+			continue
+		}
+		timing := record.Timing
+		if timing == "" {
+			timing = record.Hour
+		}
+		period := record.FiscalPeriod
+		if period == "" && record.Quarter > 0 && record.Year > 0 {
+			period = fmt.Sprintf("Q%d %d", record.Quarter, record.Year)
+		}
+
+		event, err := parseEarnings(func(key string) string {
+			switch key {
+			case "symbol":
+				return record.Symbol
+			case "date":
+				return record.Date
+			case "timing":
+				return timing
+			case "fiscal_period":
+				return period
+			}
+			return ""
+		})
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", n+1, err)
+		}
+		event.EPSEstimate = record.EPSEstimate
+		event.EPSActual = record.EPSActual
+		event.RevenueEstimate = record.RevenueEstimate
+		event.RevenueActual = record.RevenueActual
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func parseEarnings(field func(string) string) (services.EarningsEvent, error) {
+	event := services.EarningsEvent{
+		Symbol:       normalizeTicker(field("symbol")),
+		FiscalPeriod: field("fiscal_period"),
+	}
+
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(field("date")))
+	if err != nil {
+		return event, fmt.Errorf("invalid report date %q", field("date"))
+	}
+	event.ReportDate = date
+
+	timing, ok := earningsTimings[strings.ToLower(strings.TrimSpace(field("timing")))]
+	if !ok {
+		return event, fmt.Errorf("unknown report timing %q", field("timing"))
+	}
+	event.Timing = timing
+
+	for key, dst := range map[string]**float64{
+		"eps_estimate":     &event.EPSEstimate,
+		"eps_actual":       &event.EPSActual,
+		"revenue_estimate": &event.RevenueEstimate,
+		"revenue_actual":   &event.RevenueActual,
+	} {
+		raw := strings.NewReplacer("$", "", ",", "").Replace(field(key))
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return event, fmt.Errorf("invalid %s %q", strings.ReplaceAll(key, "_", " "), field(key))
+		}
+		*dst = &v
+	}
+
+	return event, nil
+}
@@ -0,0 +1,304 @@
+package ingest
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// ETFHoldingsImporter loads fund constituents from issuer holdings files.
+type ETFHoldingsImporter struct {
+	log  *slog.Logger
+	etfs *services.ETFService
+	dir  string
+}
+
+// NewETFHoldingsImporter reads *.csv files from dir, one fund per file, in
+// the layouts issuers publish: iShares and SPDR downloads, whose header row
+// follows a few lines naming the fund and the as-of date, and Invesco's flat
+// file with a fund ticker and date on every row:
+//
+//	Fund Ticker,Holding Ticker,Shares/Par Value,MarketValue,Weight,Name,Sector,Date
+//	QQQ,AAPL,123456,45678901.23,8.91,Apple Inc,Information Technology,10/15/2026
+//
+// The fund is the fund ticker column, a "Ticker Symbol:" line above the
+// header, or else the file name up to the first underscore or dash
+// (IVV_holdings.csv). Weights are percentages unless the file's weights add
+// up to about one. Holdings are dated from a date column or an "as of" line,
+// falling back to the file's modification time.
+func NewETFHoldingsImporter(log *slog.Logger, etfs *services.ETFService, dir string) *ETFHoldingsImporter {
+	return &ETFHoldingsImporter{log: log, etfs: etfs, dir: dir}
+}
+
+// etfColumns maps the header names accepted for each field.
+var etfColumns = map[string][]string{
+	"fund":         {"fund ticker", "fund_ticker", "etf", "etf_symbol", "fund"},
+	"ticker":       {"ticker", "holding ticker", "holding_ticker", "symbol"},
+	"name":         {"name", "security name", "holding name", "holding", "description"},
+	"sector":       {"sector", "gics sector"},
+	"asset_class":  {"asset class", "asset_class", "security type", "class of shares"},
+	"weight":       {"weight (%)", "weight", "% of net assets", "% of funds", "weight_pct", "percent of fund"},
+	"shares":       {"shares", "quantity", "shares held", "shares/par value", "par value"},
+	"market_value": {"market value", "marketvalue", "market_value"},
+	"date":         {"date", "as_of", "as of", "as of date"},
+}
+
+// etfDateLayouts are the date spellings issuers use.
+var etfDateLayouts = []string{"2006-01-02", "01/02/2006", "1/2/2006", "Jan 2, 2006", "January 2, 2006", "02-Jan-2006", "2-Jan-2006"}
+
+// Import loads every CSV in the directory; each file replaces the stored
+// holdings of its fund.
+func (i *ETFHoldingsImporter) Import(ctx context.Context) error {
+	entries, err := os.ReadDir(i.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		i.log.Debug("etf holdings directory not found", slog.String("dir", i.dir))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read etf holdings dir: %w", err)
+	}
+
+	var files, imported, total int
+	for _, entry := range entries {
+		if entry.IsDir() || strings.ToLower(filepath.Ext(entry.Name())) != ".csv" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		files++
+
+		fund, err := readETFHoldingsFile(filepath.Join(i.dir, entry.Name()))
+		if err != nil {
+			i.log.Warn("etf holdings import failed", slog.String("file", entry.Name()), slog.Any("err", err))
+			continue
+		}
+		if err := i.etfs.StoreHoldings(ctx, "file", fund); err != nil {
+			i.log.Warn("etf holdings import failed", slog.String("file", entry.Name()), slog.Any("err", err))
+			continue
+		}
+		imported++
+		total += len(fund.Holdings)
+	}
+
+	if files > 0 && imported == 0 {
+		return errors.New("etf holdings import failed for every file")
+	}
+
+	i.log.Info("etf holdings import complete", slog.Int("files", imported), slog.Int("holdings", total))
+	return nil
+}
+
+// readETFHoldingsFile parses a whole file before anything is stored.
+func readETFHoldingsFile(path string) (services.ETFHoldings, error) {
+	var fund services.ETFHoldings
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fund, err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return fund, err
+	}
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+
+	// Lines above the header describe the fund; the header is the first
+	// line naming both a ticker and a weight column.
+	var cols map[string]int
+	line := 0
+	for cols == nil {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return fund, errors.New("no header with ticker and weight columns")
+		}
+		if err != nil {
+			return fund, fmt.Errorf("read header: %w", err)
+		}
+		line++
+
+		found := mapColumns(record, etfColumns)
+		_, hasTicker := found["ticker"]
+		_, hasWeight := found["weight"]
+		if hasTicker && hasWeight {
+			cols = found
+			continue
+		}
+		readETFPreamble(record, &fund)
+	}
+
+	var holdings []services.ETFHolding
+	var weights float64
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fund, fmt.Errorf("read holdings: %w", err)
+		}
+		line++
+
+		field := func(key string) string {
+			idx, ok := cols[key]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+		// Disclaimers below the table leave the weight blank.
+		rawWeight := field("weight")
+		if rawWeight == "" || rawWeight == "-" {
+			continue
+		}
+		weight, err := strconv.ParseFloat(strings.NewReplacer(",", "", "%", "").Replace(rawWeight), 64)
+		if err != nil {
+			return fund, fmt.Errorf("line %d: invalid weight %q", line, rawWeight)
+		}
+
+		if fund.Symbol == "" {
+			fund.Symbol = normalizeTicker(field("fund"))
+		}
+		if fund.AsOf.IsZero() {
+			if date, ok := parseETFDate(field("date")); ok {
+				fund.AsOf = date
+			}
+		}
+
+		ticker := strings.ToUpper(field("ticker"))
+		h := services.ETFHolding{
+			Name:       field("name"),
+			Sector:     field("sector"),
+			AssetClass: holdingAssetClass(field("asset_class"), field("sector"), ticker),
+			Weight:     weight,
+		}
+		// Cash lines carry placeholders such as "-" or CASH_USD for a ticker.
+		if ticker != "-" && !strings.ContainsAny(ticker, "_ ") {
+			h.Symbol = normalizeTicker(ticker)
+		}
+		if h.Sector == "-" || h.AssetClass != services.AssetEquity {
+			h.Sector = ""
+		}
+		if h.Name == "" {
+			h.Name = h.Symbol
+		}
+		for key, dst := range map[string]**float64{"shares": &h.Shares, "market_value": &h.MarketValue} {
+			v, err := holdingNumber(field(key))
+			if err != nil {
+				return fund, fmt.Errorf("line %d: invalid %s %q", line, key, field(key))
+			}
+			*dst = v
+		}
+		holdings = append(holdings, h)
+		weights += weight
+	}
+	if len(holdings) == 0 {
+		return fund, errors.New("no holdings")
+	}
+
+	if weights > 1.5 {
+		for i := range holdings {
+			holdings[i].Weight /= 100
+		}
+	}
+	if fund.Symbol == "" {
+		stem := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		fund.Symbol, _, _ = strings.Cut(strings.ReplaceAll(stem, "-", "_"), "_")
+		fund.Symbol = normalizeTicker(fund.Symbol)
+	}
+	if fund.AsOf.IsZero() {
+		fund.AsOf = stat.ModTime()
+	}
+	fund.Holdings = holdings
+	return fund, nil
+}
+
+// readETFPreamble picks the fund ticker and as-of date out of a line above
+// the header, such as "Ticker Symbol:,SPY" or "Fund Holdings as of,Oct 15, 2026".
+func readETFPreamble(record []string, fund *services.ETFHoldings) {
+	if len(record) == 0 {
+		return
+	}
+	key := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(strings.TrimPrefix(record[0], "\ufeff")), ":"))
+	var value string
+	for _, cell := range record[1:] {
+		if cell = strings.TrimSpace(cell); cell != "" {
+			value = cell
+			break
+		}
+	}
+
+	switch {
+	case key == "ticker symbol" || key == "fund ticker" || key == "ticker":
+		fund.Symbol = normalizeTicker(value)
+	case strings.Contains(key, "as of") || key == "holdings" || key == "date":
+		if date, ok := parseETFDate(value); ok {
+			fund.AsOf = date
+		}
+	}
+}
+
+// parseETFDate reads a date in any issuer spelling, with or without a
+// leading "As of".
+func parseETFDate(raw string) (time.Time, bool) {
+	raw = strings.TrimSpace(raw)
+	if len(raw) > 6 && strings.EqualFold(raw[:6], "as of ") {
+		raw = strings.TrimSpace(raw[6:])
+	}
+	for _, layout := range etfDateLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// holdingAssetClass maps an issuer's asset class label onto the stored
+// classes. Files without one mark cash by its sector or ticker.
+func holdingAssetClass(label, sector, ticker string) string {
+	label = strings.ToLower(label)
+	switch {
+	case label == "":
+		if strings.Contains(strings.ToLower(sector), "cash") || strings.HasPrefix(ticker, "CASH") {
+			return services.AssetCash
+		}
+		return services.AssetEquity
+	case strings.Contains(label, "equity") || strings.Contains(label, "stock") || strings.Contains(label, "common"):
+		return services.AssetEquity
+	case strings.Contains(label, "cash") || strings.Contains(label, "money market") || strings.Contains(label, "currency"):
+		return services.AssetCash
+	case strings.Contains(label, "future"):
+		return services.AssetFuture
+	}
+	return services.AssetOther
+}
+
+// holdingNumber reads an optional figure such as "47,123,456.00"; blanks
+// and dashes are missing.
+func holdingNumber(raw string) (*float64, error) {
+	if raw == "" || raw == "-" || raw == "--" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(strings.ReplaceAll(raw, ",", ""), 64)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
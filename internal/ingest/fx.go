@@ -0,0 +1,233 @@
+package ingest
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// FXRateImporter loads daily exchange rates from CSV files.
+type FXRateImporter struct {
+	log *slog.Logger
+	fx  *services.FXService
+	dir string
+}
+
+// NewFXRateImporter reads *.csv files from dir, either one rate per row:
+//
+//	date,currency,rate
+//	2026-10-15,EUR,0.9214
+//
+// with rate in units of currency per US dollar, or one row per day with a
+// column per currency in the same units:
+//
+//	date,EUR,GBP,JPY
+//	2026-10-15,0.9214,0.7702,151.32
+//
+// A pair column (EURUSD, USD/JPY) can replace currency; pairs quoted in
+// dollars per unit are inverted, and pairs without the dollar are rejected.
+func NewFXRateImporter(log *slog.Logger, fx *services.FXService, dir string) *FXRateImporter {
+	return &FXRateImporter{log: log, fx: fx, dir: dir}
+}
+
+// fxColumns maps the header names accepted for each field of the long format.
+var fxColumns = map[string][]string{
+	"date":     {"date", "rate_date", "as_of"},
+	"currency": {"currency", "code", "ccy"},
+	"pair":     {"pair", "symbol"},
+	"rate":     {"rate", "value", "close", "price"},
+}
+
+// Import loads every CSV in the directory; days already stored are updated.
+func (i *FXRateImporter) Import(ctx context.Context) error {
+	entries, err := os.ReadDir(i.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		i.log.Debug("fx rates directory not found", slog.String("dir", i.dir))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read fx rates dir: %w", err)
+	}
+
+	var files, imported, total int
+	for _, entry := range entries {
+		if entry.IsDir() || strings.ToLower(filepath.Ext(entry.Name())) != ".csv" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		files++
+
+		rates, err := readFXRateFile(filepath.Join(i.dir, entry.Name()))
+		if err != nil {
+			i.log.Warn("fx rate import failed", slog.String("file", entry.Name()), slog.Any("err", err))
+			continue
+		}
+		if err := i.fx.StoreRates(ctx, "file", rates); err != nil {
+			i.log.Warn("fx rate import failed", slog.String("file", entry.Name()), slog.Any("err", err))
+			continue
+		}
+		imported++
+		total += len(rates)
+	}
+
+	if files > 0 && imported == 0 {
+		return errors.New("fx rate import failed for every file")
+	}
+
+	i.log.Info("fx rate import complete", slog.Int("files", imported), slog.Int("rates", total))
+	return nil
+}
+
+// readFXRateFile parses a whole file before anything is stored.
+func readFXRateFile(path string) ([]services.FXRate, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	cols := mapColumns(header, fxColumns)
+	if _, ok := cols["date"]; !ok {
+		return nil, errors.New("no date column")
+	}
+	_, hasCurrency := cols["currency"]
+	_, hasPair := cols["pair"]
+	long := hasCurrency || hasPair
+	if _, ok := cols["rate"]; long && !ok {
+		return nil, errors.New("no rate column")
+	}
+
+	// The wide format names a currency in every other column.
+	currencies := make([]string, len(header))
+	if !long {
+		for col, name := range header {
+			if col == cols["date"] {
+				continue
+			}
+			code, ok := services.NormalizeCurrency(name)
+			if !ok {
+				return nil, fmt.Errorf("unrecognized currency column %q", name)
+			}
+			currencies[col] = code
+		}
+	}
+
+	var rates []services.FXRate
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read rates: %w", err)
+		}
+
+		field := func(col int) string {
+			if col >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[col])
+		}
+		raw := field(cols["date"])
+		if raw == "" {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q", line, raw)
+		}
+
+		if long {
+			currency, invert, err := fxCurrency(field, cols, hasCurrency)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			rate, ok, err := fxValue(field(cols["rate"]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid rate %q", line, field(cols["rate"]))
+			}
+			if !ok || currency == services.USD {
+				continue
+			}
+			if invert {
+				rate = 1 / rate
+			}
+			rates = append(rates, services.FXRate{Currency: currency, Date: date, Rate: rate})
+			continue
+		}
+
+		for col, currency := range currencies {
+			if currency == "" || currency == services.USD {
+				continue
+			}
+			rate, ok, err := fxValue(field(col))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s rate %q", line, currency, field(col))
+			}
+			if ok {
+				rates = append(rates, services.FXRate{Currency: currency, Date: date, Rate: rate})
+			}
+		}
+	}
+
+	return rates, nil
+}
+
+// fxCurrency reads a row's currency, from the currency column or a pair,
+// and whether the pair quotes dollars per unit and needs inverting.
+func fxCurrency(field func(int) string, cols map[string]int, hasCurrency bool) (string, bool, error) {
+	if hasCurrency {
+		code, ok := services.NormalizeCurrency(field(cols["currency"]))
+		if !ok {
+			return "", false, fmt.Errorf("invalid currency %q", field(cols["currency"]))
+		}
+		return code, false, nil
+	}
+
+	raw := field(cols["pair"])
+	pair := strings.NewReplacer("/", "", "=X", "", " ", "").Replace(strings.ToUpper(raw))
+	if len(pair) != 6 {
+		return "", false, fmt.Errorf("invalid pair %q", raw)
+	}
+	switch {
+	case pair[:3] == services.USD:
+		return pair[3:], false, nil
+	case pair[3:] == services.USD:
+		return pair[:3], true, nil
+	}
+	return "", false, fmt.Errorf("pair %q is not quoted against USD", raw)
+}
+
+// fxValue reads an optional positive rate; blanks and placeholders such as
+// FRED's "." are skipped.
+func fxValue(raw string) (float64, bool, error) {
+	if raw == "" || raw == "." || raw == "-" || strings.EqualFold(raw, "N/A") {
+		return 0, false, nil
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil || v <= 0 {
+		return 0, false, fmt.Errorf("invalid rate %q", raw)
+	}
+	return v, true, nil
+}
@@ -0,0 +1,114 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/loganlanou/Financing-101/internal/macro"
+)
+
+// macroScheduleFile holds the release calendar; every other CSV in the
+// directory is a FRED series.
+const macroScheduleFile = "schedule.csv"
+
+// MacroImporter loads FRED series downloads and the release schedule.
+type MacroImporter struct {
+	log   *slog.Logger
+	macro *macro.Service
+	dir   string
+}
+
+// NewMacroImporter reads *.csv files from dir: FRED graph downloads such as
+// CPIAUCSL.csv, plus an optional schedule.csv of upcoming releases.
+func NewMacroImporter(log *slog.Logger, service *macro.Service, dir string) *MacroImporter {
+	return &MacroImporter{log: log, macro: service, dir: dir}
+}
+
+// Import loads every CSV in the directory; stored observations are updated.
+func (i *MacroImporter) Import(ctx context.Context) error {
+	entries, err := os.ReadDir(i.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		i.log.Debug("macro directory not found", slog.String("dir", i.dir))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read macro dir: %w", err)
+	}
+
+	var files, imported, observations, releases int
+	for _, entry := range entries {
+		if entry.IsDir() || strings.ToLower(filepath.Ext(entry.Name())) != ".csv" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		files++
+
+		path := filepath.Join(i.dir, entry.Name())
+		var n int
+		if strings.EqualFold(entry.Name(), macroScheduleFile) {
+			n, err = i.importSchedule(ctx, path)
+			releases += n
+		} else {
+			n, err = i.importSeries(ctx, path)
+			observations += n
+		}
+		if err != nil {
+			i.log.Warn("macro import failed", slog.String("file", entry.Name()), slog.Any("err", err))
+			continue
+		}
+		imported++
+	}
+
+	if files > 0 && imported == 0 {
+		return errors.New("macro import failed for every file")
+	}
+
+	i.log.Info("macro import complete", slog.Int("files", imported), slog.Int("observations", observations), slog.Int("releases", releases))
+	return nil
+}
+
+func (i *MacroImporter) importSeries(ctx context.Context, path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	id, observations, err := macro.ParseFRED(f)
+	if err != nil {
+		return 0, err
+	}
+	series, ok := macro.FindSeries(id)
+	if !ok {
+		series = macro.Series{ID: id, Title: id}
+	}
+	if err := i.macro.StoreSeries(ctx, series, "fred", observations); err != nil {
+		return 0, err
+	}
+	return len(observations), nil
+}
+
+func (i *MacroImporter) importSchedule(ctx context.Context, path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	releases, err := macro.ParseSchedule(f)
+	if err != nil {
+		return 0, err
+	}
+	if err := i.macro.StoreReleases(ctx, "csv", releases); err != nil {
+		return 0, err
+	}
+	return len(releases), nil
+}
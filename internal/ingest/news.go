@@ -22,6 +22,16 @@ type NewsIngestor struct {
 	parser    *gofeed.Parser
 	analyzer  *govader.SentimentIntensityAnalyzer
 	tickerLex map[string]struct{}
+	// aliasLex maps lower-cased company aliases ("nvidia") to tickers.
+	aliasLex map[string]string
+	// coinLex maps coin tickers ("BTC") to their pair symbol (BTC-USD).
+	coinLex map[string]string
+}
+
+// tickerStopwords are upper-case words in headlines that collide with real tickers.
+var tickerStopwords = map[string]struct{}{
+	"AI": {}, "CEO": {}, "CFO": {}, "EPS": {}, "ETF": {}, "EV": {}, "FDA": {}, "FED": {}, "GDP": {},
+	"IPO": {}, "IT": {}, "NYSE": {}, "SEC": {}, "UK": {}, "US": {}, "USA": {}, "CPI": {}, "ALL": {}, "NOW": {},
 }
 
 func NewNewsIngestor(log *slog.Logger, queries *database.Queries, feeds []string) *NewsIngestor {
@@ -29,19 +39,49 @@ func NewNewsIngestor(log *slog.Logger, queries *database.Queries, feeds []string
 		feeds = []string{"https://finance.yahoo.com/news/rssindex"}
 	}
 
-	lex := map[string]struct{}{}
-	for _, symbol := range []string{"AAPL", "GOOGL", "META", "MSFT", "NVDA", "AMZN", "TSLA", "SPY", "QQQ", "LMT", "XOM", "NFLX", "ORCL", "AMD", "INTC", "AVGO", "JPM", "BAC"} {
-		lex[symbol] = struct{}{}
-	}
-
 	return &NewsIngestor{
 		log:       log,
 		queries:   queries,
 		feeds:     feeds,
 		parser:    gofeed.NewParser(),
 		analyzer:  govader.NewSentimentIntensityAnalyzer(),
-		tickerLex: lex,
+		tickerLex: map[string]struct{}{},
+		aliasLex:  map[string]string{},
+		coinLex:   map[string]string{},
+	}
+}
+
+// loadLexicon refreshes the ticker and alias lookups from the symbols table.
+func (n *NewsIngestor) loadLexicon(ctx context.Context) error {
+	rows, err := n.queries.ListActiveSymbols(ctx)
+	if err != nil {
+		return err
+	}
+
+	lex := make(map[string]struct{}, len(rows))
+	aliases := map[string]string{}
+	coins := map[string]string{}
+	for _, row := range rows {
+		switch row.Type {
+		case "stock", "etf":
+			lex[row.Symbol] = struct{}{}
+		case "crypto":
+			base, _, _ := strings.Cut(row.Symbol, "-")
+			coins[base] = row.Symbol
+		default:
+			continue
+		}
+		for _, alias := range strings.Split(row.Aliases, ",") {
+			if alias = strings.ToLower(strings.TrimSpace(alias)); alias != "" {
+				aliases[alias] = row.Symbol
+			}
+		}
 	}
+
+	n.tickerLex = lex
+	n.aliasLex = aliases
+	n.coinLex = coins
+	return nil
 }
 
 // Refresh downloads the feeds and upserts the freshest articles.
@@ -50,6 +90,10 @@ func (n *NewsIngestor) Refresh(ctx context.Context, maxArticles int) error {
 		return errors.New("maxArticles must be positive")
 	}
 
+	if err := n.loadLexicon(ctx); err != nil {
+		n.log.Warn("load ticker lexicon failed", slog.Any("err", err))
+	}
+
 	type article struct {
 		title   string
 		source  string
@@ -127,26 +171,44 @@ func (n *NewsIngestor) Refresh(ctx context.Context, maxArticles int) error {
 func (n *NewsIngestor) extractTickers(text string) []string {
 	tokens := strings.FieldsFunc(text, func(r rune) bool {
 		switch r {
-		case ' ', ',', '.', ';', ':', '(', ')', '"', '\'', '?', '!':
+		case ' ', ',', '.', ';', ':', '(', ')', '"', '\'', '?', '!', '\u2019':
 			return true
 		}
 		return false
 	})
 	seen := map[string]struct{}{}
 	tickers := make([]string, 0, len(tokens))
+	add := func(symbol string) {
+		if _, exists := seen[symbol]; exists {
+			return
+		}
+		seen[symbol] = struct{}{}
+		tickers = append(tickers, symbol)
+	}
 	for _, token := range tokens {
-		upper := strings.ToUpper(token)
-		if len(upper) < 2 || len(upper) > 5 {
+		// Company names ("Nvidia") count in any case; bare tickers only when
+		// written upper-case or as a $cashtag, since the full symbol list
+		// contains plenty of ordinary words. A coin's cashtag ("$BTC") wins
+		// over a stock sharing its ticker.
+		if symbol, ok := n.aliasLex[strings.ToLower(token)]; ok {
+			add(symbol)
 			continue
 		}
-		if _, ok := n.tickerLex[upper]; !ok {
+		upper := strings.TrimPrefix(token, "$")
+		if upper != strings.ToUpper(upper) || len(upper) < 2 || len(upper) > 5 {
 			continue
 		}
-		if _, exists := seen[upper]; exists {
+		if _, stop := tickerStopwords[upper]; stop && upper == token {
 			continue
 		}
-		seen[upper] = struct{}{}
-		tickers = append(tickers, upper)
+		coin, isCoin := n.coinLex[upper]
+		_, listed := n.tickerLex[upper]
+		switch {
+		case isCoin && (upper != token || !listed):
+			add(coin)
+		case listed:
+			add(upper)
+		}
 	}
 
 	if len(tickers) == 0 {
@@ -0,0 +1,275 @@
+package ingest
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/options"
+)
+
+// OptionChainImporter loads option chain snapshots from CSV files.
+type OptionChainImporter struct {
+	log     *slog.Logger
+	options *options.Service
+	dir     string
+}
+
+// NewOptionChainImporter reads *.csv files from dir, one row per contract:
+//
+//	symbol,expiration,type,strike,bid,ask,last,volume,open_interest,implied_volatility
+//	AAPL,2026-11-20,call,250,12.40,12.65,12.50,1830,24410,0.284
+//
+// An OCC contract symbol column (AAPL261120C00250000) can stand in for
+// symbol, expiration, type and strike. Implied volatility is a fraction
+// unless written with a percent sign. Quotes are dated with an as_of column
+// when present and the file's modification time otherwise.
+func NewOptionChainImporter(log *slog.Logger, chains *options.Service, dir string) *OptionChainImporter {
+	return &OptionChainImporter{log: log, options: chains, dir: dir}
+}
+
+// optionColumns maps the header names accepted for each field.
+var optionColumns = map[string][]string{
+	"contract":      {"contract", "contractsymbol", "contract_symbol", "option_symbol"},
+	"symbol":        {"symbol", "underlying", "ticker"},
+	"expiration":    {"expiration", "expiration_date", "expiry", "exp_date"},
+	"type":          {"type", "option_type", "kind", "put_call", "call_put"},
+	"strike":        {"strike", "strike_price"},
+	"bid":           {"bid"},
+	"ask":           {"ask"},
+	"last":          {"last", "last_price", "lastprice", "mark"},
+	"volume":        {"volume", "vol"},
+	"open_interest": {"open_interest", "openinterest", "oi", "open interest"},
+	"iv":            {"implied_volatility", "impliedvolatility", "iv"},
+	"as_of":         {"as_of", "quote_date", "date"},
+}
+
+// Import loads every CSV in the directory; each file replaces the stored
+// chains for the expirations it covers.
+func (i *OptionChainImporter) Import(ctx context.Context) error {
+	entries, err := os.ReadDir(i.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		i.log.Debug("option chains directory not found", slog.String("dir", i.dir))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read option chains dir: %w", err)
+	}
+
+	var files, imported, total int
+	for _, entry := range entries {
+		if entry.IsDir() || strings.ToLower(filepath.Ext(entry.Name())) != ".csv" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		files++
+
+		contracts, err := readOptionChainFile(filepath.Join(i.dir, entry.Name()))
+		if err != nil {
+			i.log.Warn("option chain import failed", slog.String("file", entry.Name()), slog.Any("err", err))
+			continue
+		}
+		if err := i.options.StoreChain(ctx, "file", contracts); err != nil {
+			i.log.Warn("option chain import failed", slog.String("file", entry.Name()), slog.Any("err", err))
+			continue
+		}
+		imported++
+		total += len(contracts)
+	}
+
+	if files > 0 && imported == 0 {
+		return errors.New("option chain import failed for every file")
+	}
+
+	i.log.Info("option chain import complete", slog.Int("files", imported), slog.Int("contracts", total))
+	return nil
+}
+
+// readOptionChainFile parses a whole file before anything is stored.
+func readOptionChainFile(path string) ([]options.Contract, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	cols := mapColumns(header, optionColumns)
+	if _, ok := cols["contract"]; !ok {
+		for _, key := range []string{"symbol", "expiration", "type", "strike"} {
+			if _, ok := cols[key]; !ok {
+				return nil, fmt.Errorf("no %s or contract column", key)
+			}
+		}
+	}
+
+	var contracts []options.Contract
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read contracts: %w", err)
+		}
+
+		field := func(key string) string {
+			idx, ok := cols[key]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+		if field("contract") == "" && field("symbol") == "" {
+			continue
+		}
+
+		c, err := parseOptionContract(field, stat.ModTime())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		contracts = append(contracts, c)
+	}
+
+	return contracts, nil
+}
+
+func parseOptionContract(field func(string) string, modTime time.Time) (options.Contract, error) {
+	var c options.Contract
+	if occ := field("contract"); occ != "" && (field("expiration") == "" || field("strike") == "") {
+		parsed, err := parseOCCSymbol(occ)
+		if err != nil {
+			return c, err
+		}
+		c = parsed
+	} else {
+		c.Symbol = normalizeTicker(field("symbol"))
+		expiration, err := time.Parse("2006-01-02", field("expiration"))
+		if err != nil {
+			return c, fmt.Errorf("invalid expiration %q", field("expiration"))
+		}
+		c.Expiration = expiration
+		switch strings.ToLower(field("type")) {
+		case "call", "c", "calls":
+			c.Kind = options.Call
+		case "put", "p", "puts":
+			c.Kind = options.Put
+		default:
+			return c, fmt.Errorf("unknown option type %q", field("type"))
+		}
+		strike, err := strconv.ParseFloat(field("strike"), 64)
+		if err != nil || strike <= 0 {
+			return c, fmt.Errorf("invalid strike %q", field("strike"))
+		}
+		c.Strike = strike
+	}
+
+	c.AsOf = modTime
+	if raw := field("as_of"); raw != "" {
+		asOf, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return c, fmt.Errorf("invalid as_of %q", raw)
+		}
+		c.AsOf = asOf
+	}
+
+	for key, dst := range map[string]**float64{"bid": &c.Bid, "ask": &c.Ask, "last": &c.Last} {
+		v, err := optionNumber(field(key))
+		if err != nil {
+			return c, fmt.Errorf("invalid %s %q", key, field(key))
+		}
+		*dst = v
+	}
+	for key, dst := range map[string]*int64{"volume": &c.Volume, "open_interest": &c.OpenInterest} {
+		v, err := optionNumber(field(key))
+		if err != nil {
+			return c, fmt.Errorf("invalid %s %q", strings.ReplaceAll(key, "_", " "), field(key))
+		}
+		if v != nil {
+			*dst = int64(*v)
+		}
+	}
+
+	raw := field("iv")
+	percent := strings.HasSuffix(raw, "%")
+	iv, err := optionNumber(strings.TrimSuffix(raw, "%"))
+	if err != nil {
+		return c, fmt.Errorf("invalid implied volatility %q", raw)
+	}
+	if iv != nil && percent {
+		*iv /= 100
+	}
+	c.VendorIV = iv
+
+	return c, nil
+}
+
+// optionNumber reads an optional number; blanks and vendor placeholders
+// such as "-" are nil.
+func optionNumber(raw string) (*float64, error) {
+	raw = strings.ReplaceAll(raw, ",", "")
+	if raw == "" || raw == "-" || strings.EqualFold(raw, "N/A") {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// parseOCCSymbol reads an OCC option symbol: the root, a YYMMDD expiration,
+// C or P, and the strike times 1000 in eight digits.
+func parseOCCSymbol(occ string) (options.Contract, error) {
+	var c options.Contract
+	occ = strings.ToUpper(strings.ReplaceAll(occ, " ", ""))
+	if len(occ) < 16 {
+		return c, fmt.Errorf("invalid contract symbol %q", occ)
+	}
+	tail := occ[len(occ)-15:]
+
+	expiration, err := time.Parse("060102", tail[:6])
+	if err != nil {
+		return c, fmt.Errorf("invalid contract symbol %q", occ)
+	}
+	strike, err := strconv.ParseInt(tail[7:], 10, 64)
+	if err != nil || strike <= 0 {
+		return c, fmt.Errorf("invalid contract symbol %q", occ)
+	}
+	switch tail[6] {
+	case 'C':
+		c.Kind = options.Call
+	case 'P':
+		c.Kind = options.Put
+	default:
+		return c, fmt.Errorf("invalid contract symbol %q", occ)
+	}
+
+	c.Symbol = normalizeTicker(occ[:len(occ)-15])
+	c.Expiration = expiration
+	c.Strike = float64(strike) / 1000
+	return c, nil
+}
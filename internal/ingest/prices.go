@@ -0,0 +1,172 @@
+package ingest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/database"
+	"github.com/loganlanou/Financing-101/internal/marketcalendar"
+	"github.com/loganlanou/Financing-101/internal/services"
+	"log/slog"
+)
+
+// PriceBackfiller keeps the price_bars store current for a symbol universe,
+// fetching only the ranges that are not stored yet. Splits and dividends
+// over the whole lookback are refreshed whenever new bars arrive.
+type PriceBackfiller struct {
+	log      *slog.Logger
+	queries  *database.Queries
+	market   *services.MarketDataService
+	symbols  []string
+	lookback time.Duration
+}
+
+func NewPriceBackfiller(log *slog.Logger, queries *database.Queries, market *services.MarketDataService, symbols []string, lookback time.Duration) *PriceBackfiller {
+	return &PriceBackfiller{
+		log:      log,
+		queries:  queries,
+		market:   market,
+		symbols:  symbols,
+		lookback: lookback,
+	}
+}
+
+// Backfill brings daily bars for every symbol up to date.
+func (b *PriceBackfiller) Backfill(ctx context.Context) error {
+	if len(b.symbols) == 0 {
+		return errors.New("no symbols configured for price backfill")
+	}
+
+	failed, stored := 0, 0
+	for _, symbol := range b.symbols {
+		n, err := b.backfillSymbol(ctx, symbol, "1d")
+		if err != nil {
+			failed++
+			b.log.Warn("price backfill failed", slog.String("symbol", symbol), slog.Any("err", err))
+			continue
+		}
+		stored += n
+		if n > 0 {
+			b.refreshActions(ctx, symbol)
+		}
+	}
+
+	if failed == len(b.symbols) {
+		return errors.New("price backfill failed for every symbol")
+	}
+
+	b.log.Info("price backfill complete", slog.Int("symbols", len(b.symbols)-failed), slog.Int("bars", stored))
+	return nil
+}
+
+type timeRange struct {
+	from, to time.Time
+	// head ranges reach back to the lookback start; once fetched, the
+	// vendor has nothing earlier than the bars it returned.
+	head bool
+}
+
+func (b *PriceBackfiller) backfillSymbol(ctx context.Context, symbol, interval string) (int, error) {
+	ranges, err := b.missingRanges(ctx, symbol, interval, time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	stored := 0
+	for _, r := range ranges {
+		bars, source, err := b.market.FetchHistory(ctx, symbol, interval, r.from, r.to)
+		if err != nil {
+			return stored, err
+		}
+		if err := b.market.StoreHistory(ctx, symbol, interval, source, bars); err != nil {
+			return stored, err
+		}
+		stored += len(bars)
+		if r.head {
+			err := b.queries.UpsertPriceCoverage(ctx, database.UpsertPriceCoverageParams{Symbol: symbol, Interval: interval, CoveredFrom: r.from})
+			if err != nil {
+				return stored, fmt.Errorf("record coverage: %w", err)
+			}
+		}
+	}
+
+	return stored, nil
+}
+
+// refreshActions stores the symbol's corporate actions. Failures only log:
+// bars stay usable unadjusted, and the next backfill tries again.
+func (b *PriceBackfiller) refreshActions(ctx context.Context, symbol string) {
+	now := time.Now().UTC()
+	actions, source, err := b.market.FetchActions(ctx, symbol, now.Add(-b.lookback), now)
+	if errors.Is(err, services.ErrNotSupported) {
+		return
+	}
+	if err != nil {
+		b.log.Warn("corporate action fetch failed", slog.String("symbol", symbol), slog.Any("err", err))
+		return
+	}
+	if err := b.market.StoreActions(ctx, source, actions); err != nil {
+		b.log.Warn("corporate action store failed", slog.String("symbol", symbol), slog.Any("err", err))
+	}
+}
+
+// missingRanges compares the stored span against the lookback window and
+// returns the head and tail gaps still to fetch. The head is skipped once
+// it has been asked for, since recent listings have nothing earlier.
+func (b *PriceBackfiller) missingRanges(ctx context.Context, symbol, interval string, now time.Time) ([]timeRange, error) {
+	start := now.Add(-b.lookback)
+
+	earliest, err := b.queries.GetEarliestPriceBar(ctx, database.GetEarliestPriceBarParams{Symbol: symbol, Interval: interval})
+	if errors.Is(err, sql.ErrNoRows) {
+		return []timeRange{{from: start, to: now, head: true}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read earliest bar: %w", err)
+	}
+
+	coveredFrom := earliest.BarTime
+	asked, err := b.queries.GetPriceCoverage(ctx, database.GetPriceCoverageParams{Symbol: symbol, Interval: interval})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("read price coverage: %w", err)
+	}
+	if err == nil && asked.Before(coveredFrom) {
+		coveredFrom = asked
+	}
+
+	latest, err := b.queries.GetLatestPriceBar(ctx, database.GetLatestPriceBarParams{Symbol: symbol, Interval: interval})
+	if err != nil {
+		return nil, fmt.Errorf("read latest bar: %w", err)
+	}
+
+	// Weekends and holidays mean the edges never line up exactly.
+	const slack = 4 * 24 * time.Hour
+
+	var ranges []timeRange
+	if coveredFrom.After(start.Add(slack)) {
+		ranges = append(ranges, timeRange{from: start, to: earliest.BarTime, head: true})
+	}
+	// Refetch from the latest stored bar until it covers the last completed
+	// session, and for a day after that close so a partial bar gets its final values.
+	sessionDate, closedAt := lastCompletedDay(symbol, now)
+	if latest.BarTime.Before(sessionDate) || (latest.BarTime.Equal(sessionDate) && now.Sub(closedAt) < 24*time.Hour) {
+		ranges = append(ranges, timeRange{from: latest.BarTime, to: now})
+	}
+
+	return ranges, nil
+}
+
+// lastCompletedDay is the date key of the latest daily bar that had closed
+// by now, and when it closed. Coins trade around the clock and their daily
+// candles close at midnight UTC.
+func lastCompletedDay(symbol string, now time.Time) (time.Time, time.Time) {
+	if services.IsCrypto(symbol) {
+		utc := now.UTC()
+		today := time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, time.UTC)
+		return today.AddDate(0, 0, -1), today
+	}
+	session, _ := marketcalendar.SessionOn(marketcalendar.LastCompletedSession(now))
+	return time.Date(session.Date.Year(), session.Date.Month(), session.Date.Day(), 0, 0, 0, 0, time.UTC), session.Close
+}
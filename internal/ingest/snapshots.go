@@ -0,0 +1,209 @@
+package ingest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/loganlanou/Financing-101/internal/database"
+	"github.com/loganlanou/Financing-101/internal/services"
+	"log/slog"
+)
+
+// snapshotWindows are the trailing calendar-day windows stored on stock_snapshots.
+var snapshotWindows = [3]int{30, 90, 365}
+
+// SnapshotBuilder derives stock_snapshots returns and benchmark-relative
+// performance from the daily bars in the price store, adjusted for splits
+// and dividends so returns are total returns. Listings quoted in another
+// currency also get their returns in dollars, which the benchmark comparison
+// uses.
+type SnapshotBuilder struct {
+	log        *slog.Logger
+	queries    *database.Queries
+	market     *services.MarketDataService
+	risk       *services.RiskService
+	fx         *services.FXService
+	universe   []string
+	benchmarks []string
+}
+
+// NewSnapshotBuilder compares each symbol in universe against the first
+// benchmark with enough stored history, so "SPY,^GSPC" falls back to the
+// index. Returns are total returns, so a fund whose dividends are stored
+// belongs first; a price index like ^GSPC leaves its dividend yield out.
+// Risk statistics against the same benchmark are refreshed with each snapshot.
+func NewSnapshotBuilder(log *slog.Logger, queries *database.Queries, market *services.MarketDataService, risk *services.RiskService, fx *services.FXService, universe, benchmarks []string) *SnapshotBuilder {
+	return &SnapshotBuilder{
+		log:        log,
+		queries:    queries,
+		market:     market,
+		risk:       risk,
+		fx:         fx,
+		universe:   universe,
+		benchmarks: benchmarks,
+	}
+}
+
+// Build recomputes and upserts a snapshot for every symbol in the universe.
+func (b *SnapshotBuilder) Build(ctx context.Context) error {
+	if len(b.universe) == 0 {
+		return errors.New("no symbols configured for snapshots")
+	}
+	if len(b.benchmarks) == 0 {
+		return errors.New("no benchmark configured for snapshots")
+	}
+
+	now := time.Now().UTC()
+	// Risk statistics reach further back than the snapshot windows.
+	from := now.AddDate(0, 0, -services.RiskHistoryDays)
+
+	benchmarks := make(map[string][]services.HistoricalData, len(b.benchmarks))
+	for _, symbol := range b.benchmarks {
+		bars, err := b.market.AdjustedHistory(ctx, symbol, "1d", from, now)
+		if err != nil {
+			return fmt.Errorf("read benchmark %s: %w", symbol, err)
+		}
+		benchmarks[symbol] = bars
+	}
+
+	built := 0
+	for _, symbol := range b.universe {
+		if err := b.buildSymbol(ctx, symbol, benchmarks, from, now); err != nil {
+			b.log.Warn("snapshot build failed", slog.String("symbol", symbol), slog.Any("err", err))
+			continue
+		}
+		built++
+	}
+
+	if built == 0 {
+		return errors.New("snapshot build failed for every symbol")
+	}
+
+	b.log.Info("snapshot build complete", slog.Int("symbols", built))
+	return nil
+}
+
+func (b *SnapshotBuilder) buildSymbol(ctx context.Context, symbol string, benchmarks map[string][]services.HistoricalData, from, now time.Time) error {
+	bars, err := b.market.AdjustedHistory(ctx, symbol, "1d", from, now)
+	if err != nil {
+		return fmt.Errorf("read bars: %w", err)
+	}
+	if len(bars) == 0 {
+		return errors.New("no stored bars")
+	}
+	asOf := bars[len(bars)-1].Timestamp
+
+	var changes [3]float64
+	for i, days := range snapshotWindows {
+		change, ok := services.TrailingReturn(bars, asOf.AddDate(0, 0, -days), asOf)
+		if !ok {
+			return fmt.Errorf("insufficient history for %d-day return", days)
+		}
+		changes[i] = change
+	}
+
+	// The listing currency comes from the last quote the app fetched, not a
+	// live call, so a build spends no vendor quota; the stored one is kept
+	// until the symbol has been quoted.
+	existing, err := b.queries.GetStockSnapshotBySymbol(ctx, symbol)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("read existing snapshot: %w", err)
+	}
+	hasExisting := err == nil
+	currency := services.USD
+	if hasExisting {
+		currency = existing.Currency
+	}
+	quote, quoted := b.market.LastQuote(symbol)
+	if quoted && quote.Currency != "" {
+		currency = quote.Currency
+	}
+
+	// Benchmarks are priced in dollars, so compare dollar returns when the
+	// exchange rate history covers every window.
+	dollars, usdChanges := changes, [3]sql.NullFloat64{}
+	for i, days := range snapshotWindows {
+		change, err := b.fx.ConvertReturn(ctx, changes[i], currency, services.USD, asOf.AddDate(0, 0, -days), asOf)
+		if err != nil {
+			b.log.Debug("comparing local currency returns", slog.String("symbol", symbol), slog.String("currency", currency), slog.Any("err", err))
+			dollars, usdChanges = changes, [3]sql.NullFloat64{}
+			break
+		}
+		dollars[i] = change
+		usdChanges[i] = sql.NullFloat64{Float64: change, Valid: true}
+	}
+
+	// Use the first benchmark that covers every window ending on the same date.
+	var excess [3]float64
+	benchmark := ""
+	for _, candidate := range b.benchmarks {
+		var bench [3]float64
+		ok := true
+		for i, days := range snapshotWindows {
+			if bench[i], ok = services.TrailingReturn(benchmarks[candidate], asOf.AddDate(0, 0, -days), asOf); !ok {
+				break
+			}
+		}
+		if ok {
+			benchmark = candidate
+			for i := range excess {
+				excess[i] = dollars[i] - bench[i]
+			}
+			break
+		}
+	}
+	if benchmark == "" {
+		return errors.New("no benchmark history covers the snapshot windows")
+	}
+
+	arg := database.InsertStockSnapshotParams{
+		ID:           snapshotID(symbol),
+		Symbol:       symbol,
+		Name:         symbol,
+		Change30:     changes[0],
+		Change90:     changes[1],
+		Change365:    changes[2],
+		VsSp50030:    excess[0],
+		VsSp50090:    excess[1],
+		VsSp500365:   excess[2],
+		Conviction:   "Watching",
+		UpdatedAt:    now,
+		Currency:     currency,
+		UsdChange30:  usdChanges[0],
+		UsdChange90:  usdChanges[1],
+		UsdChange365: usdChanges[2],
+	}
+
+	// Keep the editorial fields and row identity of an existing snapshot.
+	if hasExisting {
+		arg.ID = existing.ID
+		arg.Name = existing.Name
+		arg.Sector = existing.Sector
+		arg.Industry = existing.Industry
+		arg.Conviction = existing.Conviction
+		arg.Thesis = existing.Thesis
+	} else if listing, err := b.queries.GetSymbol(ctx, symbol); err == nil {
+		arg.Name = listing.Name
+	} else if quoted && quote.Name != "" {
+		arg.Name = quote.Name
+	}
+
+	if err := b.queries.InsertStockSnapshot(ctx, arg); err != nil {
+		return fmt.Errorf("upsert snapshot: %w", err)
+	}
+
+	if err := b.risk.Refresh(ctx, symbol, bars, benchmark, benchmarks[benchmark]); err != nil {
+		return fmt.Errorf("refresh risk stats: %w", err)
+	}
+
+	b.log.Debug("snapshot updated", slog.String("symbol", symbol), slog.String("benchmark", benchmark), slog.Time("as_of", asOf))
+	return nil
+}
+
+func snapshotID(symbol string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("stock_snapshot:"+symbol)).String()
+}
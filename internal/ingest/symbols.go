@@ -0,0 +1,287 @@
+package ingest
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/database"
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// SymbolImporter loads exchange listing files into the symbols table.
+type SymbolImporter struct {
+	log     *slog.Logger
+	db      *sql.DB
+	queries *database.Queries
+	dir     string
+}
+
+// NewSymbolImporter reads listing files from dir. Both Nasdaq Trader's
+// pipe-delimited nasdaqlisted.txt/otherlisted.txt and the comma-separated
+// screener downloads from nasdaq.com are understood.
+// Each file is written in one transaction, so a listing of thousands of
+// rows holds the write lock briefly instead of once per row.
+func NewSymbolImporter(log *slog.Logger, db *sql.DB, queries *database.Queries, dir string) *SymbolImporter {
+	return &SymbolImporter{log: log, db: db, queries: queries, dir: dir}
+}
+
+// listingColumns maps the header names used by the supported formats.
+var listingColumns = map[string][]string{
+	"symbol":   {"symbol", "act symbol", "ticker"},
+	"name":     {"security name", "name", "company name"},
+	"exchange": {"exchange", "listing exchange"},
+	"etf":      {"etf"},
+	"test":     {"test issue"},
+	"sector":   {"sector"},
+	"market":   {"market category"},
+}
+
+// otherListedExchanges decodes the Exchange column of otherlisted.txt.
+var otherListedExchanges = map[string]string{
+	"A": "NYSE American",
+	"N": "NYSE",
+	"P": "NYSE Arca",
+	"Z": "Cboe BZX",
+	"V": "IEX",
+}
+
+// Import loads every .csv and .txt listing in the directory. Symbols an
+// exchange's file no longer lists are marked inactive rather than deleted
+// so historical rows keep resolving.
+func (i *SymbolImporter) Import(ctx context.Context) error {
+	entries, err := os.ReadDir(i.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		i.log.Debug("symbol listings directory not found", slog.String("dir", i.dir))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read symbol listings dir: %w", err)
+	}
+
+	// Several files can list the same exchange, so delisted symbols are only
+	// swept once every file has been read.
+	started := time.Now().UTC()
+	exchanges := map[string]struct{}{}
+
+	var files, imported, total int
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".csv" && ext != ".txt") {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		files++
+
+		n, err := i.importFile(ctx, filepath.Join(i.dir, entry.Name()), started, exchanges)
+		if err != nil {
+			i.log.Warn("symbol listing import failed", slog.String("file", entry.Name()), slog.Any("err", err))
+			continue
+		}
+		imported++
+		total += n
+	}
+
+	if files > 0 && imported == 0 {
+		return errors.New("symbol listing import failed for every file")
+	}
+
+	for exchange := range exchanges {
+		if err := i.queries.DeactivateMissingSymbols(ctx, database.DeactivateMissingSymbolsParams{
+			Exchange:   exchange,
+			SeenBefore: started,
+		}); err != nil {
+			return fmt.Errorf("deactivate delisted %s symbols: %w", exchange, err)
+		}
+	}
+
+	i.log.Info("symbol listing import complete", slog.Int("files", imported), slog.Int("symbols", total))
+	return nil
+}
+
+func (i *SymbolImporter) importFile(ctx context.Context, path string, started time.Time, exchanges map[string]struct{}) (int, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	listed := map[string]struct{}{}
+	count, err := importListing(ctx, i.queries.WithTx(tx), path, string(raw), started, listed)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	// Only exchanges from committed files are swept for delistings.
+	for exchange := range listed {
+		exchanges[exchange] = struct{}{}
+	}
+	return count, nil
+}
+
+// importListing upserts one file's rows, stamping them with started and
+// recording each exchange it lists.
+func importListing(ctx context.Context, queries *database.Queries, path, text string, started time.Time, exchanges map[string]struct{}) (int, error) {
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	if firstLine, _, _ := strings.Cut(text, "\n"); strings.Count(firstLine, "|") > strings.Count(firstLine, ",") {
+		reader.Comma = '|'
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return 0, fmt.Errorf("read header: %w", err)
+	}
+	cols := mapColumns(header, listingColumns)
+	if _, ok := cols["symbol"]; !ok {
+		return 0, errors.New("no symbol column")
+	}
+	if _, ok := cols["name"]; !ok {
+		return 0, errors.New("no name column")
+	}
+
+	// nasdaqlisted.txt has no exchange column; everything in it trades on Nasdaq.
+	fallbackExchange := exchangeFromFilename(path)
+	if _, ok := cols["market"]; ok {
+		fallbackExchange = "NASDAQ"
+	}
+
+	count := 0
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return count, fmt.Errorf("read listing: %w", err)
+		}
+
+		field := func(key string) string {
+			idx, ok := cols[key]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+
+		symbol := normalizeTicker(field("symbol"))
+		// Nasdaq Trader files end with a "File Creation Time" trailer.
+		if symbol == "" || strings.HasPrefix(symbol, "FILE CREATION TIME") || field("test") == "Y" {
+			continue
+		}
+
+		exchange := fallbackExchange
+		if code := field("exchange"); code != "" {
+			if name, ok := otherListedExchanges[code]; ok {
+				exchange = name
+			} else {
+				exchange = code
+			}
+		}
+		if exchange == "" {
+			return count, errors.New("no exchange column; name the file after its exchange, e.g. nyse.csv")
+		}
+
+		symbolType := "stock"
+		if field("etf") == "Y" {
+			symbolType = "etf"
+		}
+
+		sector := sql.NullString{}
+		if s := field("sector"); s != "" {
+			sector = sql.NullString{String: s, Valid: true}
+		}
+
+		if err := queries.UpsertSymbol(ctx, database.UpsertSymbolParams{
+			Symbol:    symbol,
+			Name:      cleanSecurityName(field("name")),
+			Exchange:  exchange,
+			Type:      symbolType,
+			Sector:    sector,
+			Active:    true,
+			UpdatedAt: started,
+		}); err != nil {
+			return count, fmt.Errorf("upsert %s: %w", symbol, err)
+		}
+		exchanges[exchange] = struct{}{}
+		count++
+	}
+
+	return count, nil
+}
+
+// mapColumns finds each key's column by the header names in columns.
+func mapColumns(header []string, columns map[string][]string) map[string]int {
+	cols := map[string]int{}
+	for idx, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		for key, aliases := range columns {
+			if _, done := cols[key]; done {
+				continue
+			}
+			for _, alias := range aliases {
+				if name == alias {
+					cols[key] = idx
+				}
+			}
+		}
+	}
+	return cols
+}
+
+// exchangeFromFilename lets screener downloads such as nyse.csv, which carry
+// no exchange column, be attributed to an exchange.
+func exchangeFromFilename(path string) string {
+	base := strings.ToLower(filepath.Base(path))
+	switch {
+	case strings.Contains(base, "amex"):
+		return "NYSE American"
+	case strings.Contains(base, "nyse"):
+		return "NYSE"
+	case strings.Contains(base, "nasdaq"):
+		return "NASDAQ"
+	}
+	return ""
+}
+
+// normalizeTicker maps class shares written "BRK/B" (Nasdaq) or "BRK-B"
+// (SEC) to the "BRK.B" form used by the rest of the app. Coin pairs such
+// as BTC-USD keep their dash.
+func normalizeTicker(ticker string) string {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if services.IsCrypto(ticker) {
+		return ticker
+	}
+	return strings.NewReplacer("/", ".", "-", ".").Replace(ticker)
+}
+
+// cleanSecurityName drops the share class boilerplate Nasdaq appends, e.g.
+// "Apple Inc. - Common Stock".
+func cleanSecurityName(name string) string {
+	for _, suffix := range []string{" - Common Stock", " Common Stock", " Common Shares"} {
+		if trimmed, ok := strings.CutSuffix(name, suffix); ok {
+			return strings.TrimSpace(trimmed)
+		}
+	}
+	return name
+}
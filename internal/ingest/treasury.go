@@ -0,0 +1,166 @@
+package ingest
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/services"
+)
+
+// TreasuryYieldImporter loads Treasury "Daily Treasury Par Yield Curve
+// Rates" CSV downloads.
+type TreasuryYieldImporter struct {
+	log    *slog.Logger
+	curves *services.YieldCurveService
+	dir    string
+}
+
+// NewTreasuryYieldImporter reads *.csv files from dir in the format
+// treasury.gov exports, newest day first:
+//
+//	Date,1 Mo,1.5 Month,2 Mo,3 Mo,4 Mo,6 Mo,1 Yr,2 Yr,3 Yr,5 Yr,7 Yr,10 Yr,20 Yr,30 Yr
+//	10/15/2026,4.12,4.10,4.08,4.02,3.98,3.90,3.72,3.55,3.50,3.58,3.72,3.95,4.40,4.48
+//
+// Tenors the Treasury did not publish on a day (the 4-month bill before
+// 2022, for example) are left blank and skipped.
+func NewTreasuryYieldImporter(log *slog.Logger, curves *services.YieldCurveService, dir string) *TreasuryYieldImporter {
+	return &TreasuryYieldImporter{log: log, curves: curves, dir: dir}
+}
+
+// Import loads every CSV in the directory; days already stored are updated.
+func (i *TreasuryYieldImporter) Import(ctx context.Context) error {
+	entries, err := os.ReadDir(i.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		i.log.Debug("treasury yields directory not found", slog.String("dir", i.dir))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read treasury yields dir: %w", err)
+	}
+
+	var files, imported, days int
+	for _, entry := range entries {
+		if entry.IsDir() || strings.ToLower(filepath.Ext(entry.Name())) != ".csv" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		files++
+
+		curves, err := readYieldCurveFile(filepath.Join(i.dir, entry.Name()))
+		if err != nil {
+			i.log.Warn("treasury yield import failed", slog.String("file", entry.Name()), slog.Any("err", err))
+			continue
+		}
+		if err := i.curves.StoreCurves(ctx, "treasury", curves); err != nil {
+			i.log.Warn("treasury yield import failed", slog.String("file", entry.Name()), slog.Any("err", err))
+			continue
+		}
+		imported++
+		days += len(curves)
+	}
+
+	if files > 0 && imported == 0 {
+		return errors.New("treasury yield import failed for every file")
+	}
+
+	i.log.Info("treasury yield import complete", slog.Int("files", imported), slog.Int("days", days))
+	return nil
+}
+
+// readYieldCurveFile parses a whole file before anything is stored.
+func readYieldCurveFile(path string) ([]services.YieldCurve, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	if len(header) == 0 || !strings.EqualFold(strings.TrimPrefix(strings.TrimSpace(header[0]), "\ufeff"), "date") {
+		return nil, errors.New("no Date column")
+	}
+	tenors := make([]float64, len(header))
+	for col := 1; col < len(header); col++ {
+		months, err := parseTenor(header[col])
+		if err != nil {
+			return nil, err
+		}
+		tenors[col] = months
+	}
+
+	var curves []services.YieldCurve
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read yields: %w", err)
+		}
+		if strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		date, err := time.Parse("01/02/2006", strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q", line, record[0])
+		}
+		curve := services.YieldCurve{Date: date}
+		for col := 1; col < len(header) && col < len(record); col++ {
+			raw := strings.TrimSpace(record[col])
+			if raw == "" || strings.EqualFold(raw, "N/A") {
+				continue
+			}
+			yield, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s yield %q", line, header[col], raw)
+			}
+			curve.Points = append(curve.Points, services.YieldPoint{
+				Tenor:  services.TenorLabel(tenors[col]),
+				Months: tenors[col],
+				Yield:  yield,
+			})
+		}
+		if len(curve.Points) > 0 {
+			curves = append(curves, curve)
+		}
+	}
+
+	return curves, nil
+}
+
+// parseTenor reads Treasury column headers such as "1 Mo", "1.5 Month" and
+// "10 Yr" as a number of months.
+func parseTenor(header string) (float64, error) {
+	amount, unit, ok := strings.Cut(strings.TrimSpace(header), " ")
+	n, err := strconv.ParseFloat(amount, 64)
+	if !ok || err != nil || n <= 0 {
+		return 0, fmt.Errorf("unrecognized tenor column %q", header)
+	}
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "mo", "mos", "month", "months":
+		return n, nil
+	case "yr", "yrs", "year", "years":
+		return n * 12, nil
+	}
+	return 0, fmt.Errorf("unrecognized tenor column %q", header)
+}
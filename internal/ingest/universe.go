@@ -0,0 +1,69 @@
+package ingest
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// universeColumns are the header names a universe CSV may carry its tickers under.
+var universeColumns = map[string][]string{
+	"symbol": {"symbol", "ticker", "act symbol"},
+}
+
+// ReadUniverse reads a symbol universe such as the S&P 500 constituents.
+// CSVs with a symbol or ticker column are read from that column; anything
+// else is taken as one symbol per line. Duplicates are dropped.
+func ReadUniverse(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read universe: %w", err)
+	}
+
+	col, ok := mapColumns(header, universeColumns)["symbol"]
+	var records [][]string
+	if !ok {
+		// No header: the first line is already a symbol.
+		col = 0
+		records = append(records, header)
+	}
+	rest, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read universe: %w", err)
+	}
+	records = append(records, rest...)
+
+	seen := map[string]struct{}{}
+	var symbols []string
+	for _, record := range records {
+		if col >= len(record) {
+			continue
+		}
+		symbol := normalizeTicker(record[col])
+		if symbol == "" || strings.HasPrefix(symbol, "#") {
+			continue
+		}
+		if _, dup := seen[symbol]; dup {
+			continue
+		}
+		seen[symbol] = struct{}{}
+		symbols = append(symbols, symbol)
+	}
+	return symbols, nil
+}
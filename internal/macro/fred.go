@@ -0,0 +1,129 @@
+package macro
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/marketcalendar"
+)
+
+// ParseFRED reads a FRED graph download: a date column headed DATE or
+// observation_date and one value column headed with the series ID, e.g.
+//
+//	observation_date,UNRATE
+//	2026-08-01,4.3
+//
+// FRED writes "." for dates without a value; those rows are skipped.
+func ParseFRED(r io.Reader) (string, []Observation, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return "", nil, fmt.Errorf("read header: %w", err)
+	}
+	if len(header) != 2 {
+		return "", nil, fmt.Errorf("expected a date and one series column, got %d columns", len(header))
+	}
+	dateCol := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(header[0]), "\ufeff"))
+	if dateCol != "date" && dateCol != "observation_date" {
+		return "", nil, fmt.Errorf("unexpected date column %q", header[0])
+	}
+	id := strings.ToUpper(strings.TrimSpace(header[1]))
+	if id == "" {
+		return "", nil, errors.New("no series id in header")
+	}
+
+	var observations []Observation
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("read observations: %w", err)
+		}
+
+		raw := strings.TrimSpace(record[1])
+		if raw == "" || raw == "." {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(record[0]))
+		if err != nil {
+			return "", nil, fmt.Errorf("line %d: invalid date %q", line, record[0])
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("line %d: invalid value %q", line, raw)
+		}
+		observations = append(observations, Observation{Date: date, Value: value})
+	}
+
+	return id, observations, nil
+}
+
+// ParseSchedule reads a release schedule with a date,time,release,series,period
+// header. Times are Eastern, as agencies publish them; series and period may
+// be blank.
+//
+//	date,time,release,series,period
+//	2026-11-06,08:30,Employment Situation,UNRATE,October 2026
+func ParseSchedule(r io.Reader) ([]Release, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	cols := map[string]int{}
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, key := range []string{"date", "time", "release"} {
+		if _, ok := cols[key]; !ok {
+			return nil, fmt.Errorf("no %s column", key)
+		}
+	}
+
+	var releases []Release
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read schedule: %w", err)
+		}
+
+		field := func(key string) string {
+			idx, ok := cols[key]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+		if field("release") == "" {
+			continue
+		}
+
+		at, err := time.ParseInLocation("2006-01-02 15:04", field("date")+" "+field("time"), marketcalendar.Location())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid release time %q %q", line, field("date"), field("time"))
+		}
+		releases = append(releases, Release{
+			Name:      field("release"),
+			ReleaseAt: at.UTC(),
+			SeriesID:  strings.ToUpper(field("series")),
+			Period:    field("period"),
+		})
+	}
+
+	return releases, nil
+}
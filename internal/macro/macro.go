@@ -0,0 +1,248 @@
+// Package macro stores economic time series imported from FRED-format CSV
+// files (CPI, unemployment, the fed funds rate, Treasury yields) and the
+// release schedule shown on the economic calendar.
+package macro
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/database"
+)
+
+// ErrUnknownSeries is returned for a series that is neither in Catalog nor stored.
+var ErrUnknownSeries = errors.New("unknown macro series")
+
+// Series describes one economic time series by its FRED ID.
+type Series struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Units     string `json:"units"`
+	Frequency string `json:"frequency"`
+	// YearOverYear marks index series, such as CPI, that are quoted as the
+	// percent change from a year earlier rather than the index level.
+	YearOverYear bool `json:"yearOverYear,omitempty"`
+}
+
+// Catalog is the series the app knows how to label. CSVs for other FRED
+// series are still imported, titled with their ID.
+var Catalog = []Series{
+	{ID: "CPIAUCSL", Title: "Consumer Price Index", Units: "Index 1982-1984=100", Frequency: "Monthly", YearOverYear: true},
+	{ID: "UNRATE", Title: "Unemployment Rate", Units: "Percent", Frequency: "Monthly"},
+	{ID: "FEDFUNDS", Title: "Effective Federal Funds Rate", Units: "Percent", Frequency: "Monthly"},
+	{ID: "DGS10", Title: "10-Year Treasury Yield", Units: "Percent", Frequency: "Daily"},
+	{ID: "DGS2", Title: "2-Year Treasury Yield", Units: "Percent", Frequency: "Daily"},
+}
+
+// FindSeries looks up a series in Catalog by ID.
+func FindSeries(id string) (Series, bool) {
+	for _, series := range Catalog {
+		if series.ID == id {
+			return series, true
+		}
+	}
+	return Series{}, false
+}
+
+// Observation is one dated value; Date is the start of the period it covers.
+type Observation struct {
+	Date  time.Time `json:"date"`
+	Value float64   `json:"value"`
+}
+
+// Reading is a series' latest observation. Change is the percent change
+// from a year earlier for YearOverYear series, when that observation exists.
+type Reading struct {
+	Observation
+	Change *float64 `json:"change,omitempty"`
+}
+
+// SeriesData is a stored series with its observations in a date range.
+type SeriesData struct {
+	Series       Series        `json:"series"`
+	Latest       *Reading      `json:"latest,omitempty"`
+	Observations []Observation `json:"observations"`
+}
+
+// Release is a scheduled data release on the economic calendar.
+type Release struct {
+	Name      string    `json:"name"`
+	ReleaseAt time.Time `json:"releaseAt"`
+	SeriesID  string    `json:"seriesId,omitempty"`
+	// Period is the month or meeting the release covers, e.g. "September 2026".
+	Period string `json:"period,omitempty"`
+	// Previous is the series' latest stored reading going into the release.
+	Previous *Reading `json:"previous,omitempty"`
+	Series   *Series  `json:"series,omitempty"`
+}
+
+// Service reads and writes the macro tables.
+type Service struct {
+	log     *slog.Logger
+	queries *database.Queries
+}
+
+func NewService(log *slog.Logger, queries *database.Queries) *Service {
+	return &Service{log: log, queries: queries}
+}
+
+// StoreSeries upserts a series and its observations.
+func (s *Service) StoreSeries(ctx context.Context, series Series, source string, observations []Observation) error {
+	err := s.queries.UpsertMacroSeries(ctx, database.UpsertMacroSeriesParams{
+		ID:        series.ID,
+		Title:     series.Title,
+		Units:     series.Units,
+		Frequency: series.Frequency,
+		Source:    source,
+		UpdatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("store series %s: %w", series.ID, err)
+	}
+
+	for _, obs := range observations {
+		err := s.queries.UpsertMacroObservation(ctx, database.UpsertMacroObservationParams{
+			SeriesID: series.ID,
+			ObsDate:  obs.Date.UTC(),
+			Value:    obs.Value,
+		})
+		if err != nil {
+			return fmt.Errorf("store %s %s: %w", series.ID, obs.Date.Format("2006-01-02"), err)
+		}
+	}
+
+	return nil
+}
+
+// StoreReleases upserts scheduled releases.
+func (s *Service) StoreReleases(ctx context.Context, source string, releases []Release) error {
+	for _, release := range releases {
+		err := s.queries.UpsertMacroRelease(ctx, database.UpsertMacroReleaseParams{
+			Name:      release.Name,
+			ReleaseAt: release.ReleaseAt.UTC(),
+			SeriesID:  release.SeriesID,
+			Period:    release.Period,
+			Source:    source,
+		})
+		if err != nil {
+			return fmt.Errorf("store release %s: %w", release.Name, err)
+		}
+	}
+	return nil
+}
+
+// Get returns a series with its observations in [from, to]. Catalog series
+// with nothing imported yet come back empty; other unstored IDs are
+// ErrUnknownSeries.
+func (s *Service) Get(ctx context.Context, id string, from, to time.Time) (*SeriesData, error) {
+	series, err := s.series(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListMacroObservations(ctx, database.ListMacroObservationsParams{
+		SeriesID: id,
+		FromTime: from.UTC(),
+		ToTime:   to.UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &SeriesData{Series: series, Observations: make([]Observation, 0, len(rows))}
+	for _, row := range rows {
+		out.Observations = append(out.Observations, Observation{Date: row.ObsDate.UTC(), Value: row.Value})
+	}
+
+	out.Latest, err = s.Latest(ctx, series)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Latest returns the newest stored reading of series, or nil when none is stored.
+func (s *Service) Latest(ctx context.Context, series Series) (*Reading, error) {
+	row, err := s.queries.GetLatestMacroObservation(ctx, series.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	reading := &Reading{Observation: Observation{Date: row.ObsDate.UTC(), Value: row.Value}}
+	if !series.YearOverYear {
+		return reading, nil
+	}
+
+	// Monthly and quarterly observations land on the same date a year apart.
+	yearAgo := reading.Date.AddDate(-1, 0, 0)
+	prior, err := s.queries.ListMacroObservations(ctx, database.ListMacroObservationsParams{
+		SeriesID: series.ID,
+		FromTime: yearAgo,
+		ToTime:   yearAgo,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(prior) > 0 && prior[0].Value != 0 {
+		change := (reading.Value/prior[0].Value - 1) * 100
+		reading.Change = &change
+	}
+	return reading, nil
+}
+
+// Upcoming lists up to limit releases at or after from, each with the
+// latest reading of the series it updates.
+func (s *Service) Upcoming(ctx context.Context, from time.Time, limit int) ([]Release, error) {
+	rows, err := s.queries.ListUpcomingMacroReleases(ctx, database.ListUpcomingMacroReleasesParams{
+		FromTime: from.UTC(),
+		Limit:    int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Release, 0, len(rows))
+	for _, row := range rows {
+		release := Release{
+			Name:      row.Name,
+			ReleaseAt: row.ReleaseAt.UTC(),
+			SeriesID:  row.SeriesID,
+			Period:    row.Period,
+		}
+		if row.SeriesID != "" {
+			series, err := s.series(ctx, row.SeriesID)
+			if err == nil {
+				release.Series = &series
+				release.Previous, err = s.Latest(ctx, series)
+			}
+			if err != nil && !errors.Is(err, ErrUnknownSeries) {
+				return nil, err
+			}
+		}
+		out = append(out, release)
+	}
+	return out, nil
+}
+
+// series describes id from the catalog, falling back to the stored row.
+func (s *Service) series(ctx context.Context, id string) (Series, error) {
+	if series, ok := FindSeries(id); ok {
+		return series, nil
+	}
+
+	row, err := s.queries.GetMacroSeries(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Series{}, fmt.Errorf("%w: %s", ErrUnknownSeries, id)
+	}
+	if err != nil {
+		return Series{}, err
+	}
+	return Series{ID: row.ID, Title: row.Title, Units: row.Units, Frequency: row.Frequency}, nil
+}
@@ -0,0 +1,153 @@
+// Package marketcalendar answers NYSE/Nasdaq trading-hours questions in
+// exchange time: holidays, 1:00 PM early closes and daylight saving time.
+package marketcalendar
+
+import (
+	"time"
+	_ "time/tzdata"
+)
+
+// Market status values shown on pages and returned by the API.
+const (
+	StatusOpen       = "open"
+	StatusPreMarket  = "pre-market"
+	StatusAfterHours = "after-hours"
+	StatusClosed     = "closed"
+)
+
+var location = mustLoadLocation("America/New_York")
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+// Location is the exchange time zone.
+func Location() *time.Location {
+	return location
+}
+
+// Session is one trading day's regular hours.
+type Session struct {
+	Date       time.Time
+	Open       time.Time
+	Close      time.Time
+	EarlyClose bool
+}
+
+// IsTradingDay reports whether the exchange holds a session on t's date in New York.
+func IsTradingDay(t time.Time) bool {
+	t = t.In(location)
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	_, closed := Holiday(t)
+	return !closed
+}
+
+// SessionOn returns the regular session for t's date, if there is one.
+func SessionOn(t time.Time) (Session, bool) {
+	if !IsTradingDay(t) {
+		return Session{}, false
+	}
+
+	t = t.In(location)
+	d := date(t.Year(), t.Month(), t.Day())
+	s := Session{
+		Date:  d,
+		Open:  clock(d, 9, 30),
+		Close: clock(d, 16, 0),
+	}
+	if earlyClose(d) {
+		s.Close = clock(d, 13, 0)
+		s.EarlyClose = true
+	}
+	return s, true
+}
+
+// IsOpen reports whether the regular session is in progress at t.
+func IsOpen(t time.Time) bool {
+	s, ok := SessionOn(t)
+	return ok && !t.Before(s.Open) && t.Before(s.Close)
+}
+
+// Status classifies t as open, pre-market (from 4:00 AM), after-hours
+// (until 8:00 PM, or 5:00 PM after an early close) or closed.
+func Status(t time.Time) string {
+	s, ok := SessionOn(t)
+	if !ok {
+		return StatusClosed
+	}
+
+	extendedEnd := clock(s.Date, 20, 0)
+	if s.EarlyClose {
+		extendedEnd = clock(s.Date, 17, 0)
+	}
+
+	switch {
+	case t.Before(clock(s.Date, 4, 0)):
+		return StatusClosed
+	case t.Before(s.Open):
+		return StatusPreMarket
+	case t.Before(s.Close):
+		return StatusOpen
+	case t.Before(extendedEnd):
+		return StatusAfterHours
+	}
+	return StatusClosed
+}
+
+// NextOpen returns the start of the first regular session opening after t.
+func NextOpen(t time.Time) time.Time {
+	d := t.In(location)
+	for {
+		if s, ok := SessionOn(d); ok && s.Open.After(t) {
+			return s.Open
+		}
+		d = nextDay(d)
+	}
+}
+
+// NextClose returns the end of the session in progress at t, or of the next one.
+func NextClose(t time.Time) time.Time {
+	d := t.In(location)
+	for {
+		if s, ok := SessionOn(d); ok && s.Close.After(t) {
+			return s.Close
+		}
+		d = nextDay(d)
+	}
+}
+
+// PreviousTradingDay returns midnight New York time of the last trading day
+// before t's date.
+func PreviousTradingDay(t time.Time) time.Time {
+	t = t.In(location)
+	d := date(t.Year(), t.Month(), t.Day())
+	for {
+		d = d.AddDate(0, 0, -1)
+		if IsTradingDay(d) {
+			return d
+		}
+	}
+}
+
+// LastCompletedSession returns midnight New York time of the most recent
+// trading day whose regular session had closed by t.
+func LastCompletedSession(t time.Time) time.Time {
+	if s, ok := SessionOn(t); ok && !t.Before(s.Close) {
+		return s.Date
+	}
+	return PreviousTradingDay(t)
+}
+
+func clock(d time.Time, hour, minute int) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), hour, minute, 0, 0, location)
+}
+
+func nextDay(t time.Time) time.Time {
+	return date(t.Year(), t.Month(), t.Day()+1)
+}
@@ -0,0 +1,138 @@
+package marketcalendar
+
+import (
+	"testing"
+	"time"
+)
+
+func utc(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// The 9:30 open is 14:30 UTC in winter and 13:30 UTC in summer; each row
+// sits on either side of a daylight saving changeover.
+func TestSessionAcrossDST(t *testing.T) {
+	tests := []struct {
+		date      string
+		wantOpen  string
+		wantClose string
+	}{
+		{"2025-03-07", "2025-03-07 14:30", "2025-03-07 21:00"},
+		{"2025-03-10", "2025-03-10 13:30", "2025-03-10 20:00"},
+		{"2025-10-31", "2025-10-31 13:30", "2025-10-31 20:00"},
+		{"2025-11-03", "2025-11-03 14:30", "2025-11-03 21:00"},
+		{"2026-03-06", "2026-03-06 14:30", "2026-03-06 21:00"},
+		{"2026-03-09", "2026-03-09 13:30", "2026-03-09 20:00"},
+		{"2026-10-30", "2026-10-30 13:30", "2026-10-30 20:00"},
+		{"2026-11-02", "2026-11-02 14:30", "2026-11-02 21:00"},
+		{"2027-03-12", "2027-03-12 14:30", "2027-03-12 21:00"},
+		{"2027-03-15", "2027-03-15 13:30", "2027-03-15 20:00"},
+		{"2027-11-05", "2027-11-05 13:30", "2027-11-05 20:00"},
+		{"2027-11-08", "2027-11-08 14:30", "2027-11-08 21:00"},
+		// Early closes are 1:00 PM New York time too.
+		{"2025-11-28", "2025-11-28 14:30", "2025-11-28 18:00"},
+		{"2026-07-02", "2026-07-02 13:30", "2026-07-02 20:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			s, ok := SessionOn(utc(tt.date + " 16:00"))
+			if !ok {
+				t.Fatalf("no session on %s", tt.date)
+			}
+			if !s.Open.Equal(utc(tt.wantOpen)) || !s.Close.Equal(utc(tt.wantClose)) {
+				t.Errorf("session %s = %s to %s UTC, want %s to %s", tt.date,
+					s.Open.UTC().Format("15:04"), s.Close.UTC().Format("15:04"), tt.wantOpen[11:], tt.wantClose[11:])
+			}
+		})
+	}
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		at   string
+		want string
+	}{
+		// 13:45 UTC is 8:45 before the spring changeover and 9:45 after it.
+		{"2026-03-06 13:45", StatusPreMarket},
+		{"2026-03-09 13:45", StatusOpen},
+		// 20:30 UTC is 3:30 PM in winter and 4:30 PM in summer.
+		{"2026-11-02 20:30", StatusOpen},
+		{"2026-10-30 20:30", StatusAfterHours},
+		// The changeover Sundays themselves are closed.
+		{"2026-03-08 15:00", StatusClosed},
+		{"2026-11-01 15:00", StatusClosed},
+		{"2026-03-09 07:59", StatusClosed},
+		{"2026-03-09 08:00", StatusPreMarket},
+		{"2026-03-09 23:59", StatusAfterHours},
+		{"2026-03-10 00:00", StatusClosed},
+		// After an early close the extended session ends at 5:00 PM.
+		{"2026-11-27 18:30", StatusAfterHours},
+		{"2026-11-27 22:00", StatusClosed},
+		{"2026-11-26 15:00", StatusClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.at, func(t *testing.T) {
+			if got := Status(utc(tt.at)); got != tt.want {
+				t.Errorf("Status(%s UTC) = %s, want %s", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextOpenAndClose(t *testing.T) {
+	tests := []struct {
+		name      string
+		at        string
+		wantOpen  string
+		wantClose string
+	}{
+		{"during the session", "2026-03-10 15:00", "2026-03-11 13:30", "2026-03-10 20:00"},
+		{"over the spring changeover", "2026-03-06 22:00", "2026-03-09 13:30", "2026-03-09 20:00"},
+		{"over a holiday weekend", "2026-07-02 21:00", "2026-07-06 13:30", "2026-07-06 20:00"},
+		{"over thanksgiving", "2026-11-25 22:00", "2026-11-27 14:30", "2026-11-27 18:00"},
+		{"into the new year", "2027-12-31 22:00", "2028-01-03 14:30", "2028-01-03 21:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at := utc(tt.at)
+			if got := NextOpen(at); !got.Equal(utc(tt.wantOpen)) {
+				t.Errorf("NextOpen(%s) = %s, want %s", tt.at, got.UTC().Format("2006-01-02 15:04"), tt.wantOpen)
+			}
+			if got := NextClose(at); !got.Equal(utc(tt.wantClose)) {
+				t.Errorf("NextClose(%s) = %s, want %s", tt.at, got.UTC().Format("2006-01-02 15:04"), tt.wantClose)
+			}
+		})
+	}
+}
+
+func TestPreviousAndLastCompleted(t *testing.T) {
+	tests := []struct {
+		at            string
+		wantPrevious  string
+		wantCompleted string
+	}{
+		// Before Tuesday's close the last completed session is Monday's.
+		{"2026-03-10 15:00", "2026-03-09", "2026-03-09"},
+		{"2026-03-10 20:00", "2026-03-09", "2026-03-10"},
+		// Monday after the observed July 3 holiday.
+		{"2026-07-06 12:00", "2026-07-02", "2026-07-02"},
+		// 1:00 AM UTC Tuesday is still Monday evening in New York.
+		{"2026-03-10 01:00", "2026-03-06", "2026-03-09"},
+		{"2026-01-02 15:00", "2025-12-31", "2025-12-31"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.at, func(t *testing.T) {
+			at := utc(tt.at)
+			if got := PreviousTradingDay(at).Format("2006-01-02"); got != tt.wantPrevious {
+				t.Errorf("PreviousTradingDay(%s) = %s, want %s", tt.at, got, tt.wantPrevious)
+			}
+			if got := LastCompletedSession(at).Format("2006-01-02"); got != tt.wantCompleted {
+				t.Errorf("LastCompletedSession(%s) = %s, want %s", tt.at, got, tt.wantCompleted)
+			}
+		})
+	}
+}
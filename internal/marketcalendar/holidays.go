@@ -0,0 +1,117 @@
+package marketcalendar
+
+import "time"
+
+// specialClosures are unscheduled full-day closures that no rule can derive.
+var specialClosures = map[string]string{
+	"2012-10-29": "Hurricane Sandy",
+	"2012-10-30": "Hurricane Sandy",
+	"2018-12-05": "National Day of Mourning for George H.W. Bush",
+	"2025-01-09": "National Day of Mourning for Jimmy Carter",
+}
+
+// Holiday reports whether the exchange is closed all day on t's calendar date
+// in New York, and the holiday's name.
+func Holiday(t time.Time) (string, bool) {
+	t = t.In(location)
+	year, month, day := t.Date()
+
+	if name, ok := specialClosures[t.Format("2006-01-02")]; ok {
+		return name, true
+	}
+
+	date := time.Date(year, month, day, 0, 0, 0, 0, location)
+	for _, h := range holidaysFor(year) {
+		if h.date.Equal(date) {
+			return h.name, true
+		}
+	}
+	return "", false
+}
+
+type holiday struct {
+	name string
+	date time.Time
+}
+
+// holidaysFor lists the observed NYSE/Nasdaq full-day holidays for a year.
+func holidaysFor(year int) []holiday {
+	list := []holiday{
+		{"Martin Luther King Jr. Day", nthWeekday(year, time.January, time.Monday, 3)},
+		{"Washington's Birthday", nthWeekday(year, time.February, time.Monday, 3)},
+		{"Good Friday", easter(year).AddDate(0, 0, -2)},
+		{"Memorial Day", lastWeekday(year, time.May, time.Monday)},
+		{"Independence Day", observed(date(year, time.July, 4))},
+		{"Labor Day", nthWeekday(year, time.September, time.Monday, 1)},
+		{"Thanksgiving Day", nthWeekday(year, time.November, time.Thursday, 4)},
+		{"Christmas Day", observed(date(year, time.December, 25))},
+	}
+
+	// A Saturday New Year's Day is not moved back into the prior year.
+	if newYear := date(year, time.January, 1); newYear.Weekday() != time.Saturday {
+		list = append(list, holiday{"New Year's Day", observed(newYear)})
+	}
+	if year >= 2022 {
+		list = append(list, holiday{"Juneteenth", observed(date(year, time.June, 19))})
+	}
+
+	return list
+}
+
+// earlyClose reports whether the session on date closes at 1:00 PM.
+func earlyClose(d time.Time) bool {
+	month, day, weekday := d.Month(), d.Day(), d.Weekday()
+	switch {
+	case month == time.July && day == 3:
+		// Only when Independence Day itself falls on a weekday after it.
+		return weekday >= time.Monday && weekday <= time.Thursday
+	case month == time.November && weekday == time.Friday:
+		return d.AddDate(0, 0, -1).Equal(nthWeekday(d.Year(), time.November, time.Thursday, 4))
+	case month == time.December && day == 24:
+		return weekday >= time.Monday && weekday <= time.Thursday
+	}
+	return false
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, location)
+}
+
+// observed shifts a fixed-date holiday off the weekend.
+func observed(d time.Time) time.Time {
+	switch d.Weekday() {
+	case time.Saturday:
+		return d.AddDate(0, 0, -1)
+	case time.Sunday:
+		return d.AddDate(0, 0, 1)
+	}
+	return d
+}
+
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	d := date(year, month, 1)
+	offset := (int(weekday) - int(d.Weekday()) + 7) % 7
+	return d.AddDate(0, 0, offset+7*(n-1))
+}
+
+func lastWeekday(year int, month time.Month, weekday time.Weekday) time.Time {
+	d := date(year, month+1, 1).AddDate(0, 0, -1)
+	offset := (int(d.Weekday()) - int(weekday) + 7) % 7
+	return d.AddDate(0, 0, -offset)
+}
+
+// easter returns Western Easter Sunday (anonymous Gregorian algorithm).
+func easter(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return date(year, time.Month(month), day)
+}
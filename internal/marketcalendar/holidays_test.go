@@ -0,0 +1,155 @@
+package marketcalendar
+
+import (
+	"testing"
+	"time"
+)
+
+// nyseHolidays are the full-day closures NYSE published for 2025 through
+// 2027, observed dates included.
+var nyseHolidays = []struct {
+	date string
+	name string
+}{
+	{"2025-01-01", "New Year's Day"},
+	{"2025-01-09", "National Day of Mourning for Jimmy Carter"},
+	{"2025-01-20", "Martin Luther King Jr. Day"},
+	{"2025-02-17", "Washington's Birthday"},
+	{"2025-04-18", "Good Friday"},
+	{"2025-05-26", "Memorial Day"},
+	{"2025-06-19", "Juneteenth"},
+	{"2025-07-04", "Independence Day"},
+	{"2025-09-01", "Labor Day"},
+	{"2025-11-27", "Thanksgiving Day"},
+	{"2025-12-25", "Christmas Day"},
+
+	{"2026-01-01", "New Year's Day"},
+	{"2026-01-19", "Martin Luther King Jr. Day"},
+	{"2026-02-16", "Washington's Birthday"},
+	{"2026-04-03", "Good Friday"},
+	{"2026-05-25", "Memorial Day"},
+	{"2026-06-19", "Juneteenth"},
+	// July 4 is a Saturday, so the Friday before is observed.
+	{"2026-07-03", "Independence Day"},
+	{"2026-09-07", "Labor Day"},
+	{"2026-11-26", "Thanksgiving Day"},
+	{"2026-12-25", "Christmas Day"},
+
+	{"2027-01-01", "New Year's Day"},
+	{"2027-01-18", "Martin Luther King Jr. Day"},
+	{"2027-02-15", "Washington's Birthday"},
+	{"2027-03-26", "Good Friday"},
+	{"2027-05-31", "Memorial Day"},
+	// June 19 is a Saturday and July 4 a Sunday.
+	{"2027-06-18", "Juneteenth"},
+	{"2027-07-05", "Independence Day"},
+	{"2027-09-06", "Labor Day"},
+	{"2027-11-25", "Thanksgiving Day"},
+	// December 25 is a Saturday.
+	{"2027-12-24", "Christmas Day"},
+}
+
+func day(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.ParseInLocation("2006-01-02", s, Location())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestHolidays(t *testing.T) {
+	want := map[string]string{}
+	for _, h := range nyseHolidays {
+		want[h.date] = h.name
+	}
+
+	// Every weekday of the three years is a holiday exactly when listed.
+	for d := day(t, "2025-01-01"); d.Year() <= 2027; d = d.AddDate(0, 0, 1) {
+		if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+			continue
+		}
+		key := d.Format("2006-01-02")
+		name, closed := Holiday(d)
+		if wantName, ok := want[key]; closed != ok || name != wantName {
+			t.Errorf("Holiday(%s) = %q, %v; want %q, %v", key, name, closed, wantName, ok)
+		}
+	}
+}
+
+func TestObservedShifts(t *testing.T) {
+	tests := []struct {
+		date string
+		open bool
+	}{
+		// The Saturday holiday itself and the observed Friday are closed;
+		// the following Monday trades.
+		{"2026-07-03", false},
+		{"2026-07-06", true},
+		// A Sunday holiday moves to Monday; the Friday before trades.
+		{"2027-07-02", true},
+		{"2027-07-05", false},
+		// New Year's Day 2028 is a Saturday and is not observed on the
+		// last trading day of 2027.
+		{"2027-12-31", true},
+		{"2021-12-31", true},
+		// A Saturday Christmas closes the Friday before.
+		{"2027-12-24", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			if got := IsTradingDay(day(t, tt.date)); got != tt.open {
+				t.Errorf("IsTradingDay(%s) = %v, want %v", tt.date, got, tt.open)
+			}
+		})
+	}
+}
+
+func TestEarlyCloses(t *testing.T) {
+	tests := []struct {
+		date  string
+		early bool
+	}{
+		{"2025-07-03", true},
+		{"2025-11-28", true},
+		{"2025-12-24", true},
+		// July 3 2026 is the observed holiday, so July 2 is a full day.
+		{"2026-07-02", false},
+		{"2026-11-27", true},
+		{"2026-12-24", true},
+		{"2027-11-26", true},
+		// Christmas Eve 2027 is the observed holiday; the day before is full.
+		{"2027-12-23", false},
+		{"2025-12-26", false},
+		{"2026-12-31", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			s, ok := SessionOn(day(t, tt.date))
+			if !ok {
+				t.Fatalf("no session on %s", tt.date)
+			}
+			wantClose := 16
+			if tt.early {
+				wantClose = 13
+			}
+			if s.EarlyClose != tt.early || s.Close.Hour() != wantClose || s.Close.Minute() != 0 {
+				t.Errorf("session %s closes at %s (early %v), want %d:00 (early %v)",
+					tt.date, s.Close.Format("15:04"), s.EarlyClose, wantClose, tt.early)
+			}
+		})
+	}
+}
+
+func TestEaster(t *testing.T) {
+	for year, want := range map[int]string{
+		2025: "2025-04-20",
+		2026: "2026-04-05",
+		2027: "2027-03-28",
+		2038: "2038-04-25",
+	} {
+		if got := easter(year).Format("2006-01-02"); got != want {
+			t.Errorf("easter(%d) = %s, want %s", year, got, want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package options
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrNoImpliedVol is returned when a price is outside what the model can
+// produce at any volatility, such as below intrinsic value.
+var ErrNoImpliedVol = errors.New("price has no implied volatility")
+
+// Inputs are the Black-Scholes model inputs. Rate, Yield and Vol are
+// annualized fractions (0.045 = 4.5%); Years is the time to expiration.
+type Inputs struct {
+	Spot   float64 `json:"spot"`
+	Strike float64 `json:"strike"`
+	Years  float64 `json:"years"`
+	Rate   float64 `json:"rate"`
+	// Yield is the continuous dividend yield, zero for most tools' purposes.
+	Yield float64 `json:"yield,omitempty"`
+	Vol   float64 `json:"vol"`
+}
+
+// Greeks are a contract's price sensitivities, per share. Theta is per
+// calendar day, and Vega and Rho per one percentage point of volatility or
+// rate, the way quote screens show them.
+type Greeks struct {
+	Delta float64 `json:"delta"`
+	Gamma float64 `json:"gamma"`
+	Theta float64 `json:"theta"`
+	Vega  float64 `json:"vega"`
+	Rho   float64 `json:"rho"`
+}
+
+// expired reports whether the model collapses to intrinsic value.
+func (in Inputs) expired() bool {
+	return in.Years <= 0 || in.Vol <= 0
+}
+
+func (in Inputs) d1d2() (float64, float64) {
+	sqrtT := math.Sqrt(in.Years)
+	d1 := (math.Log(in.Spot/in.Strike) + (in.Rate-in.Yield+in.Vol*in.Vol/2)*in.Years) / (in.Vol * sqrtT)
+	return d1, d1 - in.Vol*sqrtT
+}
+
+// discounts are the present value factors for the dividend yield and rate.
+func (in Inputs) discounts() (float64, float64) {
+	t := max(in.Years, 0)
+	return math.Exp(-in.Yield * t), math.Exp(-in.Rate * t)
+}
+
+// Price is the Black-Scholes-Merton value of a European call or put. At
+// expiration, or with zero volatility, it is the discounted intrinsic value.
+func Price(kind Kind, in Inputs) float64 {
+	dq, dr := in.discounts()
+	if in.expired() {
+		if kind == Put {
+			return max(in.Strike*dr-in.Spot*dq, 0)
+		}
+		return max(in.Spot*dq-in.Strike*dr, 0)
+	}
+
+	d1, d2 := in.d1d2()
+	if kind == Put {
+		return in.Strike*dr*normCDF(-d2) - in.Spot*dq*normCDF(-d1)
+	}
+	return in.Spot*dq*normCDF(d1) - in.Strike*dr*normCDF(d2)
+}
+
+// ComputeGreeks returns the contract's sensitivities. An expired contract
+// has a delta of 1 (or -1) in the money and zero everywhere else.
+func ComputeGreeks(kind Kind, in Inputs) Greeks {
+	if in.expired() {
+		var g Greeks
+		switch {
+		case kind == Call && in.Spot > in.Strike:
+			g.Delta = 1
+		case kind == Put && in.Spot < in.Strike:
+			g.Delta = -1
+		}
+		return g
+	}
+
+	dq, dr := in.discounts()
+	d1, d2 := in.d1d2()
+	sqrtT := math.Sqrt(in.Years)
+	pdf := normPDF(d1)
+
+	g := Greeks{
+		Gamma: dq * pdf / (in.Spot * in.Vol * sqrtT),
+		Vega:  in.Spot * dq * pdf * sqrtT / 100,
+	}
+	decay := -in.Spot * dq * pdf * in.Vol / (2 * sqrtT)
+	if kind == Put {
+		g.Delta = dq * (normCDF(d1) - 1)
+		g.Theta = (decay + in.Rate*in.Strike*dr*normCDF(-d2) - in.Yield*in.Spot*dq*normCDF(-d1)) / 365
+		g.Rho = -in.Strike * in.Years * dr * normCDF(-d2) / 100
+	} else {
+		g.Delta = dq * normCDF(d1)
+		g.Theta = (decay - in.Rate*in.Strike*dr*normCDF(d2) + in.Yield*in.Spot*dq*normCDF(d1)) / 365
+		g.Rho = in.Strike * in.Years * dr * normCDF(d2) / 100
+	}
+	return g
+}
+
+// Volatility search bounds and tolerance for ImpliedVol.
+const (
+	minVol       = 1e-4
+	maxVol       = 5.0
+	volTolerance = 1e-8
+)
+
+// ImpliedVol finds the volatility at which Price matches price, ignoring
+// in.Vol. It takes Newton steps from a 30% guess and bisects whenever a
+// step would leave the bracket, which keeps deep in- and out-of-the-money
+// contracts, where vega is nearly zero, from diverging.
+func ImpliedVol(kind Kind, in Inputs, price float64) (float64, error) {
+	if in.Years <= 0 || in.Spot <= 0 || in.Strike <= 0 || price <= 0 {
+		return 0, ErrNoImpliedVol
+	}
+	in.Vol = minVol
+	floor := Price(kind, in)
+	in.Vol = maxVol
+	ceiling := Price(kind, in)
+	if price < floor || price > ceiling {
+		return 0, ErrNoImpliedVol
+	}
+
+	lo, hi := minVol, maxVol
+	vol := 0.3
+	for range 100 {
+		in.Vol = vol
+		diff := Price(kind, in) - price
+		if math.Abs(diff) < volTolerance {
+			return vol, nil
+		}
+		if diff > 0 {
+			hi = vol
+		} else {
+			lo = vol
+		}
+
+		vega := ComputeGreeks(kind, in).Vega * 100
+		next := vol - diff/vega
+		if vega <= 0 || math.IsNaN(next) || next <= lo || next >= hi {
+			next = (lo + hi) / 2
+		}
+		if hi-lo < volTolerance {
+			return next, nil
+		}
+		vol = next
+	}
+	return vol, nil
+}
+
+func normCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+func normPDF(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
@@ -0,0 +1,287 @@
+// Package options stores listed option chains imported from CSV and prices
+// contracts and strategies with the Black-Scholes model: fair values,
+// implied volatility, greeks and payoffs at expiration.
+package options
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/database"
+	"github.com/loganlanou/Financing-101/internal/marketcalendar"
+)
+
+// ErrNoChain is returned when no contracts are stored for a symbol, or for
+// the requested expiration.
+var ErrNoChain = errors.New("no option chain stored")
+
+// Kind is a contract or strategy leg type.
+type Kind string
+
+const (
+	Call Kind = "call"
+	Put  Kind = "put"
+	// Stock is 1 share of the underlying, used only in strategy legs.
+	Stock Kind = "stock"
+)
+
+// DefaultRate is the risk-free rate used when no Treasury bill yield is stored.
+const DefaultRate = 0.04
+
+// Contract is one listed option's latest quote. Prices are per share.
+type Contract struct {
+	Symbol       string    `json:"symbol"`
+	Expiration   time.Time `json:"expiration"`
+	Kind         Kind      `json:"kind"`
+	Strike       float64   `json:"strike"`
+	Bid          *float64  `json:"bid,omitempty"`
+	Ask          *float64  `json:"ask,omitempty"`
+	Last         *float64  `json:"last,omitempty"`
+	Volume       int64     `json:"volume"`
+	OpenInterest int64     `json:"openInterest"`
+	// VendorIV is the implied volatility the data source quoted, if any.
+	VendorIV *float64  `json:"vendorIv,omitempty"`
+	AsOf     time.Time `json:"asOf"`
+}
+
+// Mid is the bid/ask midpoint, or the last trade when the market is one-sided.
+func (c Contract) Mid() (float64, bool) {
+	if c.Bid != nil && c.Ask != nil && *c.Bid > 0 && *c.Ask >= *c.Bid {
+		return (*c.Bid + *c.Ask) / 2, true
+	}
+	if c.Last != nil && *c.Last > 0 {
+		return *c.Last, true
+	}
+	return 0, false
+}
+
+// Quote is a contract with analytics solved against the underlying price.
+type Quote struct {
+	Contract
+	// IV is solved from Mid; nil when the contract has no usable price.
+	IV     *float64 `json:"iv,omitempty"`
+	Greeks *Greeks  `json:"greeks,omitempty"`
+}
+
+// StrikeRow pairs a strike's call and put, either of which may be unlisted.
+type StrikeRow struct {
+	Strike float64 `json:"strike"`
+	Call   *Quote  `json:"call,omitempty"`
+	Put    *Quote  `json:"put,omitempty"`
+}
+
+// Chain is one expiration's contracts, lowest strike first, priced against
+// Underlying at Rate.
+type Chain struct {
+	Symbol      string      `json:"symbol"`
+	Expiration  time.Time   `json:"expiration"`
+	Expirations []time.Time `json:"expirations"`
+	Underlying  float64     `json:"underlying"`
+	Rate        float64     `json:"rate"`
+	Years       float64     `json:"years"`
+	Rows        []StrikeRow `json:"rows"`
+}
+
+// ATMStrike is the listed strike nearest the underlying price.
+func (c *Chain) ATMStrike() float64 {
+	best := 0.0
+	for _, row := range c.Rows {
+		if best == 0 || math.Abs(row.Strike-c.Underlying) < math.Abs(best-c.Underlying) {
+			best = row.Strike
+		}
+	}
+	return best
+}
+
+// ATMVol averages the implied volatility of the call and put at ATMStrike.
+func (c *Chain) ATMVol() (float64, bool) {
+	strike := c.ATMStrike()
+	var sum float64
+	var n int
+	for _, row := range c.Rows {
+		if row.Strike != strike {
+			continue
+		}
+		for _, q := range []*Quote{row.Call, row.Put} {
+			if q != nil && q.IV != nil {
+				sum += *q.IV
+				n++
+			}
+		}
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return sum / float64(n), true
+}
+
+// YearsUntil is the time from now to a contract's 4:00 pm ET expiration,
+// in years, zero once it has passed.
+func YearsUntil(expiration, now time.Time) float64 {
+	closeAt := time.Date(expiration.Year(), expiration.Month(), expiration.Day(), 16, 0, 0, 0, marketcalendar.Location())
+	return max(closeAt.Sub(now).Hours()/(365*24), 0)
+}
+
+// Service stores option chains and prices them.
+type Service struct {
+	log     *slog.Logger
+	queries *database.Queries
+}
+
+func NewService(log *slog.Logger, queries *database.Queries) *Service {
+	return &Service{log: log, queries: queries}
+}
+
+// StoreChain replaces each symbol and expiration in contracts with the new
+// quotes, so strikes no longer listed drop out.
+func (s *Service) StoreChain(ctx context.Context, source string, contracts []Contract) error {
+	type key struct {
+		symbol     string
+		expiration time.Time
+	}
+	cleared := map[key]bool{}
+
+	for _, c := range contracts {
+		k := key{c.Symbol, c.Expiration.UTC()}
+		if !cleared[k] {
+			err := s.queries.DeleteOptionChain(ctx, database.DeleteOptionChainParams{Symbol: k.symbol, Expiration: k.expiration})
+			if err != nil {
+				return fmt.Errorf("clear %s %s chain: %w", c.Symbol, c.Expiration.Format("2006-01-02"), err)
+			}
+			cleared[k] = true
+		}
+
+		err := s.queries.UpsertOptionContract(ctx, database.UpsertOptionContractParams{
+			Symbol:       c.Symbol,
+			Expiration:   k.expiration,
+			Kind:         string(c.Kind),
+			Strike:       c.Strike,
+			Bid:          sqlFloat(c.Bid),
+			Ask:          sqlFloat(c.Ask),
+			Last:         sqlFloat(c.Last),
+			Volume:       c.Volume,
+			OpenInterest: c.OpenInterest,
+			ImpliedVol:   sqlFloat(c.VendorIV),
+			AsOf:         c.AsOf.UTC(),
+			Source:       source,
+		})
+		if err != nil {
+			return fmt.Errorf("store %s %s %g %s: %w", c.Symbol, c.Expiration.Format("2006-01-02"), c.Strike, c.Kind, err)
+		}
+	}
+	return nil
+}
+
+// Expirations lists a symbol's stored expirations on or after from.
+func (s *Service) Expirations(ctx context.Context, symbol string, from time.Time) ([]time.Time, error) {
+	rows, err := s.queries.ListOptionExpirations(ctx, database.ListOptionExpirationsParams{
+		Symbol:   symbol,
+		FromTime: from.UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]time.Time, 0, len(rows))
+	for _, t := range rows {
+		out = append(out, t.UTC())
+	}
+	return out, nil
+}
+
+// Chain loads one expiration, the nearest upcoming one when expiration is
+// zero, and solves each contract's implied volatility and greeks against
+// spot and rate.
+func (s *Service) Chain(ctx context.Context, symbol string, expiration time.Time, spot, rate float64) (*Chain, error) {
+	now := time.Now()
+	local := now.In(marketcalendar.Location())
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	expirations, err := s.Expirations(ctx, symbol, today)
+	if err != nil {
+		return nil, err
+	}
+	if len(expirations) == 0 {
+		return nil, ErrNoChain
+	}
+	if expiration.IsZero() {
+		expiration = expirations[0]
+	}
+	if !slices.ContainsFunc(expirations, expiration.Equal) {
+		return nil, ErrNoChain
+	}
+
+	rows, err := s.queries.ListOptionChain(ctx, database.ListOptionChainParams{
+		Symbol:     symbol,
+		Expiration: expiration.UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	chain := &Chain{
+		Symbol:      symbol,
+		Expiration:  expiration,
+		Expirations: expirations,
+		Underlying:  spot,
+		Rate:        rate,
+		Years:       YearsUntil(expiration, now),
+	}
+	in := Inputs{Spot: spot, Years: chain.Years, Rate: rate}
+	for _, row := range rows {
+		quote := &Quote{Contract: contractFromRow(row)}
+		if price, ok := quote.Mid(); ok && spot > 0 {
+			in.Strike = quote.Strike
+			if vol, err := ImpliedVol(quote.Kind, in, price); err == nil {
+				in.Vol = vol
+				greeks := ComputeGreeks(quote.Kind, in)
+				quote.IV, quote.Greeks = &vol, &greeks
+			}
+		}
+
+		if n := len(chain.Rows); n == 0 || chain.Rows[n-1].Strike != quote.Strike {
+			chain.Rows = append(chain.Rows, StrikeRow{Strike: quote.Strike})
+		}
+		last := &chain.Rows[len(chain.Rows)-1]
+		if quote.Kind == Put {
+			last.Put = quote
+		} else {
+			last.Call = quote
+		}
+	}
+	return chain, nil
+}
+
+func contractFromRow(row database.OptionContract) Contract {
+	return Contract{
+		Symbol:       row.Symbol,
+		Expiration:   row.Expiration.UTC(),
+		Kind:         Kind(row.Kind),
+		Strike:       row.Strike,
+		Bid:          nullFloat(row.Bid),
+		Ask:          nullFloat(row.Ask),
+		Last:         nullFloat(row.Last),
+		Volume:       row.Volume,
+		OpenInterest: row.OpenInterest,
+		VendorIV:     nullFloat(row.ImpliedVol),
+		AsOf:         row.AsOf.UTC(),
+	}
+}
+
+func sqlFloat(v *float64) sql.NullFloat64 {
+	if v == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *v, Valid: true}
+}
+
+func nullFloat(v sql.NullFloat64) *float64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Float64
+}
@@ -0,0 +1,262 @@
+package options
+
+import (
+	"math"
+	"slices"
+)
+
+// Leg is one position in a strategy, per share. Quantity is positive for
+// long positions and negative for short ones; a covered call is one long
+// Stock leg and one short Call.
+type Leg struct {
+	Kind   Kind    `json:"kind"`
+	Strike float64 `json:"strike,omitempty"`
+	// Premium is the price paid or received per share; for a stock leg, the
+	// purchase price.
+	Premium  float64 `json:"premium"`
+	Quantity int     `json:"quantity"`
+}
+
+// Expiry is the leg's profit or loss at expiration with the stock at spot.
+func (l Leg) Expiry(spot float64) float64 {
+	var value float64
+	switch l.Kind {
+	case Call:
+		value = max(spot-l.Strike, 0)
+	case Put:
+		value = max(l.Strike-spot, 0)
+	case Stock:
+		value = spot
+	}
+	return float64(l.Quantity) * (value - l.Premium)
+}
+
+// Value is the leg's profit or loss before expiration, marking options at
+// their Black-Scholes price; in supplies everything but the spot and strike.
+func (l Leg) Value(spot float64, in Inputs) float64 {
+	if l.Kind == Stock {
+		return l.Expiry(spot)
+	}
+	in.Spot, in.Strike = spot, l.Strike
+	return float64(l.Quantity) * (Price(l.Kind, in) - l.Premium)
+}
+
+// Greeks are the leg's sensitivities scaled by its quantity; a share of
+// stock has a delta of one.
+func (l Leg) Greeks(in Inputs) Greeks {
+	if l.Kind == Stock {
+		return Greeks{Delta: float64(l.Quantity)}
+	}
+	in.Strike = l.Strike
+	g := ComputeGreeks(l.Kind, in)
+	q := float64(l.Quantity)
+	return Greeks{Delta: g.Delta * q, Gamma: g.Gamma * q, Theta: g.Theta * q, Vega: g.Vega * q, Rho: g.Rho * q}
+}
+
+// Strategy is a set of legs held together.
+type Strategy struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Legs []Leg  `json:"legs"`
+}
+
+// Expiry is the strategy's profit or loss per share at expiration.
+func (s Strategy) Expiry(spot float64) float64 {
+	var total float64
+	for _, leg := range s.Legs {
+		total += leg.Expiry(spot)
+	}
+	return total
+}
+
+// Value is the strategy's profit or loss per share before expiration.
+func (s Strategy) Value(spot float64, in Inputs) float64 {
+	var total float64
+	for _, leg := range s.Legs {
+		total += leg.Value(spot, in)
+	}
+	return total
+}
+
+// Greeks are the position's combined sensitivities at in.Spot.
+func (s Strategy) Greeks(in Inputs) Greeks {
+	var total Greeks
+	for _, leg := range s.Legs {
+		g := leg.Greeks(in)
+		total.Delta += g.Delta
+		total.Gamma += g.Gamma
+		total.Theta += g.Theta
+		total.Vega += g.Vega
+		total.Rho += g.Rho
+	}
+	return total
+}
+
+// Cost is the net premium paid per share, negative for a net credit.
+func (s Strategy) Cost() float64 {
+	var total float64
+	for _, leg := range s.Legs {
+		total += float64(leg.Quantity) * leg.Premium
+	}
+	return total
+}
+
+// kinks are the prices where the expiration payoff changes slope: zero and
+// each option strike, in order.
+func (s Strategy) kinks() []float64 {
+	out := []float64{0}
+	for _, leg := range s.Legs {
+		if leg.Kind != Stock && !slices.Contains(out, leg.Strike) {
+			out = append(out, leg.Strike)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// upsideSlope is how much the expiration payoff gains per $1 above the
+// highest strike.
+func (s Strategy) upsideSlope() float64 {
+	var slope float64
+	for _, leg := range s.Legs {
+		if leg.Kind == Call || leg.Kind == Stock {
+			slope += float64(leg.Quantity)
+		}
+	}
+	return slope
+}
+
+// Breakevens are the stock prices at which the position neither makes nor
+// loses money at expiration. The payoff is linear between strikes, so each
+// crossing is found exactly.
+func (s Strategy) Breakevens() []float64 {
+	kinks := s.kinks()
+	var out []float64
+	for i := 0; i < len(kinks)-1; i++ {
+		a, b := kinks[i], kinks[i+1]
+		fa, fb := s.Expiry(a), s.Expiry(b)
+		switch {
+		case fa == 0 && i == 0:
+			out = append(out, a)
+		case fb == 0:
+			out = append(out, b)
+		case (fa < 0) != (fb < 0):
+			out = append(out, a+(b-a)*fa/(fa-fb))
+		}
+	}
+
+	last := kinks[len(kinks)-1]
+	f, slope := s.Expiry(last), s.upsideSlope()
+	if slope != 0 && f != 0 && (f < 0) == (slope > 0) {
+		out = append(out, last-f/slope)
+	}
+	return out
+}
+
+// MaxProfit is the best outcome per share at expiration, or nil when the
+// upside is unlimited.
+func (s Strategy) MaxProfit() *float64 {
+	if s.upsideSlope() > 0 {
+		return nil
+	}
+	best := math.Inf(-1)
+	for _, k := range s.kinks() {
+		best = max(best, s.Expiry(k))
+	}
+	return &best
+}
+
+// MaxLoss is the worst outcome per share at expiration, negative for a
+// loss, or nil when losses are unlimited.
+func (s Strategy) MaxLoss() *float64 {
+	if s.upsideSlope() < 0 {
+		return nil
+	}
+	worst := math.Inf(1)
+	for _, k := range s.kinks() {
+		worst = min(worst, s.Expiry(k))
+	}
+	return &worst
+}
+
+// StrategyTemplate is a strategy the options tool can build around one
+// strike. Spreads place their second strike Width away.
+type StrategyTemplate struct {
+	ID          string
+	Name        string
+	Description string
+	Spread      bool
+	legs        func(strike, width float64) []Leg
+}
+
+// Strategies are the single legs and common combinations the tool offers.
+var Strategies = []StrategyTemplate{
+	{
+		ID: "long-call", Name: "Long Call",
+		Description: "Buy a call: the right to buy 100 shares at the strike. Risk is limited to the premium.",
+		legs: func(k, _ float64) []Leg {
+			return []Leg{{Kind: Call, Strike: k, Quantity: 1}}
+		},
+	},
+	{
+		ID: "long-put", Name: "Long Put",
+		Description: "Buy a put: the right to sell 100 shares at the strike, often used as insurance.",
+		legs: func(k, _ float64) []Leg {
+			return []Leg{{Kind: Put, Strike: k, Quantity: 1}}
+		},
+	},
+	{
+		ID: "covered-call", Name: "Covered Call",
+		Description: "Own the shares and sell a call at the strike, giving up gains above it for the premium.",
+		legs: func(k, _ float64) []Leg {
+			return []Leg{{Kind: Stock, Quantity: 1}, {Kind: Call, Strike: k, Quantity: -1}}
+		},
+	},
+	{
+		ID: "bull-call-spread", Name: "Bull Call Spread", Spread: true,
+		Description: "Buy a call at the strike and sell one higher, a cheaper bet on a rise with capped profit.",
+		legs: func(k, w float64) []Leg {
+			return []Leg{{Kind: Call, Strike: k, Quantity: 1}, {Kind: Call, Strike: k + w, Quantity: -1}}
+		},
+	},
+	{
+		ID: "bear-put-spread", Name: "Bear Put Spread", Spread: true,
+		Description: "Buy a put at the strike and sell one lower, a cheaper bet on a decline with capped profit.",
+		legs: func(k, w float64) []Leg {
+			return []Leg{{Kind: Put, Strike: k, Quantity: 1}, {Kind: Put, Strike: k - w, Quantity: -1}}
+		},
+	},
+	{
+		ID: "long-straddle", Name: "Long Straddle",
+		Description: "Buy a call and a put at the same strike, profiting from a large move in either direction.",
+		legs: func(k, _ float64) []Leg {
+			return []Leg{{Kind: Call, Strike: k, Quantity: 1}, {Kind: Put, Strike: k, Quantity: 1}}
+		},
+	},
+}
+
+// FindStrategy looks up a template in Strategies by ID.
+func FindStrategy(id string) (StrategyTemplate, bool) {
+	for _, t := range Strategies {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return StrategyTemplate{}, false
+}
+
+// Build creates the strategy around in.Strike, pricing each option leg with
+// Black-Scholes at in and buying stock legs at in.Spot.
+func (t StrategyTemplate) Build(in Inputs, width float64) Strategy {
+	legs := t.legs(in.Strike, width)
+	for i := range legs {
+		if legs[i].Kind == Stock {
+			legs[i].Premium = in.Spot
+			continue
+		}
+		leg := in
+		leg.Strike = legs[i].Strike
+		legs[i].Premium = Price(legs[i].Kind, leg)
+	}
+	return Strategy{ID: t.ID, Name: t.Name, Legs: legs}
+}
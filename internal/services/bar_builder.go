@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/marketcalendar"
+)
+
+// barSource is the price store source recorded for bars built from quotes.
+// They are stored under their own interval key, see builtKey.
+const barSource = "quotes"
+
+// liveQuoteSources are the providers whose quotes are real trades. Bars
+// are only built from these, so the demo provider's invented prices never
+// reach the price store or the 1D chart.
+var liveQuoteSources = map[string]bool{
+	"finnhub":      true,
+	"alphavantage": true,
+	"yahoo":        true,
+}
+
+// builtIntervals are the bar sizes BarBuilder keeps, shortest first.
+var builtIntervals = []struct {
+	name   string
+	length time.Duration
+}{
+	{"1m", time.Minute},
+	{"5m", 5 * time.Minute},
+	{"15m", 15 * time.Minute},
+}
+
+// BarBuilder turns successive quote snapshots taken during the regular
+// session into 1m, 5m and 15m OHLCV bars. The bar in progress lives in
+// memory; Run writes each bar to the price store once it closes.
+type BarBuilder struct {
+	log   *slog.Logger
+	store func(ctx context.Context, symbol, interval, source string, bars []HistoricalData) error
+
+	mu      sync.Mutex
+	symbols map[string]*symbolBars
+}
+
+// symbolBars is one symbol's open bar per interval and the closed bars not
+// yet stored.
+type symbolBars struct {
+	session time.Time
+	// last is when the latest snapshot was taken; older ones are dropped.
+	last time.Time
+	// volume is the day's cumulative volume at the last snapshot; each bar
+	// gets the increase seen while it was open.
+	volume int64
+	open   map[string]*HistoricalData
+	closed map[string][]HistoricalData
+}
+
+func newBarBuilder(log *slog.Logger, store func(ctx context.Context, symbol, interval, source string, bars []HistoricalData) error) *BarBuilder {
+	return &BarBuilder{log: log, store: store, symbols: map[string]*symbolBars{}}
+}
+
+// Observe folds a quote for symbol into its bars. Stale quotes, quotes not
+// from a live vendor, quotes taken outside the regular session and
+// snapshots older than the last one are ignored, as are coins: they have
+// no session, and vendors report their volume over a rolling day, so their
+// bars come from vendor candles.
+func (b *BarBuilder) Observe(symbol string, quote *StockQuote) {
+	if IsCrypto(symbol) || !liveQuoteSources[quote.Source] {
+		return
+	}
+	at := quote.UpdatedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+	if quote.Stale || quote.Price <= 0 || !marketcalendar.IsOpen(at) {
+		return
+	}
+	session, _ := marketcalendar.SessionOn(at)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sb, ok := b.symbols[symbol]
+	if !ok {
+		sb = &symbolBars{open: map[string]*HistoricalData{}, closed: map[string][]HistoricalData{}}
+		b.symbols[symbol] = sb
+	}
+	if at.Before(sb.last) {
+		return
+	}
+	sb.last = at
+
+	// The first snapshot of a session has no baseline, so its volume is not
+	// credited to any bar.
+	var traded int64
+	if sb.session.Equal(session.Date) {
+		traded = max(quote.Volume-sb.volume, 0)
+	}
+	if !sb.session.Equal(session.Date) || quote.Volume >= sb.volume {
+		sb.session, sb.volume = session.Date, quote.Volume
+	}
+
+	for _, iv := range builtIntervals {
+		start := at.Truncate(iv.length)
+		bar := sb.open[iv.name]
+		if bar != nil && start.After(bar.Timestamp) {
+			sb.closed[iv.name] = append(sb.closed[iv.name], *bar)
+			bar = nil
+		}
+		if bar == nil {
+			sb.open[iv.name] = &HistoricalData{
+				Date:      barLabel(iv.name, start),
+				Timestamp: start.UTC(),
+				Open:      quote.Price,
+				High:      quote.Price,
+				Low:       quote.Price,
+				Close:     quote.Price,
+				Volume:    traded,
+			}
+			continue
+		}
+		bar.High = max(bar.High, quote.Price)
+		bar.Low = min(bar.Low, quote.Price)
+		bar.Close = quote.Price
+		bar.Volume += traded
+	}
+}
+
+// Pending returns the symbol's bars for interval that are not in the price
+// store yet: closed bars awaiting a flush and the bar in progress.
+func (b *BarBuilder) Pending(symbol, interval string) []HistoricalData {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sb, ok := b.symbols[symbol]
+	if !ok {
+		return nil
+	}
+	out := slices.Clone(sb.closed[interval])
+	if bar := sb.open[interval]; bar != nil {
+		out = append(out, *bar)
+	}
+	return out
+}
+
+// Run stores closed bars just after each minute boundary until ctx is
+// cancelled.
+func (b *BarBuilder) Run(ctx context.Context) {
+	for {
+		next := time.Now().Truncate(time.Minute).Add(time.Minute + time.Second)
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		b.Flush(ctx, time.Now())
+	}
+}
+
+// Flush closes every bar that ended by now and writes the closed bars to
+// the price store. Bars that fail to store are dropped with a warning
+// rather than retried, so a broken store cannot grow memory without bound.
+func (b *BarBuilder) Flush(ctx context.Context, now time.Time) {
+	type batch struct {
+		symbol, interval string
+		bars             []HistoricalData
+	}
+	var batches []batch
+
+	b.mu.Lock()
+	for symbol, sb := range b.symbols {
+		for _, iv := range builtIntervals {
+			if bar := sb.open[iv.name]; bar != nil && !now.Before(bar.Timestamp.Add(iv.length)) {
+				sb.closed[iv.name] = append(sb.closed[iv.name], *bar)
+				delete(sb.open, iv.name)
+			}
+			if bars := sb.closed[iv.name]; len(bars) > 0 {
+				batches = append(batches, batch{symbol, iv.name, bars})
+				delete(sb.closed, iv.name)
+			}
+		}
+		if len(sb.open) == 0 && len(sb.closed) == 0 && !marketcalendar.IsOpen(now) {
+			delete(b.symbols, symbol)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, batch := range batches {
+		if err := b.store(ctx, batch.symbol, builtKey(batch.interval), barSource, batch.bars); err != nil {
+			b.log.Warn("intraday bar flush failed",
+				slog.String("symbol", batch.symbol),
+				slog.String("interval", batch.interval),
+				slog.Int("bars", len(batch.bars)),
+				slog.Any("err", err),
+			)
+		}
+	}
+}
+
+// builtInterval returns the length of a bar size BarBuilder keeps.
+func builtInterval(interval string) (time.Duration, bool) {
+	for _, iv := range builtIntervals {
+		if iv.name == interval {
+			return iv.length, true
+		}
+	}
+	return 0, false
+}
+
+// builtKey is the price store interval for bars built from quotes, such as
+// "5m@quotes". Vendor bars of the same size keep the plain key, so neither
+// writer overwrites the other's rows.
+func builtKey(interval string) string {
+	return interval + "@" + barSource
+}
+
+// sessionHistory merges the latest session's stored built bars with those
+// still in the bar builder, and reports whether together they run from the open
+// to now (or the close) with no gap longer than three bars.
+func (s *MarketDataService) sessionHistory(ctx context.Context, symbol, interval string, now time.Time) ([]HistoricalData, bool) {
+	length, ok := builtInterval(interval)
+	if !ok {
+		return nil, false
+	}
+	session, ok := marketcalendar.SessionOn(now)
+	if !ok || now.Before(session.Open) {
+		session, _ = marketcalendar.SessionOn(marketcalendar.PreviousTradingDay(now))
+	}
+	end := now
+	if end.After(session.Close) {
+		end = session.Close
+	}
+
+	stored, err := s.StoredHistory(ctx, symbol, builtKey(interval), session.Open, end)
+	if err != nil {
+		s.log.Warn("price store read failed", slog.String("symbol", symbol), slog.Any("err", err))
+	}
+
+	byTime := make(map[time.Time]HistoricalData, len(stored))
+	for _, bar := range stored {
+		byTime[bar.Timestamp.UTC()] = bar
+	}
+	// The builder's copy of a bar is newer than anything flushed earlier.
+	for _, bar := range s.bars.Pending(symbol, interval) {
+		if !bar.Timestamp.Before(session.Open) && bar.Timestamp.Before(session.Close) {
+			byTime[bar.Timestamp.UTC()] = bar
+		}
+	}
+	bars := make([]HistoricalData, 0, len(byTime))
+	for _, bar := range byTime {
+		bars = append(bars, bar)
+	}
+	slices.SortFunc(bars, func(a, b HistoricalData) int { return a.Timestamp.Compare(b.Timestamp) })
+
+	if len(bars) == 0 || bars[0].Timestamp.After(session.Open.Add(length)) {
+		return bars, false
+	}
+	prev := bars[0].Timestamp
+	for _, bar := range bars[1:] {
+		if bar.Timestamp.Sub(prev) > 3*length {
+			return bars, false
+		}
+		prev = bar.Timestamp
+	}
+	return bars, !prev.Add(3 * length).Before(end)
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/database"
+	"github.com/loganlanou/Financing-101/internal/marketcalendar"
+)
+
+// Corporate action types stored in corporate_actions.
+const (
+	ActionSplit        = "split"
+	ActionDividend     = "dividend"
+	ActionSymbolChange = "symbol_change"
+)
+
+// CorporateAction is a split, cash dividend or ticker change that takes
+// effect at the open of ExDate.
+type CorporateAction struct {
+	Symbol string    `json:"symbol"`
+	Type   string    `json:"type"`
+	ExDate time.Time `json:"exDate"`
+	// Ratio is new shares per old share: 4 for a 4-for-1 split, 0.1 for a
+	// 1-for-10 reverse split.
+	Ratio float64 `json:"ratio,omitempty"`
+	// Amount is the cash dividend per share as of ExDate.
+	Amount float64 `json:"amount,omitempty"`
+	// NewSymbol is the ticker traded from ExDate after a symbol change.
+	NewSymbol string `json:"newSymbol,omitempty"`
+	Source    string `json:"source,omitempty"`
+}
+
+// FetchActions asks providers for corporate actions in [from, to] and
+// reports which one answered. Vendors without an events feed are skipped.
+func (s *MarketDataService) FetchActions(ctx context.Context, symbol string, from, to time.Time) ([]CorporateAction, string, error) {
+	err := ErrNotSupported
+	for _, provider := range s.providers {
+		source, ok := provider.(ActionsProvider)
+		if !ok {
+			continue
+		}
+		var actions []CorporateAction
+		actions, err = source.Actions(ctx, symbol, from, to)
+		if err == nil {
+			return actions, provider.Name(), nil
+		}
+		if errors.Is(err, ErrNotSupported) {
+			continue
+		}
+	}
+
+	return nil, "", fmt.Errorf("no corporate action source for %s: %w", symbol, err)
+}
+
+// StoreActions upserts actions into the corporate_actions store
+func (s *MarketDataService) StoreActions(ctx context.Context, source string, actions []CorporateAction) error {
+	if s.queries == nil {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	for _, action := range actions {
+		err := s.queries.UpsertCorporateAction(ctx, database.UpsertCorporateActionParams{
+			Symbol:    action.Symbol,
+			Type:      action.Type,
+			ExDate:    normalizeBarTime("1d", action.ExDate),
+			Ratio:     action.Ratio,
+			Amount:    action.Amount,
+			NewSymbol: action.NewSymbol,
+			Source:    source,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("store %s %s: %w", action.Symbol, action.Type, err)
+		}
+	}
+
+	return nil
+}
+
+// Actions reads the stored corporate actions for symbol, oldest first
+func (s *MarketDataService) Actions(ctx context.Context, symbol string) ([]CorporateAction, error) {
+	if s.queries == nil {
+		return nil, nil
+	}
+
+	rows, err := s.queries.ListCorporateActions(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	return actionsFromRows(rows), nil
+}
+
+// AdjustedHistory reads bars in [from, to] from the price store adjusted for
+// splits and dividends, continuing into the ticker a symbol traded under
+// before a symbol change. Return calculations use this instead of
+// StoredHistory so splits do not show up as crashes and dividends count.
+func (s *MarketDataService) AdjustedHistory(ctx context.Context, symbol, interval string, from, to time.Time) ([]HistoricalData, error) {
+	bars, actions, err := s.historyWithActions(ctx, symbol, interval, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return AdjustHistory(bars, actions), nil
+}
+
+// historyWithActions gathers raw bars and the actions that apply to them.
+// Each hop back through a symbol change ends before that change's ex date,
+// so a reused ticker cannot loop.
+func (s *MarketDataService) historyWithActions(ctx context.Context, symbol, interval string, from, to time.Time) ([]HistoricalData, []CorporateAction, error) {
+	bars, err := s.StoredHistory(ctx, symbol, interval, from, to)
+	if err != nil || s.queries == nil {
+		return bars, nil, err
+	}
+	actions, err := s.Actions(ctx, symbol)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read corporate actions: %w", err)
+	}
+
+	rows, err := s.queries.ListSymbolChangesTo(ctx, symbol)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read symbol changes: %w", err)
+	}
+	changes := actionsFromRows(rows)
+	for i := len(changes) - 1; i >= 0; i-- {
+		change := changes[i]
+		if !change.ExDate.After(from) || change.ExDate.After(to) {
+			continue
+		}
+		// Providers often serve the full history under the new ticker already.
+		if len(bars) > 0 && bars[0].Timestamp.Before(change.ExDate) {
+			break
+		}
+
+		older, olderActions, err := s.historyWithActions(ctx, change.Symbol, interval, from, change.ExDate.Add(-time.Second))
+		if err != nil {
+			return nil, nil, err
+		}
+		bars = append(older, bars...)
+		actions = append(olderActions, actions...)
+		break
+	}
+
+	return bars, actions, nil
+}
+
+// AdjustHistory returns a copy of bars back-adjusted for splits and cash
+// dividends: the last bar keeps its traded prices and every earlier bar is
+// scaled so close-to-close changes are total returns. Bars must be in time
+// order; actions may be in any order.
+func AdjustHistory(bars []HistoricalData, actions []CorporateAction) []HistoricalData {
+	out := slices.Clone(bars)
+	if len(out) < 2 || len(actions) == 0 {
+		return out
+	}
+
+	sorted := slices.Clone(actions)
+	sortActions(sorted)
+
+	// Actions after the last bar have not happened as far as these bars know.
+	next := len(sorted) - 1
+	for next >= 0 && sorted[next].ExDate.After(out[len(out)-1].Timestamp) {
+		next--
+	}
+
+	priceFactor, volumeFactor := 1.0, 1.0
+	for i := len(out) - 2; i >= 0; i-- {
+		// Apply actions whose ex date falls after bar i and by bar i+1, using
+		// raw closes on either side of it.
+		prevClose, exClose := bars[i].Close, bars[i+1].Close
+		for next >= 0 && sorted[next].ExDate.After(out[i].Timestamp) {
+			action := sorted[next]
+			next--
+			switch action.Type {
+			case ActionSplit:
+				if splitPending(prevClose, exClose, action.Ratio) {
+					priceFactor /= action.Ratio
+					volumeFactor *= action.Ratio
+				}
+			case ActionDividend:
+				if action.Amount > 0 && prevClose > action.Amount {
+					priceFactor *= 1 - action.Amount/prevClose
+				}
+			}
+		}
+
+		out[i].Open *= priceFactor
+		out[i].High *= priceFactor
+		out[i].Low *= priceFactor
+		out[i].Close *= priceFactor
+		out[i].Volume = int64(math.Round(float64(out[i].Volume) * volumeFactor))
+	}
+
+	return out
+}
+
+// splitPending reports whether raw closes still show a split's price jump.
+// Some providers split-adjust history before serving it, and adjusting
+// those bars again would count the split twice.
+func splitPending(prevClose, exClose, ratio float64) bool {
+	if prevClose <= 0 || exClose <= 0 || ratio <= 0 || ratio == 1 {
+		return false
+	}
+	jump := math.Log(prevClose / exClose)
+	return math.Abs(jump-math.Log(ratio)) < math.Abs(jump)
+}
+
+// actionDate keys a vendor event timestamp by its exchange-local calendar
+// date at midnight UTC, matching how the price store keys daily bars.
+func actionDate(t time.Time) time.Time {
+	local := t.In(marketcalendar.Location())
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func sortActions(actions []CorporateAction) {
+	slices.SortStableFunc(actions, func(a, b CorporateAction) int {
+		return a.ExDate.Compare(b.ExDate)
+	})
+}
+
+func actionsFromRows(rows []database.CorporateAction) []CorporateAction {
+	out := make([]CorporateAction, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, CorporateAction{
+			Symbol:    row.Symbol,
+			Type:      row.Type,
+			ExDate:    row.ExDate.UTC(),
+			Ratio:     row.Ratio,
+			Amount:    row.Amount,
+			NewSymbol: row.NewSymbol,
+			Source:    row.Source,
+		})
+	}
+	return out
+}
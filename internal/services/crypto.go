@@ -0,0 +1,96 @@
+package services
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/marketcalendar"
+)
+
+// CryptoAsset is a coin the app knows by name, quoted as a dollar pair.
+type CryptoAsset struct {
+	// Symbol is the pair the app quotes, e.g. BTC-USD.
+	Symbol string
+	Base   string
+	Name   string
+}
+
+// CryptoAssets are the coins seeded into the symbol master and tagged in
+// news by cashtag ("$BTC").
+var CryptoAssets = []CryptoAsset{
+	{Symbol: "BTC-USD", Base: "BTC", Name: "Bitcoin"},
+	{Symbol: "ETH-USD", Base: "ETH", Name: "Ethereum"},
+	{Symbol: "SOL-USD", Base: "SOL", Name: "Solana"},
+	{Symbol: "XRP-USD", Base: "XRP", Name: "XRP"},
+	{Symbol: "ADA-USD", Base: "ADA", Name: "Cardano"},
+	{Symbol: "DOGE-USD", Base: "DOGE", Name: "Dogecoin"},
+	{Symbol: "LTC-USD", Base: "LTC", Name: "Litecoin"},
+	{Symbol: "AVAX-USD", Base: "AVAX", Name: "Avalanche"},
+	{Symbol: "LINK-USD", Base: "LINK", Name: "Chainlink"},
+	{Symbol: "DOT-USD", Base: "DOT", Name: "Polkadot"},
+}
+
+// cryptoBases are the coin tickers IsCrypto recognizes: the CryptoAssets
+// plus any pairs configured with AddCryptoSymbols.
+var cryptoBases = struct {
+	sync.RWMutex
+	set map[string]bool
+}{set: knownCryptoBases()}
+
+func knownCryptoBases() map[string]bool {
+	bases := make(map[string]bool, len(CryptoAssets))
+	for _, asset := range CryptoAssets {
+		bases[asset.Base] = true
+	}
+	return bases
+}
+
+// AddCryptoSymbols registers the coins of configured pairs such as
+// "SHIB-USD" so IsCrypto recognizes them. Call it at startup.
+func AddCryptoSymbols(symbols []string) {
+	cryptoBases.Lock()
+	defer cryptoBases.Unlock()
+	for _, symbol := range symbols {
+		if base, quote, ok := strings.Cut(strings.ToUpper(symbol), "-"); ok && base != "" {
+			if _, valid := NormalizeCurrency(quote); valid {
+				cryptoBases.set[base] = true
+			}
+		}
+	}
+}
+
+// IsCrypto reports whether symbol is a known coin quoted in a currency,
+// such as BTC-USD or ETH-EUR. The coin must be known rather than inferred
+// from the pair's shape, so currency pairs like EUR-USD are not coins.
+func IsCrypto(symbol string) bool {
+	base, quote, ok := strings.Cut(symbol, "-")
+	if !ok || base == "" {
+		return false
+	}
+	if _, valid := NormalizeCurrency(quote); !valid || strings.ToUpper(quote) != quote {
+		return false
+	}
+	cryptoBases.RLock()
+	defer cryptoBases.RUnlock()
+	return cryptoBases.set[base]
+}
+
+// cryptoName is a known coin's name, or the pair itself.
+func cryptoName(symbol string) string {
+	for _, asset := range CryptoAssets {
+		if asset.Symbol == symbol {
+			return asset.Name
+		}
+	}
+	return symbol
+}
+
+// MarketStatus is the trading status of one symbol right now: coins trade
+// around the clock, everything else follows the US exchange session.
+func MarketStatus(symbol string, now time.Time) string {
+	if IsCrypto(symbol) {
+		return marketcalendar.StatusOpen
+	}
+	return marketcalendar.Status(now)
+}
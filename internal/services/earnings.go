@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/database"
+	"github.com/loganlanou/Financing-101/internal/marketcalendar"
+)
+
+// Earnings report timings stored in earnings_events.
+const (
+	EarningsBeforeOpen  = "bmo"
+	EarningsAfterClose  = "amc"
+	EarningsDuringHours = "dmh"
+)
+
+// EarningsHistoryQuarters is how many past reports the stock page shows.
+const EarningsHistoryQuarters = 8
+
+// EarningsEvent is one quarterly report. Estimates are the consensus going
+// into the report; actuals are nil until the company has reported.
+type EarningsEvent struct {
+	Symbol          string    `json:"symbol"`
+	ReportDate      time.Time `json:"reportDate"`
+	Timing          string    `json:"timing,omitempty"`
+	FiscalPeriod    string    `json:"fiscalPeriod,omitempty"`
+	EPSEstimate     *float64  `json:"epsEstimate,omitempty"`
+	EPSActual       *float64  `json:"epsActual,omitempty"`
+	RevenueEstimate *float64  `json:"revenueEstimate,omitempty"`
+	RevenueActual   *float64  `json:"revenueActual,omitempty"`
+}
+
+// Reported reports whether the event carries any actual results.
+func (e EarningsEvent) Reported() bool {
+	return e.EPSActual != nil || e.RevenueActual != nil
+}
+
+// EPSSurprise is the percent by which reported EPS beat (positive) or missed
+// the estimate, or nil when either side is missing.
+func (e EarningsEvent) EPSSurprise() *float64 {
+	return surprise(e.EPSActual, e.EPSEstimate)
+}
+
+// RevenueSurprise is the percent by which revenue beat or missed the estimate.
+func (e EarningsEvent) RevenueSurprise() *float64 {
+	return surprise(e.RevenueActual, e.RevenueEstimate)
+}
+
+// TimingLabel describes a report timing for display.
+func TimingLabel(timing string) string {
+	switch timing {
+	case EarningsBeforeOpen:
+		return "Before Open"
+	case EarningsAfterClose:
+		return "After Close"
+	case EarningsDuringHours:
+		return "During Hours"
+	}
+	return "Time TBD"
+}
+
+// EarningsSummary is a company's next scheduled report and its recent
+// reports, newest first.
+type EarningsSummary struct {
+	Next    *EarningsEvent  `json:"next,omitempty"`
+	History []EarningsEvent `json:"history"`
+}
+
+// EarningsService stores earnings calendar entries and results.
+type EarningsService struct {
+	log     *slog.Logger
+	queries *database.Queries
+}
+
+func NewEarningsService(log *slog.Logger, queries *database.Queries) *EarningsService {
+	return &EarningsService{log: log, queries: queries}
+}
+
+// Store upserts events; a later dump with actuals fills in a scheduled report,
+// and blank fields never overwrite stored ones.
+func (s *EarningsService) Store(ctx context.Context, source string, events []EarningsEvent) error {
+	now := time.Now().UTC()
+	for _, event := range events {
+		err := s.queries.UpsertEarningsEvent(ctx, database.UpsertEarningsEventParams{
+			Symbol:          event.Symbol,
+			ReportDate:      normalizeBarTime("1d", event.ReportDate),
+			Timing:          event.Timing,
+			FiscalPeriod:    event.FiscalPeriod,
+			EpsEstimate:     sqlFloat(event.EPSEstimate),
+			EpsActual:       sqlFloat(event.EPSActual),
+			RevenueEstimate: sqlFloat(event.RevenueEstimate),
+			RevenueActual:   sqlFloat(event.RevenueActual),
+			Source:          source,
+			UpdatedAt:       now,
+		})
+		if err != nil {
+			return fmt.Errorf("store %s earnings %s: %w", event.Symbol, event.ReportDate.Format("2006-01-02"), err)
+		}
+	}
+	return nil
+}
+
+// ForSymbol returns the next report on or after today, exchange time, and
+// the EarningsHistoryQuarters reports before it.
+func (s *EarningsService) ForSymbol(ctx context.Context, symbol string) (*EarningsSummary, error) {
+	today := s.today()
+
+	rows, err := s.queries.ListEarningsHistory(ctx, database.ListEarningsHistoryParams{
+		Symbol: symbol,
+		Before: today,
+		Limit:  EarningsHistoryQuarters,
+	})
+	if err != nil {
+		return nil, err
+	}
+	summary := &EarningsSummary{History: make([]EarningsEvent, 0, len(rows))}
+	for _, row := range rows {
+		summary.History = append(summary.History, earningsFromRow(row))
+	}
+
+	next, err := s.queries.GetNextEarningsEvent(ctx, database.GetNextEarningsEventParams{
+		Symbol:   symbol,
+		FromTime: today,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return summary, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	event := earningsFromRow(next)
+	summary.Next = &event
+	return summary, nil
+}
+
+// Calendar lists reports dated in [from, to), by date then symbol.
+func (s *EarningsService) Calendar(ctx context.Context, from, to time.Time) ([]EarningsEvent, error) {
+	rows, err := s.queries.ListEarningsCalendar(ctx, database.ListEarningsCalendarParams{
+		FromTime: normalizeBarTime("1d", from),
+		ToTime:   normalizeBarTime("1d", to),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]EarningsEvent, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, earningsFromRow(row))
+	}
+	return out, nil
+}
+
+// today is the current exchange-local date at midnight UTC, the way report
+// dates are stored.
+func (s *EarningsService) today() time.Time {
+	now := time.Now().In(marketcalendar.Location())
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func earningsFromRow(row database.EarningsEvent) EarningsEvent {
+	return EarningsEvent{
+		Symbol:          row.Symbol,
+		ReportDate:      row.ReportDate.UTC(),
+		Timing:          row.Timing,
+		FiscalPeriod:    row.FiscalPeriod,
+		EPSEstimate:     nullFloat(row.EpsEstimate),
+		EPSActual:       nullFloat(row.EpsActual),
+		RevenueEstimate: nullFloat(row.RevenueEstimate),
+		RevenueActual:   nullFloat(row.RevenueActual),
+	}
+}
+
+// surprise measures against the estimate's magnitude so a smaller loss than
+// expected counts as a beat.
+func surprise(actual, estimate *float64) *float64 {
+	if actual == nil || estimate == nil || *estimate == 0 {
+		return nil
+	}
+	v := (*actual - *estimate) / math.Abs(*estimate) * 100
+	return &v
+}
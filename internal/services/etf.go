@@ -0,0 +1,401 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/database"
+)
+
+// ErrNoHoldings is returned for a fund without a stored holdings file.
+var ErrNoHoldings = errors.New("no etf holdings stored")
+
+// Holding asset classes stored in etf_holdings.
+const (
+	AssetEquity = "equity"
+	AssetCash   = "cash"
+	AssetFuture = "future"
+	AssetOther  = "other"
+)
+
+// Exposure buckets for value that has no sector of its own.
+const (
+	SectorCashOther    = "Cash & Other"
+	SectorUnclassified = "Unclassified"
+	SectorCrypto       = "Crypto"
+	SectorFunds        = "Funds"
+)
+
+// maxLookThroughDepth bounds how many funds deep a fund of funds is opened.
+const maxLookThroughDepth = 3
+
+// sectorNames maps issuer and vendor sector labels, lower-cased, onto the
+// names used by SectorETFs.
+var sectorNames = map[string]string{
+	"information technology":     "Technology",
+	"technology":                 "Technology",
+	"communication":              "Communication Services",
+	"communications":             "Communication Services",
+	"communication services":     "Communication Services",
+	"telecommunication services": "Communication Services",
+	"consumer discretionary":     "Consumer Cyclical",
+	"consumer cyclical":          "Consumer Cyclical",
+	"financials":                 "Financials",
+	"financial services":         "Financials",
+	"health care":                "Healthcare",
+	"healthcare":                 "Healthcare",
+	"industrials":                "Industrials",
+	"consumer staples":           "Consumer Defensive",
+	"consumer defensive":         "Consumer Defensive",
+	"energy":                     "Energy",
+	"materials":                  "Materials",
+	"basic materials":            "Materials",
+	"utilities":                  "Utilities",
+	"real estate":                "Real Estate",
+}
+
+// canonicalSector names a sector the way the rest of the app does, keeping
+// labels it does not recognize.
+func canonicalSector(name string) string {
+	name = strings.TrimSpace(name)
+	if canonical, ok := sectorNames[strings.ToLower(name)]; ok {
+		return canonical
+	}
+	return name
+}
+
+// ETFHolding is one line of a fund's holdings file.
+type ETFHolding struct {
+	// Symbol is empty for cash and other lines without a ticker.
+	Symbol     string `json:"symbol,omitempty"`
+	Name       string `json:"name"`
+	Sector     string `json:"sector,omitempty"`
+	AssetClass string `json:"assetClass"`
+	// Weight is the fraction of the fund's net assets (0.071 = 7.1%).
+	Weight      float64  `json:"weight"`
+	Shares      *float64 `json:"shares,omitempty"`
+	MarketValue *float64 `json:"marketValue,omitempty"`
+}
+
+// ETFHoldings is a fund's stored holdings file, largest weight first.
+type ETFHoldings struct {
+	Symbol   string       `json:"symbol"`
+	AsOf     time.Time    `json:"asOf"`
+	Holdings []ETFHolding `json:"holdings"`
+}
+
+// ETFSummary describes one stored holdings file.
+type ETFSummary struct {
+	Symbol   string    `json:"symbol"`
+	AsOf     time.Time `json:"asOf"`
+	Holdings int       `json:"holdings"`
+}
+
+// Position is an amount held in one symbol. Values only need a common unit,
+// such as dollars, since exposures are also reported as weights.
+type Position struct {
+	Symbol string  `json:"symbol"`
+	Value  float64 `json:"value"`
+}
+
+// HoldingExposure is the value held in one underlying symbol, outright and
+// through funds.
+type HoldingExposure struct {
+	Symbol string  `json:"symbol"`
+	Name   string  `json:"name"`
+	Sector string  `json:"sector"`
+	Value  float64 `json:"value"`
+	Weight float64 `json:"weight"`
+	// Direct is the part of Value held outright rather than through a fund.
+	Direct float64 `json:"direct"`
+	// Via lists the funds contributing to Value, in the order first seen.
+	Via []string `json:"via,omitempty"`
+}
+
+// SectorExposure is the value held in one sector.
+type SectorExposure struct {
+	Sector string  `json:"sector"`
+	Value  float64 `json:"value"`
+	Weight float64 `json:"weight"`
+}
+
+// Exposure decomposes a set of positions into underlying holdings and
+// sectors, largest first. Weights are fractions of Total.
+type Exposure struct {
+	Total    float64           `json:"total"`
+	Holdings []HoldingExposure `json:"holdings"`
+	Sectors  []SectorExposure  `json:"sectors"`
+	// Funds are the holdings files used, in the order first opened.
+	Funds []ETFSummary `json:"funds"`
+	// Opaque are funds with no holdings file to open; each counts as a
+	// single holding in SectorFunds.
+	Opaque []string `json:"opaque,omitempty"`
+}
+
+// ETFService stores fund holdings and looks through funds to the stocks
+// they own.
+type ETFService struct {
+	log     *slog.Logger
+	queries *database.Queries
+}
+
+func NewETFService(log *slog.Logger, queries *database.Queries) *ETFService {
+	return &ETFService{log: log, queries: queries}
+}
+
+// StoreHoldings replaces a fund's holdings with one file's lines, kept in
+// file order.
+func (s *ETFService) StoreHoldings(ctx context.Context, source string, fund ETFHoldings) error {
+	if err := s.queries.DeleteETFHoldings(ctx, fund.Symbol); err != nil {
+		return fmt.Errorf("clear %s holdings: %w", fund.Symbol, err)
+	}
+	for i, h := range fund.Holdings {
+		err := s.queries.InsertETFHolding(ctx, database.InsertETFHoldingParams{
+			EtfSymbol:   fund.Symbol,
+			Position:    int64(i + 1),
+			Symbol:      h.Symbol,
+			Name:        h.Name,
+			Sector:      sql.NullString{String: h.Sector, Valid: h.Sector != ""},
+			AssetClass:  h.AssetClass,
+			Weight:      h.Weight,
+			Shares:      sqlFloat(h.Shares),
+			MarketValue: sqlFloat(h.MarketValue),
+			AsOf:        normalizeBarTime("1d", fund.AsOf),
+			Source:      source,
+		})
+		if err != nil {
+			return fmt.Errorf("store %s holding %q: %w", fund.Symbol, h.Name, err)
+		}
+	}
+	return nil
+}
+
+// Holdings returns a fund's stored holdings, or ErrNoHoldings.
+func (s *ETFService) Holdings(ctx context.Context, symbol string) (*ETFHoldings, error) {
+	rows, err := s.queries.ListETFHoldings(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w for %s", ErrNoHoldings, symbol)
+	}
+
+	fund := &ETFHoldings{Symbol: symbol, AsOf: rows[0].AsOf.UTC(), Holdings: make([]ETFHolding, 0, len(rows))}
+	for _, row := range rows {
+		fund.Holdings = append(fund.Holdings, ETFHolding{
+			Symbol:      row.Symbol,
+			Name:        row.Name,
+			Sector:      canonicalSector(row.Sector.String),
+			AssetClass:  row.AssetClass,
+			Weight:      row.Weight,
+			Shares:      nullFloat(row.Shares),
+			MarketValue: nullFloat(row.MarketValue),
+		})
+	}
+	return fund, nil
+}
+
+// List describes every stored holdings file, by fund symbol.
+func (s *ETFService) List(ctx context.Context) ([]ETFSummary, error) {
+	rows, err := s.queries.ListETFs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ETFSummary, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, ETFSummary{Symbol: row.EtfSymbol, AsOf: row.AsOf.UTC(), Holdings: int(row.Holdings)})
+	}
+	return out, nil
+}
+
+// Summary describes one fund's holdings file, or returns ErrNoHoldings.
+func (s *ETFService) Summary(ctx context.Context, symbol string) (*ETFSummary, error) {
+	funds, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, fund := range funds {
+		if fund.Symbol == symbol {
+			return &fund, nil
+		}
+	}
+	return nil, fmt.Errorf("%w for %s", ErrNoHoldings, symbol)
+}
+
+// LookThrough decomposes positions into the stocks and sectors underneath.
+// Funds with a holdings file are replaced by their holdings, weight for
+// weight, opening funds of funds up to three levels deep. Cash, futures and
+// any weight a file leaves unlisted count as SectorCashOther.
+func (s *ETFService) LookThrough(ctx context.Context, positions []Position) (*Exposure, error) {
+	l := &lookThrough{
+		svc:      s,
+		funds:    map[string]*ETFHoldings{},
+		holdings: map[string]*HoldingExposure{},
+		sectors:  map[string]float64{},
+		exposure: &Exposure{},
+	}
+	for _, p := range positions {
+		if p.Value <= 0 {
+			continue
+		}
+		l.exposure.Total += p.Value
+		if err := l.add(ctx, p.Symbol, "", "", p.Value, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	out := l.exposure
+	for _, h := range l.holdings {
+		if out.Total > 0 {
+			h.Weight = h.Value / out.Total
+		}
+		out.Holdings = append(out.Holdings, *h)
+	}
+	for sector, value := range l.sectors {
+		exposure := SectorExposure{Sector: sector, Value: value}
+		if out.Total > 0 {
+			exposure.Weight = value / out.Total
+		}
+		out.Sectors = append(out.Sectors, exposure)
+	}
+	slices.SortFunc(out.Holdings, func(a, b HoldingExposure) int {
+		return cmp.Or(cmp.Compare(b.Value, a.Value), cmp.Compare(a.Symbol, b.Symbol))
+	})
+	slices.SortFunc(out.Sectors, func(a, b SectorExposure) int {
+		return cmp.Or(cmp.Compare(b.Value, a.Value), cmp.Compare(a.Sector, b.Sector))
+	})
+	return out, nil
+}
+
+// lookThrough accumulates one LookThrough call, loading each fund once.
+type lookThrough struct {
+	svc      *ETFService
+	funds    map[string]*ETFHoldings
+	holdings map[string]*HoldingExposure
+	sectors  map[string]float64
+	exposure *Exposure
+}
+
+// add credits value in symbol, reached through the funds in via. name and
+// sector come from the fund's file when symbol is one of its holdings.
+func (l *lookThrough) add(ctx context.Context, symbol, name, sector string, value float64, via []string) error {
+	fund, err := l.fund(ctx, symbol, via)
+	if err != nil {
+		return err
+	}
+	if fund != nil {
+		via = append(slices.Clone(via), symbol)
+		listed := 0.0
+		for _, h := range fund.Holdings {
+			listed += h.Weight
+			if h.Symbol == "" || h.AssetClass != AssetEquity {
+				l.sectors[SectorCashOther] += value * h.Weight
+				continue
+			}
+			if err := l.add(ctx, h.Symbol, h.Name, h.Sector, value*h.Weight, via); err != nil {
+				return err
+			}
+		}
+		if listed < 1 {
+			l.sectors[SectorCashOther] += value * (1 - listed)
+		}
+		return nil
+	}
+
+	exposure, ok := l.holdings[symbol]
+	if !ok {
+		exposure = &HoldingExposure{Symbol: symbol, Name: name, Sector: sector}
+		if err := l.describe(ctx, exposure); err != nil {
+			return err
+		}
+		l.holdings[symbol] = exposure
+	}
+	exposure.Value += value
+	if len(via) == 0 {
+		exposure.Direct += value
+	} else if fund := via[len(via)-1]; !slices.Contains(exposure.Via, fund) {
+		exposure.Via = append(exposure.Via, fund)
+	}
+	l.sectors[exposure.Sector] += value
+	return nil
+}
+
+// fund loads symbol's holdings when it is a fund that can be opened at this
+// depth, and nil otherwise.
+func (l *lookThrough) fund(ctx context.Context, symbol string, via []string) (*ETFHoldings, error) {
+	if len(via) >= maxLookThroughDepth || slices.Contains(via, symbol) {
+		return nil, nil
+	}
+	if fund, ok := l.funds[symbol]; ok {
+		return fund, nil
+	}
+	fund, err := l.svc.Holdings(ctx, symbol)
+	if errors.Is(err, ErrNoHoldings) {
+		l.funds[symbol] = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	l.funds[symbol] = fund
+	l.exposure.Funds = append(l.exposure.Funds, ETFSummary{Symbol: symbol, AsOf: fund.AsOf, Holdings: len(fund.Holdings)})
+	return fund, nil
+}
+
+// describe fills in a holding's name and sector from the symbol master and
+// company profiles when its fund's file did not supply them. Funds without
+// a holdings file are noted as opaque.
+func (l *lookThrough) describe(ctx context.Context, exposure *HoldingExposure) error {
+	if IsCrypto(exposure.Symbol) {
+		exposure.Sector = SectorCrypto
+		if exposure.Name == "" {
+			exposure.Name = cryptoName(exposure.Symbol)
+		}
+		return nil
+	}
+
+	row, err := l.svc.queries.GetSymbol(ctx, exposure.Symbol)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if err == nil {
+		if exposure.Name == "" {
+			exposure.Name = row.Name
+		}
+		if exposure.Sector == "" {
+			exposure.Sector = canonicalSector(row.Sector.String)
+		}
+		if (row.Type == "etf" || row.Type == "fund") && exposure.Sector == "" {
+			exposure.Sector = SectorFunds
+			l.exposure.Opaque = append(l.exposure.Opaque, exposure.Symbol)
+		}
+	}
+
+	if exposure.Sector == "" {
+		profile, err := l.svc.queries.GetCompanyProfile(ctx, exposure.Symbol)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if err == nil {
+			exposure.Sector = canonicalSector(profile.Sector.String)
+			if exposure.Name == "" {
+				exposure.Name = profile.Name
+			}
+		}
+	}
+
+	if exposure.Name == "" {
+		exposure.Name = exposure.Symbol
+	}
+	if exposure.Sector == "" {
+		exposure.Sector = SectorUnclassified
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/database"
+)
+
+// fundamentalsHistory is how many quarters are read per company; eight
+// covers the trailing twelve months and the same period a year earlier.
+const fundamentalsHistory = 8
+
+// FundamentalsService serves statement lines imported from SEC filings and
+// the ratios derived from them.
+type FundamentalsService struct {
+	log     *slog.Logger
+	queries *database.Queries
+}
+
+func NewFundamentalsService(log *slog.Logger, queries *database.Queries) *FundamentalsService {
+	return &FundamentalsService{log: log, queries: queries}
+}
+
+// CompanyFundamentals is a company's recent quarters, newest first.
+type CompanyFundamentals struct {
+	Symbol   string              `json:"symbol"`
+	CIK      string              `json:"cik"`
+	Name     string              `json:"name"`
+	Sector   string              `json:"sector,omitempty"`
+	Industry string              `json:"industry,omitempty"`
+	Quarters []FundamentalPeriod `json:"quarters"`
+	Ratios   FundamentalRatios   `json:"ratios"`
+}
+
+// FundamentalPeriod holds one fiscal quarter; nil lines were not reported.
+type FundamentalPeriod struct {
+	PeriodEnd          time.Time `json:"periodEnd"`
+	FiscalYear         int       `json:"fiscalYear"`
+	FiscalPeriod       string    `json:"fiscalPeriod"`
+	Form               string    `json:"form"`
+	FiledAt            time.Time `json:"filedAt"`
+	Revenue            *float64  `json:"revenue,omitempty"`
+	GrossProfit        *float64  `json:"grossProfit,omitempty"`
+	OperatingIncome    *float64  `json:"operatingIncome,omitempty"`
+	NetIncome          *float64  `json:"netIncome,omitempty"`
+	EPSDiluted         *float64  `json:"epsDiluted,omitempty"`
+	TotalAssets        *float64  `json:"totalAssets,omitempty"`
+	TotalLiabilities   *float64  `json:"totalLiabilities,omitempty"`
+	StockholdersEquity *float64  `json:"stockholdersEquity,omitempty"`
+	Cash               *float64  `json:"cash,omitempty"`
+	LongTermDebt       *float64  `json:"longTermDebt,omitempty"`
+	SharesOutstanding  *float64  `json:"sharesOutstanding,omitempty"`
+	OperatingCashFlow  *float64  `json:"operatingCashFlow,omitempty"`
+	CapitalExpenditure *float64  `json:"capitalExpenditure,omitempty"`
+}
+
+// FundamentalRatios are trailing-twelve-month figures over the latest four
+// quarters plus balance sheet ratios at the latest quarter end. A ratio is
+// nil when a required line is missing.
+type FundamentalRatios struct {
+	AsOf            time.Time `json:"asOf"`
+	RevenueTTM      *float64  `json:"revenueTTM,omitempty"`
+	NetIncomeTTM    *float64  `json:"netIncomeTTM,omitempty"`
+	EPSTTM          *float64  `json:"epsTTM,omitempty"`
+	FreeCashFlowTTM *float64  `json:"freeCashFlowTTM,omitempty"`
+	GrossMargin     *float64  `json:"grossMargin,omitempty"`
+	OperatingMargin *float64  `json:"operatingMargin,omitempty"`
+	NetMargin       *float64  `json:"netMargin,omitempty"`
+	ReturnOnEquity  *float64  `json:"returnOnEquity,omitempty"`
+	DebtToEquity    *float64  `json:"debtToEquity,omitempty"`
+	RevenueGrowth   *float64  `json:"revenueGrowth,omitempty"`
+}
+
+// Get returns imported fundamentals for symbol, or nil when none exist.
+func (s *FundamentalsService) Get(ctx context.Context, symbol string) (*CompanyFundamentals, error) {
+	profile, err := s.queries.GetCompanyProfile(ctx, symbol)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListFundamentals(ctx, database.ListFundamentalsParams{
+		Symbol: symbol,
+		Limit:  fundamentalsHistory,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &CompanyFundamentals{
+		Symbol:   profile.Symbol,
+		CIK:      profile.Cik,
+		Name:     profile.Name,
+		Sector:   profile.Sector.String,
+		Industry: profile.Industry.String,
+		Quarters: make([]FundamentalPeriod, 0, len(rows)),
+	}
+	for _, row := range rows {
+		out.Quarters = append(out.Quarters, FundamentalPeriod{
+			PeriodEnd:          row.PeriodEnd.UTC(),
+			FiscalYear:         int(row.FiscalYear),
+			FiscalPeriod:       row.FiscalPeriod,
+			Form:               row.Form,
+			FiledAt:            row.FiledAt.UTC(),
+			Revenue:            nullFloat(row.Revenue),
+			GrossProfit:        nullFloat(row.GrossProfit),
+			OperatingIncome:    nullFloat(row.OperatingIncome),
+			NetIncome:          nullFloat(row.NetIncome),
+			EPSDiluted:         nullFloat(row.EpsDiluted),
+			TotalAssets:        nullFloat(row.TotalAssets),
+			TotalLiabilities:   nullFloat(row.TotalLiabilities),
+			StockholdersEquity: nullFloat(row.StockholdersEquity),
+			Cash:               nullFloat(row.Cash),
+			LongTermDebt:       nullFloat(row.LongTermDebt),
+			SharesOutstanding:  nullFloat(row.SharesOutstanding),
+			OperatingCashFlow:  nullFloat(row.OperatingCashFlow),
+			CapitalExpenditure: nullFloat(row.CapitalExpenditures),
+		})
+	}
+	out.Ratios = computeRatios(out.Quarters)
+
+	return out, nil
+}
+
+// Apply fills quote fundamentals the quote provider left empty from filings.
+func (s *FundamentalsService) Apply(ctx context.Context, quote *StockQuote) {
+	f, err := s.Get(ctx, quote.Symbol)
+	if err != nil {
+		s.log.Warn("load fundamentals failed", slog.String("symbol", quote.Symbol), slog.Any("err", err))
+		return
+	}
+	if f == nil {
+		return
+	}
+
+	if quote.Name == "" {
+		quote.Name = f.Name
+	}
+	if quote.Sector == "" {
+		quote.Sector = f.Sector
+	}
+	if quote.Industry == "" {
+		quote.Industry = f.Industry
+	}
+	if quote.EPS == 0 && f.Ratios.EPSTTM != nil {
+		quote.EPS = *f.Ratios.EPSTTM
+	}
+	if quote.PE == 0 && quote.EPS > 0 && quote.Price > 0 {
+		quote.PE = quote.Price / quote.EPS
+	}
+	if quote.MarketCap == 0 && quote.Price > 0 && len(f.Quarters) > 0 && f.Quarters[0].SharesOutstanding != nil {
+		quote.MarketCap = int64(quote.Price * *f.Quarters[0].SharesOutstanding)
+	}
+}
+
+// computeRatios expects quarters newest first.
+func computeRatios(quarters []FundamentalPeriod) FundamentalRatios {
+	var r FundamentalRatios
+	if len(quarters) == 0 {
+		return r
+	}
+	latest := quarters[0]
+	r.AsOf = latest.PeriodEnd
+
+	ttm := trailingYear(quarters)
+	if ttm != nil {
+		r.RevenueTTM = sumLine(ttm, func(q FundamentalPeriod) *float64 { return q.Revenue })
+		r.NetIncomeTTM = sumLine(ttm, func(q FundamentalPeriod) *float64 { return q.NetIncome })
+		r.EPSTTM = sumLine(ttm, func(q FundamentalPeriod) *float64 { return q.EPSDiluted })
+		if r.EPSTTM == nil {
+			// Fourth-quarter EPS is only filed for the full year, so fall
+			// back to earnings over the latest share count.
+			r.EPSTTM = ratio(r.NetIncomeTTM, latest.SharesOutstanding)
+		}
+		ocf := sumLine(ttm, func(q FundamentalPeriod) *float64 { return q.OperatingCashFlow })
+		capex := sumLine(ttm, func(q FundamentalPeriod) *float64 { return q.CapitalExpenditure })
+		if ocf != nil && capex != nil {
+			r.FreeCashFlowTTM = ptr(*ocf - *capex)
+		}
+		gross := sumLine(ttm, func(q FundamentalPeriod) *float64 { return q.GrossProfit })
+		operating := sumLine(ttm, func(q FundamentalPeriod) *float64 { return q.OperatingIncome })
+		r.GrossMargin = ratio(gross, r.RevenueTTM)
+		r.OperatingMargin = ratio(operating, r.RevenueTTM)
+		r.NetMargin = ratio(r.NetIncomeTTM, r.RevenueTTM)
+		r.ReturnOnEquity = ratio(r.NetIncomeTTM, latest.StockholdersEquity)
+
+		if prior := trailingYear(quarters[4:]); prior != nil {
+			priorRevenue := sumLine(prior, func(q FundamentalPeriod) *float64 { return q.Revenue })
+			if growth := ratio(r.RevenueTTM, priorRevenue); growth != nil {
+				r.RevenueGrowth = ptr(*growth - 1)
+			}
+		}
+	}
+
+	r.DebtToEquity = ratio(latest.LongTermDebt, latest.StockholdersEquity)
+	return r
+}
+
+// trailingYear returns the first four quarters when they are consecutive.
+func trailingYear(quarters []FundamentalPeriod) []FundamentalPeriod {
+	if len(quarters) < 4 {
+		return nil
+	}
+	window := quarters[:4]
+	span := window[0].PeriodEnd.Sub(window[3].PeriodEnd)
+	// Three quarter gaps span roughly 273 days; allow for 52/53-week years.
+	if span < 260*24*time.Hour || span > 290*24*time.Hour {
+		return nil
+	}
+	return window
+}
+
+func sumLine(quarters []FundamentalPeriod, line func(FundamentalPeriod) *float64) *float64 {
+	var total float64
+	for _, q := range quarters {
+		v := line(q)
+		if v == nil {
+			return nil
+		}
+		total += *v
+	}
+	return &total
+}
+
+func ratio(num, den *float64) *float64 {
+	if num == nil || den == nil || *den == 0 {
+		return nil
+	}
+	return ptr(*num / *den)
+}
+
+func nullFloat(v sql.NullFloat64) *float64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Float64
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,317 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/database"
+)
+
+// USD is the currency FX rates are stored against and quotes default to.
+const USD = "USD"
+
+// ErrNoFXRate is returned when no stored rate converts a currency on a date.
+var ErrNoFXRate = errors.New("no fx rate stored")
+
+// fxMaxAge is how far back a conversion looks for the latest daily rate,
+// enough to cover long weekends and holidays without using a stale rate.
+const fxMaxAge = 7 * 24 * time.Hour
+
+// minorUnits are listing currencies quoted in hundredths of another, as
+// London prices in pence (GBp).
+var minorUnits = map[string]string{
+	"GBp": "GBP",
+	"GBX": "GBP",
+	"ZAc": "ZAR",
+	"ILA": "ILS",
+}
+
+// NormalizeCurrency upper-cases a three-letter currency code, keeping the
+// minor-unit spellings vendors use such as GBp; ok is false for anything else.
+func NormalizeCurrency(code string) (string, bool) {
+	code = strings.TrimSpace(code)
+	if _, ok := minorUnits[code]; ok {
+		return code, true
+	}
+	if len(code) != 3 {
+		return "", false
+	}
+	for _, r := range code {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return "", false
+		}
+	}
+	return strings.ToUpper(code), true
+}
+
+// FXRate is units of Currency per US dollar on Date.
+type FXRate struct {
+	Currency string    `json:"currency"`
+	Date     time.Time `json:"date"`
+	Rate     float64   `json:"rate"`
+}
+
+// FXService stores daily exchange rates and converts quotes, bars and
+// returns between currencies.
+type FXService struct {
+	log     *slog.Logger
+	queries *database.Queries
+}
+
+func NewFXService(log *slog.Logger, queries *database.Queries) *FXService {
+	return &FXService{log: log, queries: queries}
+}
+
+// StoreRates upserts daily rates, keyed by currency and date.
+func (s *FXService) StoreRates(ctx context.Context, source string, rates []FXRate) error {
+	for _, r := range rates {
+		err := s.queries.UpsertFXRate(ctx, database.UpsertFXRateParams{
+			Currency: r.Currency,
+			RateDate: normalizeBarTime("1d", r.Date),
+			Rate:     r.Rate,
+			Source:   source,
+		})
+		if err != nil {
+			return fmt.Errorf("store %s rate on %s: %w", r.Currency, r.Date.Format("2006-01-02"), err)
+		}
+	}
+	return nil
+}
+
+// Latest returns each currency's most recent rate on or before asOf,
+// starting with the dollar itself.
+func (s *FXService) Latest(ctx context.Context, asOf time.Time) ([]FXRate, error) {
+	rows, err := s.queries.ListLatestFXRates(ctx, asOf.UTC())
+	if err != nil {
+		return nil, err
+	}
+	out := []FXRate{{Currency: USD, Date: normalizeBarTime("1d", asOf), Rate: 1}}
+	for _, row := range rows {
+		if row.Currency == USD {
+			continue
+		}
+		out = append(out, FXRate{Currency: row.Currency, Date: row.RateDate.UTC(), Rate: row.Rate})
+	}
+	return out, nil
+}
+
+// Currencies lists the currencies pages can convert to right now: the
+// dollar and every currency with a rate in the last week.
+func (s *FXService) Currencies(ctx context.Context) ([]string, error) {
+	now := time.Now()
+	rates, err := s.Latest(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, 0, len(rates))
+	for _, r := range rates {
+		if now.Sub(r.Date) <= fxMaxAge {
+			out = append(out, r.Currency)
+		}
+	}
+	return out, nil
+}
+
+// Rate is what one unit of from is worth in to on a date, using the latest
+// daily rates no more than a week older.
+func (s *FXService) Rate(ctx context.Context, from, to string, on time.Time) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+	fromRate, err := s.usdRate(ctx, from, on)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := s.usdRate(ctx, to, on)
+	if err != nil {
+		return 0, err
+	}
+	return toRate / fromRate, nil
+}
+
+// usdRate is units of currency per dollar on a date.
+func (s *FXService) usdRate(ctx context.Context, currency string, on time.Time) (float64, error) {
+	scale := 1.0
+	if major, ok := minorUnits[currency]; ok {
+		currency, scale = major, 100
+	}
+	if currency == USD {
+		return scale, nil
+	}
+
+	row, err := s.queries.GetFXRateOn(ctx, database.GetFXRateOnParams{Currency: currency, AsOf: on.UTC()})
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && on.Sub(row.RateDate) > fxMaxAge) {
+		return 0, fmt.Errorf("%w for %s on %s", ErrNoFXRate, currency, on.Format("2006-01-02"))
+	}
+	if err != nil {
+		return 0, err
+	}
+	return row.Rate * scale, nil
+}
+
+// ConvertQuote returns a copy of quote with prices, per-share figures and
+// market cap in currency to, all at the latest daily rate. Percent changes
+// and ratios are unchanged.
+func (s *FXService) ConvertQuote(ctx context.Context, quote *StockQuote, to string) (*StockQuote, error) {
+	from := quote.Currency
+	if from == "" {
+		from = USD
+	}
+	if from == to {
+		return quote, nil
+	}
+	at := quote.UpdatedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+	rate, err := s.Rate(ctx, from, to, at)
+	if err != nil {
+		return nil, err
+	}
+
+	q := *quote
+	q.Currency = to
+	for _, v := range []*float64{&q.Price, &q.Change, &q.Open, &q.High, &q.Low, &q.PrevClose, &q.Week52High, &q.Week52Low, &q.Dividend, &q.EPS} {
+		*v *= rate
+	}
+	q.MarketCap = int64(math.Round(float64(q.MarketCap) * rate))
+	return &q, nil
+}
+
+// ConvertBars converts each bar at the rate of its own day. Every bar must
+// have a rate, so a chart is either fully converted or left as it was.
+func (s *FXService) ConvertBars(ctx context.Context, bars []HistoricalData, from, to string) ([]HistoricalData, error) {
+	if from == to || len(bars) == 0 {
+		return bars, nil
+	}
+	start, end := bars[0].Timestamp, bars[len(bars)-1].Timestamp
+	fromRates, err := s.series(ctx, from, start, end)
+	if err != nil {
+		return nil, err
+	}
+	toRates, err := s.series(ctx, to, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]HistoricalData, len(bars))
+	for i, bar := range bars {
+		fromRate, ok := fromRates.at(bar.Timestamp)
+		if !ok {
+			return nil, fmt.Errorf("%w for %s on %s", ErrNoFXRate, from, bar.Timestamp.Format("2006-01-02"))
+		}
+		toRate, ok := toRates.at(bar.Timestamp)
+		if !ok {
+			return nil, fmt.Errorf("%w for %s on %s", ErrNoFXRate, to, bar.Timestamp.Format("2006-01-02"))
+		}
+		rate := toRate / fromRate
+		bar.Open *= rate
+		bar.High *= rate
+		bar.Low *= rate
+		bar.Close *= rate
+		out[i] = bar
+	}
+	return out, nil
+}
+
+// ConvertReturn restates a percent return earned in from over [start, end]
+// as the return in to, adding the exchange rate's move over the window.
+func (s *FXService) ConvertReturn(ctx context.Context, pct float64, from, to string, start, end time.Time) (float64, error) {
+	if from == to {
+		return pct, nil
+	}
+	startRate, err := s.Rate(ctx, from, to, start)
+	if err != nil {
+		return 0, err
+	}
+	endRate, err := s.Rate(ctx, from, to, end)
+	if err != nil {
+		return 0, err
+	}
+	return ((1+pct/100)*endRate/startRate - 1) * 100, nil
+}
+
+// ConvertSnapshot returns a copy of snapshot with its 30-day, 90-day and
+// 1-year returns restated in currency to, starting from the dollar returns
+// when stored. Benchmark comparisons stay in dollars.
+func (s *FXService) ConvertSnapshot(ctx context.Context, snapshot *StockSnapshot, to string) (*StockSnapshot, error) {
+	from := snapshot.Currency
+	if from == "" {
+		from = USD
+	}
+	if from == to {
+		return snapshot, nil
+	}
+
+	out := *snapshot
+	out.Currency = to
+	changes := []*float64{&out.Change30, &out.Change90, &out.Change365}
+	dollars := []*float64{snapshot.USDChange30, snapshot.USDChange90, snapshot.USDChange365}
+	for i, days := range []int{30, 90, 365} {
+		change, source := *changes[i], from
+		if dollars[i] != nil {
+			change, source = *dollars[i], USD
+		}
+		end := snapshot.UpdatedAt
+		converted, err := s.ConvertReturn(ctx, change, source, to, end.AddDate(0, 0, -days), end)
+		if err != nil {
+			return nil, err
+		}
+		*changes[i] = converted
+	}
+	return &out, nil
+}
+
+// fxSeries is one currency's daily rates per dollar over a window.
+type fxSeries struct {
+	scale float64
+	// rates is nil for the dollar, whose rate is always scale.
+	rates []database.FxRate
+}
+
+func (s *FXService) series(ctx context.Context, currency string, from, to time.Time) (fxSeries, error) {
+	out := fxSeries{scale: 1}
+	if major, ok := minorUnits[currency]; ok {
+		currency, out.scale = major, 100
+	}
+	if currency == USD {
+		return out, nil
+	}
+	rows, err := s.queries.ListFXRates(ctx, database.ListFXRatesParams{
+		Currency: currency,
+		FromTime: from.Add(-fxMaxAge).UTC(),
+		ToTime:   to.UTC(),
+	})
+	if err != nil {
+		return out, err
+	}
+	if len(rows) == 0 {
+		return out, fmt.Errorf("%w for %s", ErrNoFXRate, currency)
+	}
+	out.rates = rows
+	return out, nil
+}
+
+// at is the latest rate on or before t, no more than fxMaxAge older.
+func (f fxSeries) at(t time.Time) (float64, bool) {
+	if f.rates == nil {
+		return f.scale, true
+	}
+	i, found := slices.BinarySearchFunc(f.rates, t, func(r database.FxRate, t time.Time) int {
+		return r.RateDate.Compare(t)
+	})
+	if !found {
+		i--
+	}
+	if i < 0 || t.Sub(f.rates[i].RateDate) > fxMaxAge {
+		return 0, false
+	}
+	return f.rates[i].Rate * f.scale, true
+}
@@ -2,36 +2,59 @@ package services
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"net/http"
+	"log/slog"
 	"sync"
 	"time"
+
+	"github.com/loganlanou/Financing-101/internal/database"
+	"github.com/loganlanou/Financing-101/internal/marketcalendar"
+	"golang.org/x/sync/errgroup"
 )
 
 // MarketDataService aggregates data from multiple financial APIs
 type MarketDataService struct {
-	httpClient     *http.Client
-	alphaVantageKey string
-	finnhubKey      string
-	cache           *MarketCache
+	log       *slog.Logger
+	queries   *database.Queries
+	providers []QuoteProvider
+	cache     *MarketCache
+	bars      *BarBuilder
+	// maxConcurrent bounds in-flight vendor lookups for batch requests.
+	maxConcurrent int
 }
 
+var errNoProviders = errors.New("no quote providers configured")
+
 // MarketCache provides thread-safe caching for market data
 type MarketCache struct {
 	mu        sync.RWMutex
 	quotes    map[string]*CachedQuote
+	overviews map[string]*cachedOverview
 	indices   []IndexQuote
 	indexTime time.Time
 }
 
+// cachedOverview remembers fundamentals for a day; a nil Data records a
+// lookup that failed so it is not retried on every quote.
+type cachedOverview struct {
+	Data      *CompanyOverview
+	ExpiresAt time.Time
+}
+
+const (
+	overviewTTL         = 24 * time.Hour
+	overviewNegativeTTL = time.Hour
+)
+
 // CachedQuote holds cached stock data with expiration
 type CachedQuote struct {
 	Data      *StockQuote
 	ExpiresAt time.Time
 }
 
-// StockQuote represents real-time stock data
+// StockQuote represents real-time stock data. Prices are in Currency, an
+// ISO code except for the minor units some listings use (GBp for pence).
 type StockQuote struct {
 	Symbol        string    `json:"symbol"`
 	Name          string    `json:"name"`
@@ -55,120 +78,332 @@ type StockQuote struct {
 	Sector        string    `json:"sector"`
 	Industry      string    `json:"industry"`
 	Exchange      string    `json:"exchange"`
+	Currency      string    `json:"currency"`
+	Source        string    `json:"source"`
 	UpdatedAt     time.Time `json:"updatedAt"`
+	// Stale marks a quote served from an expired cache entry after every
+	// provider failed, or sample data from the demo provider
+	Stale bool `json:"stale"`
 }
 
 // IndexQuote represents a market index
 type IndexQuote struct {
-	Symbol        string  `json:"symbol"`
-	Name          string  `json:"name"`
-	Price         float64 `json:"price"`
-	Change        float64 `json:"change"`
-	ChangePercent float64 `json:"changePercent"`
+	Symbol        string    `json:"symbol"`
+	Name          string    `json:"name"`
+	Price         float64   `json:"price"`
+	Change        float64   `json:"change"`
+	ChangePercent float64   `json:"changePercent"`
+	Currency      string    `json:"currency"`
+	Source        string    `json:"source"`
+	UpdatedAt     time.Time `json:"updatedAt"`
 }
 
-// MarketOverview contains summary market data
+// MarketOverview contains summary market data; movers come from MoversService
 type MarketOverview struct {
-	Indices       []IndexQuote     `json:"indices"`
-	TopGainers    []StockQuote     `json:"topGainers"`
-	TopLosers     []StockQuote     `json:"topLosers"`
-	MostActive    []StockQuote     `json:"mostActive"`
-	SectorPerf    []SectorPerf     `json:"sectorPerf"`
-	MarketStatus  string           `json:"marketStatus"`
-	LastUpdated   time.Time        `json:"lastUpdated"`
+	Indices      []IndexQuote `json:"indices"`
+	SectorPerf   []SectorPerf `json:"sectorPerf"`
+	MarketStatus string       `json:"marketStatus"`
+	LastUpdated  time.Time    `json:"lastUpdated"`
 }
 
 // SectorPerf represents sector performance
 type SectorPerf struct {
+	Symbol        string  `json:"symbol"`
 	Sector        string  `json:"sector"`
 	ChangePercent float64 `json:"changePercent"`
 }
 
 // HistoricalData represents price history
 type HistoricalData struct {
-	Date   string  `json:"date"`
-	Open   float64 `json:"open"`
-	High   float64 `json:"high"`
-	Low    float64 `json:"low"`
-	Close  float64 `json:"close"`
-	Volume int64   `json:"volume"`
+	Date      string    `json:"date"`
+	Timestamp time.Time `json:"timestamp"`
+	Open      float64   `json:"open"`
+	High      float64   `json:"high"`
+	Low       float64   `json:"low"`
+	Close     float64   `json:"close"`
+	Volume    int64     `json:"volume"`
 }
 
-// NewMarketDataService creates a new market data service
-func NewMarketDataService(alphaVantageKey, finnhubKey string) *MarketDataService {
-	return &MarketDataService{
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		alphaVantageKey: alphaVantageKey,
-		finnhubKey:      finnhubKey,
+// indexSymbols lists the benchmarks shown in overviews, in display order
+var indexSymbols = []string{"^GSPC", "^DJI", "^IXIC", "^RUT", "^VIX"}
+
+var indexNames = map[string]string{
+	"^GSPC": "S&P 500",
+	"^DJI":  "Dow Jones",
+	"^IXIC": "NASDAQ",
+	"^RUT":  "Russell 2000",
+	"^VIX":  "VIX",
+}
+
+// historyWindows maps a chart period to its bar interval and lookback
+var historyWindows = map[string]struct {
+	interval string
+	lookback time.Duration
+}{
+	"1D": {"5m", 24 * time.Hour},
+	"5D": {"15m", 5 * 24 * time.Hour},
+	"1M": {"1h", 31 * 24 * time.Hour},
+	"3M": {"1d", 92 * 24 * time.Hour},
+	"6M": {"1d", 183 * 24 * time.Hour},
+	"1Y": {"1d", 366 * 24 * time.Hour},
+	"5Y": {"1wk", 5 * 366 * 24 * time.Hour},
+}
+
+// NewMarketDataService creates a market data service that queries providers in order.
+// queries may be nil, in which case history is never persisted.
+func NewMarketDataService(log *slog.Logger, queries *database.Queries, providers []QuoteProvider, maxConcurrent int) *MarketDataService {
+	if maxConcurrent <= 0 {
+		maxConcurrent = 1
+	}
+	s := &MarketDataService{
+		log:           log,
+		queries:       queries,
+		providers:     providers,
+		maxConcurrent: maxConcurrent,
 		cache: &MarketCache{
-			quotes: make(map[string]*CachedQuote),
+			quotes:    make(map[string]*CachedQuote),
+			overviews: make(map[string]*cachedOverview),
 		},
 	}
+	s.bars = newBarBuilder(log, s.StoreHistory)
+	return s
+}
+
+// Bars is the builder fed with every fresh quote; run it to store the bars
+// it closes.
+func (s *MarketDataService) Bars() *BarBuilder {
+	return s.bars
 }
 
+// ProviderHealth reports rate limit and circuit breaker state per provider
+func (s *MarketDataService) ProviderHealth() []ProviderHealth {
+	out := make([]ProviderHealth, 0, len(s.providers))
+	for _, provider := range s.providers {
+		if guarded, ok := provider.(*guardedProvider); ok {
+			out = append(out, guarded.health())
+			continue
+		}
+		out = append(out, ProviderHealth{Name: provider.Name(), State: CircuitClosed})
+	}
+	return out
+}
+
+// quoteCacheTTL is how long a fetched quote is served from cache.
+const quoteCacheTTL = time.Minute
+
 // GetQuote fetches a stock quote, using cache when available
 func (s *MarketDataService) GetQuote(ctx context.Context, symbol string) (*StockQuote, error) {
+	return s.getQuote(ctx, symbol, quoteCacheTTL)
+}
+
+// LastQuote returns the most recent quote fetched for symbol, however old,
+// without asking the providers.
+func (s *MarketDataService) LastQuote(symbol string) (*StockQuote, bool) {
+	s.cache.mu.RLock()
+	defer s.cache.mu.RUnlock()
+	cached, ok := s.cache.quotes[symbol]
+	if !ok {
+		return nil, false
+	}
+	return cached.Data, true
+}
+
+// getQuote serves a cached quote fetched less than maxAge ago, else asks
+// the providers.
+func (s *MarketDataService) getQuote(ctx context.Context, symbol string, maxAge time.Duration) (*StockQuote, error) {
 	// Check cache first
 	s.cache.mu.RLock()
-	if cached, ok := s.cache.quotes[symbol]; ok && time.Now().Before(cached.ExpiresAt) {
+	if cached, ok := s.cache.quotes[symbol]; ok && time.Now().Before(cached.ExpiresAt.Add(min(maxAge, quoteCacheTTL)-quoteCacheTTL)) {
 		s.cache.mu.RUnlock()
 		return cached.Data, nil
 	}
 	s.cache.mu.RUnlock()
 
-	// Try Finnhub first (faster, more requests)
-	quote, err := s.fetchFinnhubQuote(ctx, symbol)
-	if err != nil {
-		// Fallback to Alpha Vantage
-		quote, err = s.fetchAlphaVantageQuote(ctx, symbol)
-		if err != nil {
-			// Final fallback to Yahoo Finance
-			quote, err = s.fetchYahooQuote(ctx, symbol)
-			if err != nil {
-				return nil, fmt.Errorf("all data sources failed for %s: %w", symbol, err)
+	var quote *StockQuote
+	err := errNoProviders
+	for _, provider := range s.providers {
+		quote, err = provider.Quote(ctx, symbol)
+		if err == nil {
+			if quote.Source == "" {
+				quote.Source = provider.Name()
 			}
+			break
 		}
 	}
+	if err != nil {
+		// Serve the last known quote rather than nothing; UpdatedAt shows its age
+		s.cache.mu.RLock()
+		cached, ok := s.cache.quotes[symbol]
+		s.cache.mu.RUnlock()
+		if ok {
+			stale := *cached.Data
+			stale.Stale = true
+			return &stale, nil
+		}
+		return nil, fmt.Errorf("all data sources failed for %s: %w", symbol, err)
+	}
+
+	s.bars.Observe(symbol, quote)
+
+	// Coins have no company fundamentals to look up.
+	if !IsCrypto(symbol) && (quote.MarketCap == 0 || quote.Sector == "" || quote.Currency == "") {
+		if overview := s.getOverview(ctx, symbol); overview != nil {
+			applyOverview(quote, overview)
+		}
+	}
+	if quote.Currency == "" {
+		quote.Currency = USD
+	}
 
 	// Cache the result for 1 minute
 	s.cache.mu.Lock()
 	s.cache.quotes[symbol] = &CachedQuote{
 		Data:      quote,
-		ExpiresAt: time.Now().Add(1 * time.Minute),
+		ExpiresAt: time.Now().Add(quoteCacheTTL),
 	}
 	s.cache.mu.Unlock()
 
 	return quote, nil
 }
 
-// GetMultipleQuotes fetches quotes for multiple symbols concurrently
-func (s *MarketDataService) GetMultipleQuotes(ctx context.Context, symbols []string) (map[string]*StockQuote, error) {
-	results := make(map[string]*StockQuote)
+// getOverview returns cached fundamentals for symbol, asking each provider
+// that publishes them on a miss. Rate-limited lookups are not remembered so
+// they are retried once the budget refills.
+func (s *MarketDataService) getOverview(ctx context.Context, symbol string) *CompanyOverview {
+	s.cache.mu.RLock()
+	cached, ok := s.cache.overviews[symbol]
+	s.cache.mu.RUnlock()
+	if ok && time.Now().Before(cached.ExpiresAt) {
+		return cached.Data
+	}
+
+	var overview *CompanyOverview
+	var err error
+	var asked bool
+	for _, provider := range s.providers {
+		source, ok := provider.(OverviewProvider)
+		if !ok {
+			continue
+		}
+		overview, err = source.Overview(ctx, symbol)
+		if errors.Is(err, ErrNotSupported) {
+			continue
+		}
+		asked = true
+		if err == nil {
+			break
+		}
+	}
+	if !asked {
+		return nil
+	}
+
+	ttl := overviewTTL
+	if err != nil {
+		if errors.Is(err, ErrRateLimited) || errors.Is(err, ErrCircuitOpen) || ctx.Err() != nil {
+			return nil
+		}
+		s.log.Debug("company overview unavailable", slog.String("symbol", symbol), slog.Any("err", err))
+		overview, ttl = nil, overviewNegativeTTL
+	}
+
+	s.cache.mu.Lock()
+	s.cache.overviews[symbol] = &cachedOverview{Data: overview, ExpiresAt: time.Now().Add(ttl)}
+	s.cache.mu.Unlock()
+
+	return overview
+}
+
+// applyOverview fills fundamentals the quote provider left empty.
+func applyOverview(quote *StockQuote, overview *CompanyOverview) {
+	if quote.Name == "" {
+		quote.Name = overview.Name
+	}
+	if quote.Exchange == "" {
+		quote.Exchange = overview.Exchange
+	}
+	if quote.Currency == "" {
+		quote.Currency = overview.Currency
+	}
+	if quote.Sector == "" {
+		quote.Sector = overview.Sector
+	}
+	if quote.Industry == "" {
+		quote.Industry = overview.Industry
+	}
+	if quote.MarketCap == 0 {
+		quote.MarketCap = overview.MarketCap
+	}
+	if quote.PE == 0 {
+		quote.PE = overview.PE
+	}
+	if quote.EPS == 0 {
+		quote.EPS = overview.EPS
+	}
+	if quote.Beta == 0 {
+		quote.Beta = overview.Beta
+	}
+	if quote.Dividend == 0 {
+		quote.Dividend = overview.Dividend
+	}
+	if quote.DividendYield == 0 {
+		quote.DividendYield = overview.DividendYield
+	}
+	if quote.Week52High == 0 {
+		quote.Week52High = overview.Week52High
+	}
+	if quote.Week52Low == 0 {
+		quote.Week52Low = overview.Week52Low
+	}
+}
+
+// GetMultipleQuotes fetches quotes for multiple symbols, at most
+// maxConcurrent at a time so a batch cannot burst through vendor quotas.
+// Per-symbol failures are recorded in the batch; the error is only set
+// when no symbol could be quoted.
+func (s *MarketDataService) GetMultipleQuotes(ctx context.Context, symbols []string) (*QuoteBatch, error) {
+	return s.getMultipleQuotes(ctx, symbols, quoteCacheTTL)
+}
+
+// GetFreshQuotes is GetMultipleQuotes for pollers that need quotes no
+// older than maxAge, shorter than the usual one-minute cache.
+func (s *MarketDataService) GetFreshQuotes(ctx context.Context, symbols []string, maxAge time.Duration) (*QuoteBatch, error) {
+	return s.getMultipleQuotes(ctx, symbols, maxAge)
+}
+
+func (s *MarketDataService) getMultipleQuotes(ctx context.Context, symbols []string, maxAge time.Duration) (*QuoteBatch, error) {
+	batch := &QuoteBatch{
+		Symbols: symbols,
+		Quotes:  make(map[string]*StockQuote, len(symbols)),
+		Errors:  make(map[string]error),
+	}
 	var mu sync.Mutex
-	var wg sync.WaitGroup
-	errChan := make(chan error, len(symbols))
 
+	var g errgroup.Group
+	g.SetLimit(s.maxConcurrent)
 	for _, sym := range symbols {
-		wg.Add(1)
-		go func(symbol string) {
-			defer wg.Done()
-			quote, err := s.GetQuote(ctx, symbol)
+		g.Go(func() error {
+			quote, err := s.getQuote(ctx, sym, maxAge)
+			mu.Lock()
+			defer mu.Unlock()
 			if err != nil {
-				errChan <- err
-				return
+				batch.Errors[sym] = err
+				return nil
 			}
-			mu.Lock()
-			results[symbol] = quote
-			mu.Unlock()
-		}(sym)
+			batch.Quotes[sym] = quote
+			return nil
+		})
 	}
+	_ = g.Wait()
 
-	wg.Wait()
-	close(errChan)
+	if unavailable := batch.Unavailable(); len(unavailable) > 0 {
+		s.log.Warn("quotes unavailable",
+			slog.Int("requested", len(symbols)),
+			slog.Any("symbols", unavailable),
+		)
+	}
 
-	return results, nil
+	return batch, batch.Err()
 }
 
 // GetMarketOverview returns summary market data
@@ -183,14 +418,6 @@ func (s *MarketDataService) GetMarketOverview(ctx context.Context) (*MarketOverv
 		overview.Indices = indices
 	}
 
-	// Fetch movers
-	gainers, losers, active, err := s.GetMarketMovers(ctx)
-	if err == nil {
-		overview.TopGainers = gainers
-		overview.TopLosers = losers
-		overview.MostActive = active
-	}
-
 	// Fetch sector performance
 	sectors, err := s.GetSectorPerformance(ctx)
 	if err == nil {
@@ -214,28 +441,33 @@ func (s *MarketDataService) GetIndices(ctx context.Context) ([]IndexQuote, error
 	}
 	s.cache.mu.RUnlock()
 
-	indexSymbols := []string{"^GSPC", "^DJI", "^IXIC", "^RUT", "^VIX"}
-	indexNames := map[string]string{
-		"^GSPC": "S&P 500",
-		"^DJI":  "Dow Jones",
-		"^IXIC": "NASDAQ",
-		"^RUT":  "Russell 2000",
-		"^VIX":  "VIX",
-	}
-
 	var indices []IndexQuote
-	for _, symbol := range indexSymbols {
-		quote, err := s.fetchYahooQuote(ctx, symbol)
-		if err != nil {
-			continue
+	err := errNoProviders
+	for _, provider := range s.providers {
+		indices, err = provider.Indices(ctx, indexSymbols)
+		if err == nil && len(indices) > 0 {
+			for i := range indices {
+				if indices[i].Source == "" {
+					indices[i].Source = provider.Name()
+				}
+				if indices[i].UpdatedAt.IsZero() {
+					indices[i].UpdatedAt = time.Now()
+				}
+				if indices[i].Currency == "" {
+					indices[i].Currency = USD
+				}
+			}
+			break
 		}
-		indices = append(indices, IndexQuote{
-			Symbol:        symbol,
-			Name:          indexNames[symbol],
-			Price:         quote.Price,
-			Change:        quote.Change,
-			ChangePercent: quote.ChangePercent,
-		})
+	}
+	if err != nil {
+		s.cache.mu.RLock()
+		stale := s.cache.indices
+		s.cache.mu.RUnlock()
+		if len(stale) > 0 {
+			return stale, nil
+		}
+		return nil, fmt.Errorf("index data unavailable: %w", err)
 	}
 
 	// Cache indices
@@ -247,61 +479,28 @@ func (s *MarketDataService) GetIndices(ctx context.Context) ([]IndexQuote, error
 	return indices, nil
 }
 
-// GetMarketMovers returns top gainers, losers, and most active stocks
-func (s *MarketDataService) GetMarketMovers(ctx context.Context) (gainers, losers, active []StockQuote, err error) {
-	// This would typically call an API endpoint for market movers
-	// For now, return curated list that can be updated
-	symbols := []string{"NVDA", "AAPL", "MSFT", "GOOGL", "AMZN", "META", "TSLA", "AMD", "NFLX", "JPM"}
-
-	quotes, err := s.GetMultipleQuotes(ctx, symbols)
-	if err != nil {
-		return nil, nil, nil, err
-	}
-
-	var allQuotes []StockQuote
-	for _, q := range quotes {
-		allQuotes = append(allQuotes, *q)
-	}
-
-	// Sort by change percent for gainers/losers
-	// In production, this would come pre-sorted from the API
-	for _, q := range allQuotes {
-		if q.ChangePercent > 0 {
-			gainers = append(gainers, q)
-		} else {
-			losers = append(losers, q)
-		}
+// GetSectorPerformance returns today's change for each sector fund, in
+// SectorETFs order; funds that cannot be quoted are left out
+func (s *MarketDataService) GetSectorPerformance(ctx context.Context) ([]SectorPerf, error) {
+	symbols := make([]string, len(SectorETFs))
+	for i, etf := range SectorETFs {
+		symbols[i] = etf.Symbol
 	}
 
-	// Most active by volume
-	active = allQuotes
-	return gainers, losers, active, nil
-}
-
-// GetSectorPerformance returns sector performance data
-func (s *MarketDataService) GetSectorPerformance(ctx context.Context) ([]SectorPerf, error) {
-	// Sector ETFs to track
-	sectorETFs := map[string]string{
-		"XLK": "Technology",
-		"XLF": "Financials",
-		"XLV": "Healthcare",
-		"XLE": "Energy",
-		"XLY": "Consumer Cyclical",
-		"XLP": "Consumer Defensive",
-		"XLI": "Industrials",
-		"XLB": "Materials",
-		"XLU": "Utilities",
-		"XLRE": "Real Estate",
+	batch, err := s.GetMultipleQuotes(ctx, symbols)
+	if err != nil {
+		return nil, err
 	}
 
 	var sectors []SectorPerf
-	for symbol, name := range sectorETFs {
-		quote, err := s.GetQuote(ctx, symbol)
-		if err != nil {
+	for _, etf := range SectorETFs {
+		quote, ok := batch.Quotes[etf.Symbol]
+		if !ok {
 			continue
 		}
 		sectors = append(sectors, SectorPerf{
-			Sector:        name,
+			Symbol:        etf.Symbol,
+			Sector:        etf.Sector,
 			ChangePercent: quote.ChangePercent,
 		})
 	}
@@ -309,285 +508,60 @@ func (s *MarketDataService) GetSectorPerformance(ctx context.Context) ([]SectorP
 	return sectors, nil
 }
 
-// GetHistoricalData returns historical price data
-func (s *MarketDataService) GetHistoricalData(ctx context.Context, symbol string, period string) ([]HistoricalData, error) {
-	// Would call Alpha Vantage or Yahoo Finance for historical data
-	// Period: 1D, 5D, 1M, 3M, 6M, 1Y, 5Y
-	return s.fetchYahooHistorical(ctx, symbol, period)
-}
-
-// GetMarketStatus returns whether the market is open
-func (s *MarketDataService) GetMarketStatus() string {
-	now := time.Now().In(time.FixedZone("EST", -5*60*60))
-	hour := now.Hour()
-	minute := now.Minute()
-	weekday := now.Weekday()
-
-	// Market hours: 9:30 AM - 4:00 PM EST, Monday-Friday
-	if weekday == time.Saturday || weekday == time.Sunday {
-		return "closed"
-	}
-
-	marketOpen := hour > 9 || (hour == 9 && minute >= 30)
-	marketClose := hour < 16
-
-	if marketOpen && marketClose {
-		return "open"
-	} else if hour >= 4 && (hour < 9 || (hour == 9 && minute < 30)) {
-		return "pre-market"
-	} else if hour >= 16 && hour < 20 {
-		return "after-hours"
-	}
-	return "closed"
-}
-
-// fetchFinnhubQuote fetches quote from Finnhub API
-func (s *MarketDataService) fetchFinnhubQuote(ctx context.Context, symbol string) (*StockQuote, error) {
-	if s.finnhubKey == "" {
-		return nil, fmt.Errorf("finnhub API key not configured")
-	}
-
-	url := fmt.Sprintf("https://finnhub.io/api/v1/quote?symbol=%s&token=%s", symbol, s.finnhubKey)
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var data struct {
-		C  float64 `json:"c"`  // Current price
-		D  float64 `json:"d"`  // Change
-		DP float64 `json:"dp"` // Percent change
-		H  float64 `json:"h"`  // High
-		L  float64 `json:"l"`  // Low
-		O  float64 `json:"o"`  // Open
-		PC float64 `json:"pc"` // Previous close
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, err
-	}
-
-	if data.C == 0 {
-		return nil, fmt.Errorf("no data returned for %s", symbol)
-	}
-
-	return &StockQuote{
-		Symbol:        symbol,
-		Price:         data.C,
-		Change:        data.D,
-		ChangePercent: data.DP,
-		Open:          data.O,
-		High:          data.H,
-		Low:           data.L,
-		PrevClose:     data.PC,
-		UpdatedAt:     time.Now(),
-	}, nil
-}
-
-// fetchAlphaVantageQuote fetches quote from Alpha Vantage API
-func (s *MarketDataService) fetchAlphaVantageQuote(ctx context.Context, symbol string) (*StockQuote, error) {
-	if s.alphaVantageKey == "" {
-		return nil, fmt.Errorf("alpha vantage API key not configured")
-	}
-
-	url := fmt.Sprintf("https://www.alphavantage.co/query?function=GLOBAL_QUOTE&symbol=%s&apikey=%s", symbol, s.alphaVantageKey)
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var data struct {
-		GlobalQuote struct {
-			Symbol        string `json:"01. symbol"`
-			Open          string `json:"02. open"`
-			High          string `json:"03. high"`
-			Low           string `json:"04. low"`
-			Price         string `json:"05. price"`
-			Volume        string `json:"06. volume"`
-			PrevClose     string `json:"08. previous close"`
-			Change        string `json:"09. change"`
-			ChangePercent string `json:"10. change percent"`
-		} `json:"Global Quote"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, err
-	}
-
-	return &StockQuote{
-		Symbol:    symbol,
-		UpdatedAt: time.Now(),
-	}, nil
+// IsHistoryPeriod reports whether GetHistoricalData knows period
+func IsHistoryPeriod(period string) bool {
+	_, ok := historyWindows[period]
+	return ok
 }
 
-// fetchYahooQuote fetches quote from Yahoo Finance (unofficial)
-func (s *MarketDataService) fetchYahooQuote(ctx context.Context, symbol string) (*StockQuote, error) {
-	url := fmt.Sprintf("https://query1.finance.yahoo.com/v8/finance/chart/%s?interval=1d&range=1d", symbol)
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("User-Agent", "Mozilla/5.0")
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var data struct {
-		Chart struct {
-			Result []struct {
-				Meta struct {
-					Symbol             string  `json:"symbol"`
-					RegularMarketPrice float64 `json:"regularMarketPrice"`
-					PreviousClose      float64 `json:"previousClose"`
-					Exchange           string  `json:"exchangeName"`
-				} `json:"meta"`
-				Indicators struct {
-					Quote []struct {
-						Open   []float64 `json:"open"`
-						High   []float64 `json:"high"`
-						Low    []float64 `json:"low"`
-						Close  []float64 `json:"close"`
-						Volume []int64   `json:"volume"`
-					} `json:"quote"`
-				} `json:"indicators"`
-			} `json:"result"`
-			Error interface{} `json:"error"`
-		} `json:"chart"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, err
+// GetHistoricalData returns historical price data, preferring the local price store
+func (s *MarketDataService) GetHistoricalData(ctx context.Context, symbol string, period string) ([]HistoricalData, error) {
+	// Period: 1D, 5D, 1M, 3M, 6M, 1Y, 5Y
+	window, ok := historyWindows[period]
+	if !ok {
+		window = historyWindows["1Y"]
 	}
 
-	if len(data.Chart.Result) == 0 {
-		return nil, fmt.Errorf("no data for symbol %s", symbol)
-	}
+	to := time.Now()
+	from := to.Add(-window.lookback)
 
-	result := data.Chart.Result[0]
-	meta := result.Meta
-	price := meta.RegularMarketPrice
-	prevClose := meta.PreviousClose
-	change := price - prevClose
-	changePercent := 0.0
-	if prevClose > 0 {
-		changePercent = (change / prevClose) * 100
-	}
-
-	var open, high, low float64
-	var volume int64
-	if len(result.Indicators.Quote) > 0 && len(result.Indicators.Quote[0].Open) > 0 {
-		q := result.Indicators.Quote[0]
-		open = q.Open[0]
-		high = q.High[0]
-		low = q.Low[0]
-		if len(q.Volume) > 0 {
-			volume = q.Volume[0]
+	var stored []HistoricalData
+	if isDailyInterval(window.interval) {
+		var err error
+		stored, err = s.StoredHistory(ctx, symbol, window.interval, from, to)
+		if err != nil {
+			s.log.Warn("price store read failed", slog.String("symbol", symbol), slog.Any("err", err))
+		}
+		if coversRange(stored, window.interval, from, to) {
+			return stored, nil
+		}
+	} else if period == "1D" && !IsCrypto(symbol) {
+		// Bars built from polled quotes replace the vendor call once they
+		// cover the session.
+		var covered bool
+		stored, covered = s.sessionHistory(ctx, symbol, window.interval, to)
+		if covered {
+			return stored, nil
 		}
 	}
 
-	return &StockQuote{
-		Symbol:        symbol,
-		Price:         price,
-		Change:        change,
-		ChangePercent: changePercent,
-		Open:          open,
-		High:          high,
-		Low:           low,
-		PrevClose:     prevClose,
-		Volume:        volume,
-		Exchange:      meta.Exchange,
-		UpdatedAt:     time.Now(),
-	}, nil
-}
-
-// fetchYahooHistorical fetches historical data from Yahoo Finance
-func (s *MarketDataService) fetchYahooHistorical(ctx context.Context, symbol string, period string) ([]HistoricalData, error) {
-	intervalMap := map[string]string{
-		"1D":  "5m",
-		"5D":  "15m",
-		"1M":  "1h",
-		"3M":  "1d",
-		"6M":  "1d",
-		"1Y":  "1d",
-		"5Y":  "1wk",
-	}
-
-	interval := intervalMap[period]
-	if interval == "" {
-		interval = "1d"
-	}
-
-	url := fmt.Sprintf("https://query1.finance.yahoo.com/v8/finance/chart/%s?interval=%s&range=%s", symbol, interval, period)
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("User-Agent", "Mozilla/5.0")
-
-	resp, err := s.httpClient.Do(req)
+	history, source, err := s.FetchHistory(ctx, symbol, window.interval, from, to)
 	if err != nil {
+		// Offline: a partial stored series beats an empty chart
+		if len(stored) > 0 {
+			return stored, nil
+		}
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	var data struct {
-		Chart struct {
-			Result []struct {
-				Timestamp  []int64 `json:"timestamp"`
-				Indicators struct {
-					Quote []struct {
-						Open   []float64 `json:"open"`
-						High   []float64 `json:"high"`
-						Low    []float64 `json:"low"`
-						Close  []float64 `json:"close"`
-						Volume []int64   `json:"volume"`
-					} `json:"quote"`
-				} `json:"indicators"`
-			} `json:"result"`
-		} `json:"chart"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, err
-	}
-
-	if len(data.Chart.Result) == 0 {
-		return nil, fmt.Errorf("no historical data for %s", symbol)
-	}
 
-	result := data.Chart.Result[0]
-	var history []HistoricalData
-
-	if len(result.Indicators.Quote) > 0 {
-		q := result.Indicators.Quote[0]
-		for i, ts := range result.Timestamp {
-			if i < len(q.Close) {
-				history = append(history, HistoricalData{
-					Date:   time.Unix(ts, 0).Format("2006-01-02 15:04"),
-					Open:   q.Open[i],
-					High:   q.High[i],
-					Low:    q.Low[i],
-					Close:  q.Close[i],
-					Volume: q.Volume[i],
-				})
-			}
-		}
+	if err := s.StoreHistory(ctx, symbol, window.interval, source, history); err != nil {
+		s.log.Warn("price store write failed", slog.String("symbol", symbol), slog.Any("err", err))
 	}
 
 	return history, nil
 }
+
+// GetMarketStatus returns the exchange session state right now
+func (s *MarketDataService) GetMarketStatus() string {
+	return marketcalendar.Status(time.Now())
+}
@@ -31,6 +31,12 @@ type StockSnapshot struct {
     Conviction   string
     Thesis       string
     UpdatedAt    time.Time
+    // Currency is the listing's currency; Change* are returns in it and
+    // USDChange* the same returns in dollars, nil without FX history.
+    Currency     string
+    USDChange30  *float64
+    USDChange90  *float64
+    USDChange365 *float64
 }
 
 // Trade mirrors congressional disclosure data.
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMoverLimit is how many names each mover list holds by default.
+	DefaultMoverLimit = 10
+	// MaxMoverLimit caps the per-list limit callers may ask for.
+	MaxMoverLimit = 50
+)
+
+// CapBucket is a market capitalization range used to filter movers.
+type CapBucket struct {
+	ID    string
+	Label string
+	// Min is inclusive and Max exclusive; a zero Max is unbounded.
+	Min, Max int64
+}
+
+// CapBuckets are the market cap filters offered for movers, largest first,
+// using the common index-provider cutoffs.
+var CapBuckets = []CapBucket{
+	{ID: "mega", Label: "Mega Cap", Min: 200_000_000_000},
+	{ID: "large", Label: "Large Cap", Min: 10_000_000_000, Max: 200_000_000_000},
+	{ID: "mid", Label: "Mid Cap", Min: 2_000_000_000, Max: 10_000_000_000},
+	{ID: "small", Label: "Small Cap", Min: 300_000_000, Max: 2_000_000_000},
+	{ID: "micro", Label: "Micro Cap", Min: 1, Max: 300_000_000},
+}
+
+// FindCapBucket looks up a bucket by ID.
+func FindCapBucket(id string) (CapBucket, bool) {
+	for _, bucket := range CapBuckets {
+		if bucket.ID == id {
+			return bucket, true
+		}
+	}
+	return CapBucket{}, false
+}
+
+// Contains reports whether marketCap falls in the bucket. Unknown (zero)
+// market caps are in no bucket.
+func (b CapBucket) Contains(marketCap int64) bool {
+	return marketCap > 0 && marketCap >= b.Min && (b.Max == 0 || marketCap < b.Max)
+}
+
+// MoverFilter narrows and sizes the mover lists.
+type MoverFilter struct {
+	// Cap is a CapBuckets ID; empty means every market cap.
+	Cap string
+	// Limit is the length of each list; zero means DefaultMoverLimit.
+	Limit int
+}
+
+// MarketMovers are the ranked mover lists plus which symbols could not be quoted
+type MarketMovers struct {
+	Gainers    []StockQuote  `json:"gainers"`
+	Losers     []StockQuote  `json:"losers"`
+	MostActive []StockQuote  `json:"mostActive"`
+	Coverage   QuoteCoverage `json:"coverage"`
+	UpdatedAt  time.Time     `json:"updatedAt"`
+}
+
+// MoversService quotes a slice of the symbol universe each refresh cycle,
+// keeping the last quote seen for every symbol, and ranks those quotes for
+// each request. A page view never fans out into vendor calls, and a cycle
+// never asks for more than the batch size, so a 500-name universe fits the
+// vendors' per-minute budgets at the cost of taking several cycles to cover.
+type MoversService struct {
+	log          *slog.Logger
+	market       *MarketDataService
+	fundamentals *FundamentalsService
+	universe     []string
+	batchSize    int
+	interval     time.Duration
+
+	// next is where the following cycle's slice of the universe starts.
+	next int
+
+	mu        sync.RWMutex
+	quotes    map[string]StockQuote
+	updatedAt time.Time
+}
+
+// NewMoversService ranks universe, quoting batchSize symbols every interval
+// in Run. Market caps missing from quotes are filled from stored
+// fundamentals.
+func NewMoversService(log *slog.Logger, market *MarketDataService, fundamentals *FundamentalsService, universe []string, batchSize int, interval time.Duration) *MoversService {
+	if batchSize <= 0 || batchSize > len(universe) {
+		batchSize = len(universe)
+	}
+	return &MoversService{
+		log:          log,
+		market:       market,
+		fundamentals: fundamentals,
+		universe:     universe,
+		batchSize:    batchSize,
+		interval:     interval,
+		quotes:       map[string]StockQuote{},
+	}
+}
+
+// Run refreshes the next slice of the universe every interval until ctx is
+// done.
+func (s *MoversService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		if err := s.refresh(ctx); err != nil && ctx.Err() == nil {
+			s.log.Warn("movers refresh failed", slog.Any("err", err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh quotes the next batchSize symbols, wrapping around the universe.
+// Symbols that cannot be quoted keep their last quote.
+func (s *MoversService) refresh(ctx context.Context) error {
+	if len(s.universe) == 0 {
+		return nil
+	}
+	symbols := make([]string, 0, s.batchSize)
+	for i := range s.batchSize {
+		symbols = append(symbols, s.universe[(s.next+i)%len(s.universe)])
+	}
+	s.next = (s.next + s.batchSize) % len(s.universe)
+
+	batch, err := s.market.GetMultipleQuotes(ctx, symbols)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	quotes := batch.Ordered()
+	for i := range quotes {
+		if quotes[i].MarketCap == 0 {
+			s.fundamentals.Apply(ctx, &quotes[i])
+		}
+	}
+
+	s.mu.Lock()
+	for _, q := range quotes {
+		s.quotes[q.Symbol] = q
+	}
+	s.updatedAt = time.Now()
+	quoted := len(s.quotes)
+	s.mu.Unlock()
+
+	s.log.Debug("movers refreshed",
+		slog.Int("symbols", len(quotes)),
+		slog.Int("unavailable", len(batch.Unavailable())),
+		slog.Int("quoted", quoted),
+		slog.Int("universe", len(s.universe)),
+	)
+	return nil
+}
+
+// Movers ranks the quotes gathered so far. Until the background refresh
+// has been round the universe, the lists cover only the symbols it has
+// reached and the coverage counts the rest as unavailable.
+func (s *MoversService) Movers(ctx context.Context, filter MoverFilter) (*MarketMovers, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	quotes := make([]StockQuote, 0, len(s.quotes))
+	coverage := QuoteCoverage{Requested: len(s.universe)}
+	for _, symbol := range s.universe {
+		q, ok := s.quotes[symbol]
+		if !ok {
+			coverage.Unavailable = append(coverage.Unavailable, symbol)
+			continue
+		}
+		quotes = append(quotes, q)
+	}
+
+	movers := RankMovers(quotes, filter)
+	movers.Coverage = coverage
+	movers.UpdatedAt = s.updatedAt
+	return movers, nil
+}
+
+// RankMovers sorts gainers by percent change descending, losers by percent
+// change ascending and most active by volume descending. Unchanged names
+// are neither gainers nor losers. Coins rank on their rolling 24-hour change
+// but are left out of most active, since coin volume is not share volume.
+func RankMovers(quotes []StockQuote, filter MoverFilter) *MarketMovers {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultMoverLimit
+	}
+	bucket, bucketed := FindCapBucket(filter.Cap)
+
+	movers := &MarketMovers{}
+	for _, q := range quotes {
+		if bucketed && !bucket.Contains(q.MarketCap) {
+			continue
+		}
+		switch {
+		case q.ChangePercent > 0:
+			movers.Gainers = append(movers.Gainers, q)
+		case q.ChangePercent < 0:
+			movers.Losers = append(movers.Losers, q)
+		}
+		if q.Volume > 0 && !IsCrypto(q.Symbol) {
+			movers.MostActive = append(movers.MostActive, q)
+		}
+	}
+
+	// Ties fall back to symbol order so lists are stable between refreshes.
+	slices.SortFunc(movers.Gainers, func(a, b StockQuote) int {
+		return cmp.Or(cmp.Compare(b.ChangePercent, a.ChangePercent), cmp.Compare(a.Symbol, b.Symbol))
+	})
+	slices.SortFunc(movers.Losers, func(a, b StockQuote) int {
+		return cmp.Or(cmp.Compare(a.ChangePercent, b.ChangePercent), cmp.Compare(a.Symbol, b.Symbol))
+	})
+	slices.SortFunc(movers.MostActive, func(a, b StockQuote) int {
+		return cmp.Or(cmp.Compare(b.Volume, a.Volume), cmp.Compare(a.Symbol, b.Symbol))
+	})
+
+	movers.Gainers = movers.Gainers[:min(limit, len(movers.Gainers))]
+	movers.Losers = movers.Losers[:min(limit, len(movers.Losers))]
+	movers.MostActive = movers.MostActive[:min(limit, len(movers.MostActive))]
+	return movers
+}
@@ -24,6 +24,23 @@ func (s *NewsService) Latest(ctx context.Context, limit int32) ([]NewsHeadline,
         return nil, err
     }
 
+    return headlinesFromRows(rows), nil
+}
+
+// ForSymbol returns the latest articles tagged with one ticker.
+func (s *NewsService) ForSymbol(ctx context.Context, symbol string, limit int32) ([]NewsHeadline, error) {
+    rows, err := s.queries.ListNewsBySymbol(ctx, database.ListNewsBySymbolParams{
+        Symbol: symbol,
+        Limit:  int64(limit),
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    return headlinesFromRows(rows), nil
+}
+
+func headlinesFromRows(rows []database.NewsArticle) []NewsHeadline {
     headlines := make([]NewsHeadline, 0, len(rows))
     for _, row := range rows {
         headlines = append(headlines, NewsHeadline{
@@ -39,7 +56,7 @@ func (s *NewsService) Latest(ctx context.Context, limit int32) ([]NewsHeadline,
         })
     }
 
-    return headlines, nil
+    return headlines
 }
 
 func splitTickers(raw string) []string {
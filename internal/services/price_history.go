@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/database"
+	"github.com/loganlanou/Financing-101/internal/marketcalendar"
+)
+
+// FetchHistory asks providers for bars in [from, to] and reports which one answered
+func (s *MarketDataService) FetchHistory(ctx context.Context, symbol, interval string, from, to time.Time) ([]HistoricalData, string, error) {
+	err := errNoProviders
+	for _, provider := range s.providers {
+		var history []HistoricalData
+		history, err = provider.History(ctx, symbol, interval, from, to)
+		if err == nil {
+			return history, provider.Name(), nil
+		}
+	}
+
+	return nil, "", fmt.Errorf("no historical data source for %s: %w", symbol, err)
+}
+
+// StoreHistory upserts bars into the price store
+func (s *MarketDataService) StoreHistory(ctx context.Context, symbol, interval, source string, bars []HistoricalData) error {
+	if s.queries == nil {
+		return nil
+	}
+
+	for _, bar := range bars {
+		if bar.Timestamp.IsZero() {
+			continue
+		}
+		err := s.queries.UpsertPriceBar(ctx, database.UpsertPriceBarParams{
+			Symbol:   symbol,
+			Interval: interval,
+			BarTime:  normalizeBarTime(interval, bar.Timestamp),
+			Open:     bar.Open,
+			High:     bar.High,
+			Low:      bar.Low,
+			Close:    bar.Close,
+			Volume:   bar.Volume,
+			Source:   source,
+		})
+		if err != nil {
+			return fmt.Errorf("store %s %s bar: %w", symbol, interval, err)
+		}
+	}
+
+	return nil
+}
+
+// StoredHistory reads bars in [from, to] from the price store
+func (s *MarketDataService) StoredHistory(ctx context.Context, symbol, interval string, from, to time.Time) ([]HistoricalData, error) {
+	if s.queries == nil {
+		return nil, nil
+	}
+
+	rows, err := s.queries.ListPriceBars(ctx, database.ListPriceBarsParams{
+		Symbol:   symbol,
+		Interval: interval,
+		FromTime: normalizeBarTime(interval, from),
+		ToTime:   to.UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]HistoricalData, 0, len(rows))
+	for _, row := range rows {
+		history = append(history, HistoricalData{
+			Date:      barLabel(interval, row.BarTime),
+			Timestamp: row.BarTime,
+			Open:      row.Open,
+			High:      row.High,
+			Low:       row.Low,
+			Close:     row.Close,
+			Volume:    row.Volume,
+		})
+	}
+
+	return history, nil
+}
+
+// isDailyInterval reports whether bars are keyed by calendar date
+func isDailyInterval(interval string) bool {
+	return interval == "1d" || interval == "1wk"
+}
+
+// barLabel labels a bar the way every provider does: daily and weekly bars
+// by their calendar date, intraday bars in exchange time, whatever the
+// server's time zone.
+func barLabel(interval string, t time.Time) string {
+	if isDailyInterval(interval) {
+		return normalizeBarTime(interval, t).Format("2006-01-02 15:04")
+	}
+	return t.In(marketcalendar.Location()).Format("2006-01-02 15:04")
+}
+
+// normalizeBarTime stores times in UTC so the store compares them consistently;
+// daily and weekly bars are keyed by their calendar date.
+func normalizeBarTime(interval string, t time.Time) time.Time {
+	t = t.UTC()
+	if isDailyInterval(interval) {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return t
+}
+
+// TrailingWindow returns the bars from the last one on or before start
+// through the last one on or before end, or false when that span holds
+// fewer than two bars or the store has a gap of more than a week before
+// start. Bars must be in time order. Snapshot returns, sector returns and
+// risk windows all measure their spans with it, so they agree on dates.
+func TrailingWindow(bars []HistoricalData, start, end time.Time) ([]HistoricalData, bool) {
+	startIdx, endIdx := -1, -1
+	for i, bar := range bars {
+		if !bar.Timestamp.After(start) {
+			startIdx = i
+		}
+		if !bar.Timestamp.After(end) {
+			endIdx = i
+		}
+	}
+	if startIdx < 0 || endIdx <= startIdx {
+		return nil, false
+	}
+	if start.Sub(bars[startIdx].Timestamp) > 7*24*time.Hour {
+		return nil, false
+	}
+	return bars[startIdx : endIdx+1], true
+}
+
+// TrailingReturn is the percent change in close across TrailingWindow.
+func TrailingReturn(bars []HistoricalData, start, end time.Time) (float64, bool) {
+	window, ok := TrailingWindow(bars, start, end)
+	if !ok || window[0].Close == 0 {
+		return 0, false
+	}
+	return (window[len(window)-1].Close/window[0].Close - 1) * 100, true
+}
+
+// coversRange reports whether stored bars span [from, to], allowing for
+// weekends and holidays at either end.
+func coversRange(bars []HistoricalData, interval string, from, to time.Time) bool {
+	if len(bars) == 0 {
+		return false
+	}
+
+	slack := 4 * 24 * time.Hour
+	if interval == "1wk" {
+		slack = 8 * 24 * time.Hour
+	}
+
+	first := bars[0].Timestamp
+	last := bars[len(bars)-1].Timestamp
+	return !first.After(from.Add(slack)) && !last.Before(to.Add(-slack))
+}
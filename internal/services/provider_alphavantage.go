@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/marketcalendar"
+)
+
+const defaultAlphaVantageBaseURL = "https://www.alphavantage.co"
+
+// AlphaVantageProvider serves quotes and time series from the Alpha Vantage API.
+type AlphaVantageProvider struct {
+	client  *http.Client
+	apiKey  string
+	baseURL string
+}
+
+func NewAlphaVantageProvider(client *http.Client, apiKey, baseURL string) *AlphaVantageProvider {
+	if baseURL == "" {
+		baseURL = defaultAlphaVantageBaseURL
+	}
+	return &AlphaVantageProvider{client: client, apiKey: apiKey, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+func (p *AlphaVantageProvider) Name() string {
+	return "alphavantage"
+}
+
+// Supports reports whether symbol is an equity; coins are left to crypto vendors.
+func (p *AlphaVantageProvider) Supports(symbol string) bool {
+	return !IsCrypto(symbol)
+}
+
+// Quote fetches a GLOBAL_QUOTE payload
+func (p *AlphaVantageProvider) Quote(ctx context.Context, symbol string) (*StockQuote, error) {
+	if p.apiKey == "" {
+		return nil, fmt.Errorf("alpha vantage API key not configured")
+	}
+
+	params := url.Values{}
+	params.Set("function", "GLOBAL_QUOTE")
+	params.Set("symbol", symbol)
+
+	var data struct {
+		GlobalQuote struct {
+			Symbol        string `json:"01. symbol"`
+			Open          string `json:"02. open"`
+			High          string `json:"03. high"`
+			Low           string `json:"04. low"`
+			Price         string `json:"05. price"`
+			Volume        string `json:"06. volume"`
+			PrevClose     string `json:"08. previous close"`
+			Change        string `json:"09. change"`
+			ChangePercent string `json:"10. change percent"`
+		} `json:"Global Quote"`
+	}
+
+	if err := p.query(ctx, params, &data); err != nil {
+		return nil, err
+	}
+
+	gq := data.GlobalQuote
+	if gq.Symbol == "" {
+		return nil, fmt.Errorf("alpha vantage: no quote for %s", symbol)
+	}
+
+	return &StockQuote{
+		Symbol:        symbol,
+		Price:         parseAlphaVantageFloat(gq.Price),
+		Change:        parseAlphaVantageFloat(gq.Change),
+		ChangePercent: parseAlphaVantageFloat(strings.TrimSuffix(gq.ChangePercent, "%")),
+		Open:          parseAlphaVantageFloat(gq.Open),
+		High:          parseAlphaVantageFloat(gq.High),
+		Low:           parseAlphaVantageFloat(gq.Low),
+		PrevClose:     parseAlphaVantageFloat(gq.PrevClose),
+		Volume:        int64(parseAlphaVantageFloat(gq.Volume)),
+		UpdatedAt:     time.Now(),
+	}, nil
+}
+
+// Overview fetches company fundamentals from the OVERVIEW function
+func (p *AlphaVantageProvider) Overview(ctx context.Context, symbol string) (*CompanyOverview, error) {
+	if p.apiKey == "" {
+		return nil, fmt.Errorf("alpha vantage API key not configured")
+	}
+
+	params := url.Values{}
+	params.Set("function", "OVERVIEW")
+	params.Set("symbol", symbol)
+
+	var data struct {
+		Symbol               string `json:"Symbol"`
+		Name                 string `json:"Name"`
+		Exchange             string `json:"Exchange"`
+		Currency             string `json:"Currency"`
+		Sector               string `json:"Sector"`
+		Industry             string `json:"Industry"`
+		MarketCapitalization string `json:"MarketCapitalization"`
+		PERatio              string `json:"PERatio"`
+		EPS                  string `json:"EPS"`
+		Beta                 string `json:"Beta"`
+		DividendPerShare     string `json:"DividendPerShare"`
+		DividendYield        string `json:"DividendYield"`
+		Week52High           string `json:"52WeekHigh"`
+		Week52Low            string `json:"52WeekLow"`
+	}
+
+	if err := p.query(ctx, params, &data); err != nil {
+		return nil, err
+	}
+	if data.Symbol == "" {
+		return nil, fmt.Errorf("alpha vantage: no overview for %s", symbol)
+	}
+
+	return &CompanyOverview{
+		Symbol:        symbol,
+		Name:          data.Name,
+		Exchange:      data.Exchange,
+		Currency:      data.Currency,
+		Sector:        titleCase(data.Sector),
+		Industry:      titleCase(data.Industry),
+		MarketCap:     int64(parseAlphaVantageFloat(data.MarketCapitalization)),
+		PE:            parseAlphaVantageFloat(data.PERatio),
+		EPS:           parseAlphaVantageFloat(data.EPS),
+		Beta:          parseAlphaVantageFloat(data.Beta),
+		Dividend:      parseAlphaVantageFloat(data.DividendPerShare),
+		DividendYield: parseAlphaVantageFloat(data.DividendYield) * 100,
+		Week52High:    parseAlphaVantageFloat(data.Week52High),
+		Week52Low:     parseAlphaVantageFloat(data.Week52Low),
+	}, nil
+}
+
+// query calls /query with the API key and decodes into out. Alpha Vantage
+// answers throttled or rejected calls with HTTP 200 and a "Note",
+// "Information" or "Error Message" body, which are surfaced as errors here.
+func (p *AlphaVantageProvider) query(ctx context.Context, params url.Values, out interface{}) error {
+	params.Set("apikey", p.apiKey)
+
+	var raw json.RawMessage
+	if err := getJSON(ctx, p.client, p.baseURL+"/query?"+params.Encode(), nil, &raw); err != nil {
+		return err
+	}
+
+	var status struct {
+		Note         string `json:"Note"`
+		Information  string `json:"Information"`
+		ErrorMessage string `json:"Error Message"`
+	}
+	if err := json.Unmarshal(raw, &status); err == nil {
+		switch {
+		case status.Note != "":
+			return fmt.Errorf("alpha vantage: %w: %s", ErrRateLimited, status.Note)
+		case status.Information != "":
+			return fmt.Errorf("alpha vantage: %w: %s", ErrRateLimited, status.Information)
+		case status.ErrorMessage != "":
+			return fmt.Errorf("alpha vantage: %s", status.ErrorMessage)
+		}
+	}
+
+	return json.Unmarshal(raw, out)
+}
+
+// History fetches a daily, weekly or intraday time series and trims it to [from, to]
+func (p *AlphaVantageProvider) History(ctx context.Context, symbol, interval string, from, to time.Time) ([]HistoricalData, error) {
+	if p.apiKey == "" {
+		return nil, fmt.Errorf("alpha vantage API key not configured")
+	}
+
+	params := url.Values{}
+	params.Set("symbol", symbol)
+
+	var seriesKey string
+	switch interval {
+	case "1d":
+		params.Set("function", "TIME_SERIES_DAILY")
+		seriesKey = "Time Series (Daily)"
+	case "1wk":
+		params.Set("function", "TIME_SERIES_WEEKLY")
+		seriesKey = "Weekly Time Series"
+	case "1m", "5m", "15m":
+		avInterval := strings.TrimSuffix(interval, "m") + "min"
+		params.Set("function", "TIME_SERIES_INTRADAY")
+		params.Set("interval", avInterval)
+		seriesKey = "Time Series (" + avInterval + ")"
+	case "1h":
+		params.Set("function", "TIME_SERIES_INTRADAY")
+		params.Set("interval", "60min")
+		seriesKey = "Time Series (60min)"
+	default:
+		return nil, fmt.Errorf("alpha vantage: unsupported interval %q", interval)
+	}
+
+	// The compact output only covers the latest 100 points.
+	if time.Since(from) > 100*24*time.Hour {
+		params.Set("outputsize", "full")
+	}
+
+	var data map[string]interface{}
+	if err := p.query(ctx, params, &data); err != nil {
+		return nil, err
+	}
+
+	series, ok := data[seriesKey].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("no historical data for %s", symbol)
+	}
+	loc := alphaVantageLocation(data["Meta Data"])
+
+	history := make([]HistoricalData, 0, len(series))
+	for stamp, raw := range series {
+		fields, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ts, err := parseAlphaVantageTime(stamp, loc)
+		if err != nil || ts.Before(from) || ts.After(to) {
+			continue
+		}
+		history = append(history, HistoricalData{
+			Date:      barLabel(interval, ts),
+			Timestamp: ts.UTC(),
+			Open:      parseAlphaVantageFloat(fields["1. open"]),
+			High:      parseAlphaVantageFloat(fields["2. high"]),
+			Low:       parseAlphaVantageFloat(fields["3. low"]),
+			Close:     parseAlphaVantageFloat(fields["4. close"]),
+			Volume:    int64(parseAlphaVantageFloat(fields["5. volume"])),
+		})
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Date < history[j].Date
+	})
+
+	return history, nil
+}
+
+// Indices is not offered by Alpha Vantage
+func (p *AlphaVantageProvider) Indices(ctx context.Context, symbols []string) ([]IndexQuote, error) {
+	return nil, ErrNotSupported
+}
+
+// parseAlphaVantageTime reads a series stamp. Dates stay at UTC midnight;
+// intraday stamps are wall-clock times in loc, the series' time zone.
+func parseAlphaVantageTime(stamp string, loc *time.Location) (time.Time, error) {
+	if len(stamp) == len("2006-01-02") {
+		return time.Parse("2006-01-02", stamp)
+	}
+	return time.ParseInLocation("2006-01-02 15:04:05", stamp, loc)
+}
+
+// alphaVantageLocation reads the time zone named in a series' meta data
+// ("6. Time Zone" for intraday series), defaulting to US/Eastern, which is
+// what Alpha Vantage stamps US listings in.
+func alphaVantageLocation(meta interface{}) *time.Location {
+	fields, _ := meta.(map[string]interface{})
+	for key, raw := range fields {
+		name, ok := raw.(string)
+		if !ok || !strings.HasSuffix(key, "Time Zone") {
+			continue
+		}
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return marketcalendar.Location()
+}
+
+// parseAlphaVantageFloat reads the API's numeric strings; "None", "-" and
+// other placeholders read as zero.
+func parseAlphaVantageFloat(raw interface{}) float64 {
+	str, ok := raw.(string)
+	if !ok {
+		return 0
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(str), 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// titleCase turns Alpha Vantage's upper-case "TECHNOLOGY" labels into "Technology".
+func titleCase(s string) string {
+	words := strings.Fields(strings.ToLower(s))
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultCoinbaseBaseURL = "https://api.exchange.coinbase.com"
+
+// coinbaseMaxCandles is the most candles one /candles request returns.
+const coinbaseMaxCandles = 300
+
+// CoinbaseProvider serves crypto spot quotes and candles from the public
+// Coinbase Exchange API, which needs no key. Equities are not supported.
+type CoinbaseProvider struct {
+	client  *http.Client
+	baseURL string
+}
+
+func NewCoinbaseProvider(client *http.Client, baseURL string) *CoinbaseProvider {
+	if baseURL == "" {
+		baseURL = defaultCoinbaseBaseURL
+	}
+	return &CoinbaseProvider{client: client, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+func (p *CoinbaseProvider) Name() string {
+	return "coinbase"
+}
+
+// Supports reports whether symbol is a coin pair.
+func (p *CoinbaseProvider) Supports(symbol string) bool {
+	return IsCrypto(symbol)
+}
+
+// Quote fetches the rolling 24-hour stats for a pair from /products/:id/stats.
+// Coins have no close, so the price 24 hours ago stands in for the
+// previous close and the change is measured from it.
+func (p *CoinbaseProvider) Quote(ctx context.Context, symbol string) (*StockQuote, error) {
+	if !IsCrypto(symbol) {
+		return nil, ErrNotSupported
+	}
+
+	var data struct {
+		Open   string `json:"open"`
+		High   string `json:"high"`
+		Low    string `json:"low"`
+		Last   string `json:"last"`
+		Volume string `json:"volume"`
+	}
+	if err := getJSON(ctx, p.client, p.baseURL+"/products/"+url.PathEscape(symbol)+"/stats", nil, &data); err != nil {
+		return nil, err
+	}
+
+	price, _ := strconv.ParseFloat(data.Last, 64)
+	if price == 0 {
+		return nil, fmt.Errorf("%s: %w", symbol, ErrNoData)
+	}
+	open, _ := strconv.ParseFloat(data.Open, 64)
+	high, _ := strconv.ParseFloat(data.High, 64)
+	low, _ := strconv.ParseFloat(data.Low, 64)
+	volume, _ := strconv.ParseFloat(data.Volume, 64)
+	_, currency, _ := strings.Cut(symbol, "-")
+
+	quote := &StockQuote{
+		Symbol:    symbol,
+		Name:      cryptoName(symbol),
+		Price:     price,
+		Open:      open,
+		High:      high,
+		Low:       low,
+		PrevClose: open,
+		Volume:    int64(volume),
+		Exchange:  "Coinbase",
+		Currency:  currency,
+		UpdatedAt: time.Now(),
+	}
+	if open > 0 {
+		quote.Change = price - open
+		quote.ChangePercent = quote.Change / open * 100
+	}
+	return quote, nil
+}
+
+// History fetches candles from /products/:id/candles, paging through the
+// 300-candle limit. Weekly bars are built from daily candles.
+func (p *CoinbaseProvider) History(ctx context.Context, symbol, interval string, from, to time.Time) ([]HistoricalData, error) {
+	if !IsCrypto(symbol) {
+		return nil, ErrNotSupported
+	}
+
+	granularities := map[string]time.Duration{
+		"1m":  time.Minute,
+		"5m":  5 * time.Minute,
+		"15m": 15 * time.Minute,
+		"1h":  time.Hour,
+		"1d":  24 * time.Hour,
+		"1wk": 24 * time.Hour,
+	}
+	granularity, ok := granularities[interval]
+	if !ok {
+		return nil, fmt.Errorf("coinbase: unsupported interval %q", interval)
+	}
+
+	var history []HistoricalData
+	for start := from; start.Before(to); start = start.Add(coinbaseMaxCandles * granularity) {
+		end := start.Add(coinbaseMaxCandles * granularity)
+		if end.After(to) {
+			end = to
+		}
+
+		params := url.Values{}
+		params.Set("granularity", strconv.Itoa(int(granularity.Seconds())))
+		params.Set("start", start.UTC().Format(time.RFC3339))
+		params.Set("end", end.UTC().Format(time.RFC3339))
+
+		// Each candle is [time, low, high, open, close, volume], newest first.
+		var candles [][6]float64
+		if err := getJSON(ctx, p.client, p.baseURL+"/products/"+url.PathEscape(symbol)+"/candles?"+params.Encode(), nil, &candles); err != nil {
+			return nil, err
+		}
+		for _, c := range candles {
+			ts := time.Unix(int64(c[0]), 0)
+			history = append(history, HistoricalData{
+				Date:      barLabel(interval, ts),
+				Timestamp: ts.UTC(),
+				Open:      c[3],
+				High:      c[2],
+				Low:       c[1],
+				Close:     c[4],
+				Volume:    int64(c[5]),
+			})
+		}
+	}
+	if len(history) == 0 {
+		return nil, fmt.Errorf("no historical data for %s", symbol)
+	}
+
+	slices.SortFunc(history, func(a, b HistoricalData) int { return a.Timestamp.Compare(b.Timestamp) })
+	history = slices.CompactFunc(history, func(a, b HistoricalData) bool { return a.Timestamp.Equal(b.Timestamp) })
+	if interval == "1wk" {
+		history = weeklyBars(history)
+	}
+	return history, nil
+}
+
+// Indices is not available from a crypto exchange
+func (p *CoinbaseProvider) Indices(ctx context.Context, symbols []string) ([]IndexQuote, error) {
+	return nil, ErrNotSupported
+}
+
+// weeklyBars folds daily bars, oldest first, into weeks starting Monday.
+func weeklyBars(daily []HistoricalData) []HistoricalData {
+	var weeks []HistoricalData
+	for _, bar := range daily {
+		day := bar.Timestamp.UTC()
+		monday := time.Date(day.Year(), day.Month(), day.Day()-(int(day.Weekday())+6)%7, 0, 0, 0, 0, time.UTC)
+		if n := len(weeks); n > 0 && weeks[n-1].Timestamp.Equal(monday) {
+			week := &weeks[n-1]
+			week.High = max(week.High, bar.High)
+			week.Low = min(week.Low, bar.Low)
+			week.Close = bar.Close
+			week.Volume += bar.Volume
+			continue
+		}
+		bar.Timestamp = monday
+		bar.Date = barLabel("1wk", monday)
+		weeks = append(weeks, bar)
+	}
+	return weeks
+}
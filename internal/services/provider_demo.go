@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DemoProvider serves a fixed snapshot of sample data so pages render without
+// network access or API keys. It is not in the default QUOTE_PROVIDERS: add
+// "demo" last to fall back to it, or use it alone to run offline. Its quotes
+// are marked stale, since the prices are invented.
+type DemoProvider struct {
+	quotes  map[string]StockQuote
+	indices []IndexQuote
+}
+
+func NewDemoProvider() *DemoProvider {
+	quotes := make(map[string]StockQuote)
+	for _, q := range demoQuotes() {
+		quotes[q.Symbol] = q
+	}
+
+	return &DemoProvider{
+		quotes:  quotes,
+		indices: demoIndices(),
+	}
+}
+
+func (p *DemoProvider) Name() string {
+	return "demo"
+}
+
+// Quote returns the sample quote for a symbol
+func (p *DemoProvider) Quote(ctx context.Context, symbol string) (*StockQuote, error) {
+	q, ok := p.quotes[symbol]
+	if !ok {
+		return nil, fmt.Errorf("no demo data for %s", symbol)
+	}
+	q.UpdatedAt = time.Now()
+	q.Stale = true
+	return &q, nil
+}
+
+// History is not available from the demo snapshot
+func (p *DemoProvider) History(ctx context.Context, symbol, interval string, from, to time.Time) ([]HistoricalData, error) {
+	return nil, ErrNotSupported
+}
+
+// Indices returns the sample benchmarks that were requested
+func (p *DemoProvider) Indices(ctx context.Context, symbols []string) ([]IndexQuote, error) {
+	wanted := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		wanted[symbol] = true
+	}
+
+	var indices []IndexQuote
+	for _, idx := range p.indices {
+		if wanted[idx.Symbol] {
+			idx.UpdatedAt = time.Now()
+			indices = append(indices, idx)
+		}
+	}
+
+	return indices, nil
+}
+
+func demoIndices() []IndexQuote {
+	return []IndexQuote{
+		{Symbol: "^GSPC", Name: "S&P 500", Price: 5998.74, Change: 63.77, ChangePercent: 1.07},
+		{Symbol: "^DJI", Name: "Dow Jones", Price: 43828.06, Change: 619.05, ChangePercent: 1.43},
+		{Symbol: "^IXIC", Name: "NASDAQ", Price: 19926.72, Change: 180.09, ChangePercent: 0.91},
+		{Symbol: "^RUT", Name: "Russell 2000", Price: 2346.90, Change: 51.27, ChangePercent: 2.23},
+	}
+}
+
+func demoQuotes() []StockQuote {
+	return []StockQuote{
+		{Symbol: "AAPL", Name: "Apple Inc.", Price: 248.13, Change: 2.87, ChangePercent: 1.17, Open: 245.50, High: 249.25, Low: 244.80, PrevClose: 245.26, Volume: 45600000, MarketCap: 3780000000000, PE: 32.1, Week52High: 250.00, Week52Low: 164.08},
+		{Symbol: "MSFT", Name: "Microsoft Corporation", Price: 446.95, Change: 3.12, ChangePercent: 0.70, Open: 443.50, High: 448.75, Low: 442.20, PrevClose: 443.83, Volume: 21400000, MarketCap: 3320000000000, PE: 36.8, Week52High: 468.35, Week52Low: 362.90},
+		{Symbol: "NVDA", Name: "NVIDIA Corporation", Price: 134.25, Change: 8.42, ChangePercent: 6.69, Open: 126.50, High: 135.50, Low: 125.80, PrevClose: 125.83, Volume: 312500000, MarketCap: 3300000000000, PE: 65.2, Week52High: 140.76, Week52Low: 45.01},
+		{Symbol: "GOOGL", Name: "Alphabet Inc.", Price: 192.96, Change: 5.67, ChangePercent: 3.03, Open: 188.00, High: 193.50, Low: 187.25, PrevClose: 187.29, Volume: 28400000, MarketCap: 2370000000000, PE: 24.8, Week52High: 193.31, Week52Low: 130.67},
+		{Symbol: "AMZN", Name: "Amazon.com, Inc.", Price: 227.03, Change: 4.23, ChangePercent: 1.90, Open: 223.50, High: 228.00, Low: 222.80, PrevClose: 222.80, Volume: 39800000, MarketCap: 2380000000000, PE: 46.2, Week52High: 233.00, Week52Low: 151.61},
+		{Symbol: "META", Name: "Meta Platforms", Price: 617.12, Change: 15.23, ChangePercent: 2.53, Open: 602.00, High: 618.95, Low: 600.50, PrevClose: 601.89, Volume: 14200000, MarketCap: 1560000000000, PE: 29.3, Week52High: 618.95, Week52Low: 326.89},
+		{Symbol: "TSLA", Name: "Tesla, Inc.", Price: 424.77, Change: 14.89, ChangePercent: 3.63, Open: 410.00, High: 426.50, Low: 408.25, PrevClose: 409.88, Volume: 89500000, MarketCap: 1350000000000, PE: 78.4, Week52High: 438.22, Week52Low: 138.80},
+		{Symbol: "BRK.B", Name: "Berkshire Hathaway", Price: 458.92, Change: -1.23, ChangePercent: -0.27, Open: 460.00, High: 461.50, Low: 457.80, PrevClose: 460.15, Volume: 3200000, MarketCap: 989000000000, PE: 9.8, Week52High: 491.66, Week52Low: 378.94},
+		{Symbol: "JPM", Name: "JPMorgan Chase", Price: 243.67, Change: 3.45, ChangePercent: 1.44, Open: 240.50, High: 244.50, Low: 239.80, PrevClose: 240.22, Volume: 8900000, MarketCap: 698000000000, PE: 12.5, Week52High: 254.31, Week52Low: 173.21},
+		{Symbol: "V", Name: "Visa Inc.", Price: 317.89, Change: 2.34, ChangePercent: 0.74, Open: 315.50, High: 318.75, Low: 314.80, PrevClose: 315.55, Volume: 6200000, MarketCap: 628000000000, PE: 30.2, Week52High: 321.62, Week52Low: 252.70},
+		{Symbol: "AMD", Name: "Advanced Micro Devices", Price: 137.89, Change: 5.23, ChangePercent: 3.94, Volume: 48200000, MarketCap: 223000000000, PE: 98.5, Week52High: 164.46, Week52Low: 93.12},
+		{Symbol: "NFLX", Name: "Netflix, Inc.", Price: 909.05, Change: -12.41, ChangePercent: -1.35, Volume: 3100000, MarketCap: 389000000000, PE: 51.4, Week52High: 941.75, Week52Low: 461.86},
+		{Symbol: "INTC", Name: "Intel Corporation", Price: 20.11, Change: -0.89, ChangePercent: -4.24, Volume: 67800000, MarketCap: 86000000000, PE: 0, Week52High: 51.28, Week52Low: 18.51},
+		{Symbol: "BA", Name: "Boeing Company", Price: 177.56, Change: -4.23, ChangePercent: -2.33, Volume: 8900000, MarketCap: 108000000000, PE: 0, Week52High: 267.54, Week52Low: 137.03},
+		{Symbol: "NKE", Name: "Nike, Inc.", Price: 75.89, Change: -1.67, ChangePercent: -2.15, Volume: 12300000, MarketCap: 113000000000, PE: 21.4, Week52High: 107.43, Week52Low: 70.75},
+		{Symbol: "DIS", Name: "Walt Disney Co.", Price: 112.34, Change: -2.12, ChangePercent: -1.85, Volume: 9800000, MarketCap: 205000000000, PE: 72.1, Week52High: 123.74, Week52Low: 83.91},
+		{Symbol: "PFE", Name: "Pfizer Inc.", Price: 25.67, Change: -0.43, ChangePercent: -1.65, Volume: 32100000, MarketCap: 145000000000, PE: 19.8, Week52High: 31.54, Week52Low: 24.48},
+		// Coins trade around the clock; the change is over the last 24 hours.
+		{Symbol: "BTC-USD", Name: "Bitcoin", Price: 67412.50, Change: 1184.20, ChangePercent: 1.79, Open: 66228.30, High: 67890.00, Low: 65940.10, PrevClose: 66228.30, Volume: 14250, Exchange: "Coinbase", Currency: "USD"},
+		{Symbol: "ETH-USD", Name: "Ethereum", Price: 2618.75, Change: -31.40, ChangePercent: -1.18, Open: 2650.15, High: 2671.90, Low: 2589.30, PrevClose: 2650.15, Volume: 182400, Exchange: "Coinbase", Currency: "USD"},
+		// Sector ETFs back GetSectorPerformance.
+		{Symbol: "XLK", Name: "Technology Select Sector SPDR", Price: 236.41, Change: 5.40, ChangePercent: 2.34},
+		{Symbol: "XLV", Name: "Health Care Select Sector SPDR", Price: 146.02, Change: 1.62, ChangePercent: 1.12},
+		{Symbol: "XLF", Name: "Financial Select Sector SPDR", Price: 48.83, Change: 0.43, ChangePercent: 0.89},
+		{Symbol: "XLE", Name: "Energy Select Sector SPDR", Price: 86.51, Change: -0.39, ChangePercent: -0.45},
+		{Symbol: "XLY", Name: "Consumer Discretionary Select Sector SPDR", Price: 225.37, Change: 3.69, ChangePercent: 1.67},
+		{Symbol: "XLP", Name: "Consumer Staples Select Sector SPDR", Price: 79.44, Change: 0.18, ChangePercent: 0.23},
+		{Symbol: "XLI", Name: "Industrial Select Sector SPDR", Price: 134.09, Change: 1.04, ChangePercent: 0.78},
+		{Symbol: "XLB", Name: "Materials Select Sector SPDR", Price: 87.95, Change: -0.11, ChangePercent: -0.12},
+		{Symbol: "XLU", Name: "Utilities Select Sector SPDR", Price: 77.12, Change: -0.26, ChangePercent: -0.34},
+		{Symbol: "XLRE", Name: "Real Estate Select Sector SPDR", Price: 41.88, Change: 0.23, ChangePercent: 0.56},
+	}
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultFinnhubBaseURL = "https://finnhub.io/api/v1"
+
+// FinnhubProvider serves quotes and candles from the Finnhub REST API.
+type FinnhubProvider struct {
+	client  *http.Client
+	apiKey  string
+	baseURL string
+}
+
+func NewFinnhubProvider(client *http.Client, apiKey, baseURL string) *FinnhubProvider {
+	if baseURL == "" {
+		baseURL = defaultFinnhubBaseURL
+	}
+	return &FinnhubProvider{client: client, apiKey: apiKey, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+func (p *FinnhubProvider) Name() string {
+	return "finnhub"
+}
+
+// Supports reports whether symbol is an equity; coins are left to crypto vendors.
+func (p *FinnhubProvider) Supports(symbol string) bool {
+	return !IsCrypto(symbol)
+}
+
+// Quote fetches a real-time quote from the /quote endpoint
+func (p *FinnhubProvider) Quote(ctx context.Context, symbol string) (*StockQuote, error) {
+	if p.apiKey == "" {
+		return nil, fmt.Errorf("finnhub API key not configured")
+	}
+
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("token", p.apiKey)
+
+	var data struct {
+		C  float64 `json:"c"`  // Current price
+		D  float64 `json:"d"`  // Change
+		DP float64 `json:"dp"` // Percent change
+		H  float64 `json:"h"`  // High
+		L  float64 `json:"l"`  // Low
+		O  float64 `json:"o"`  // Open
+		PC float64 `json:"pc"` // Previous close
+	}
+
+	if err := getJSON(ctx, p.client, p.baseURL+"/quote?"+params.Encode(), nil, &data); err != nil {
+		return nil, err
+	}
+
+	if data.C == 0 {
+		return nil, fmt.Errorf("%s: %w", symbol, ErrNoData)
+	}
+
+	return &StockQuote{
+		Symbol:        symbol,
+		Price:         data.C,
+		Change:        data.D,
+		ChangePercent: data.DP,
+		Open:          data.O,
+		High:          data.H,
+		Low:           data.L,
+		PrevClose:     data.PC,
+		UpdatedAt:     time.Now(),
+	}, nil
+}
+
+// History fetches OHLCV candles from the /stock/candle endpoint
+func (p *FinnhubProvider) History(ctx context.Context, symbol, interval string, from, to time.Time) ([]HistoricalData, error) {
+	if p.apiKey == "" {
+		return nil, fmt.Errorf("finnhub API key not configured")
+	}
+
+	resolutions := map[string]string{
+		"1m":  "1",
+		"5m":  "5",
+		"15m": "15",
+		"1h":  "60",
+		"1d":  "D",
+		"1wk": "W",
+	}
+	resolution, ok := resolutions[interval]
+	if !ok {
+		return nil, fmt.Errorf("finnhub: unsupported interval %q", interval)
+	}
+
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("resolution", resolution)
+	params.Set("from", strconv.FormatInt(from.Unix(), 10))
+	params.Set("to", strconv.FormatInt(to.Unix(), 10))
+	params.Set("token", p.apiKey)
+
+	var data struct {
+		S string    `json:"s"`
+		T []int64   `json:"t"`
+		O []float64 `json:"o"`
+		H []float64 `json:"h"`
+		L []float64 `json:"l"`
+		C []float64 `json:"c"`
+		V []float64 `json:"v"`
+	}
+
+	if err := getJSON(ctx, p.client, p.baseURL+"/stock/candle?"+params.Encode(), nil, &data); err != nil {
+		return nil, err
+	}
+
+	if data.S != "ok" {
+		return nil, fmt.Errorf("no historical data for %s", symbol)
+	}
+
+	history := make([]HistoricalData, 0, len(data.T))
+	for i, ts := range data.T {
+		if i >= len(data.C) || i >= len(data.O) || i >= len(data.H) || i >= len(data.L) || i >= len(data.V) {
+			break
+		}
+		history = append(history, HistoricalData{
+			Date:      barLabel(interval, time.Unix(ts, 0)),
+			Timestamp: time.Unix(ts, 0).UTC(),
+			Open:      data.O[i],
+			High:      data.H[i],
+			Low:       data.L[i],
+			Close:     data.C[i],
+			Volume:    int64(data.V[i]),
+		})
+	}
+
+	return history, nil
+}
+
+// Indices is not available on Finnhub's free tier
+func (p *FinnhubProvider) Indices(ctx context.Context, symbols []string) ([]IndexQuote, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,295 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+var (
+	// ErrRateLimited is returned when a provider's request budget is spent.
+	ErrRateLimited = errors.New("provider rate limit reached")
+	// ErrCircuitOpen is returned while a failing provider is being skipped.
+	ErrCircuitOpen = errors.New("provider circuit open")
+)
+
+// Circuit breaker states reported by ProviderHealth.
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+// maxRateWait is how long a call may queue for a token before the next
+// provider in line is tried instead.
+const maxRateWait = time.Second
+
+// BreakerSettings control when a provider is skipped and re-probed.
+type BreakerSettings struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit.
+	FailureThreshold int
+	// Cooldown is how long an open circuit waits before letting a probe through.
+	Cooldown time.Duration
+}
+
+// ProviderHealth is a point-in-time view of a guarded provider.
+type ProviderHealth struct {
+	Name          string    `json:"name"`
+	State         string    `json:"state"`
+	Failures      int       `json:"consecutiveFailures"`
+	LastError     string    `json:"lastError,omitempty"`
+	LastFailureAt time.Time `json:"lastFailureAt,omitzero"`
+	OpenUntil     time.Time `json:"openUntil,omitzero"`
+	RatePerMinute float64   `json:"ratePerMinute,omitempty"`
+}
+
+// guardedProvider wraps a vendor with a token bucket and a circuit breaker.
+type guardedProvider struct {
+	QuoteProvider
+	log      *slog.Logger
+	limiter  *rate.Limiter
+	perMin   float64
+	settings BreakerSettings
+
+	mu          sync.Mutex
+	state       string
+	failures    int
+	lastErr     error
+	lastFailure time.Time
+	openUntil   time.Time
+	probing     bool
+}
+
+// newGuardedProvider limits p to perMinute calls (unlimited when zero). A zero
+// burst allows up to five seconds' worth of budget at once.
+func newGuardedProvider(log *slog.Logger, p QuoteProvider, perMinute float64, burst int, settings BreakerSettings) *guardedProvider {
+	limiter := rate.NewLimiter(rate.Inf, 0)
+	if perMinute > 0 {
+		if burst <= 0 {
+			burst = max(1, int(perMinute/12))
+		}
+		limiter = rate.NewLimiter(rate.Limit(perMinute/60), burst)
+	}
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = 5
+	}
+	if settings.Cooldown <= 0 {
+		settings.Cooldown = time.Minute
+	}
+
+	return &guardedProvider{
+		QuoteProvider: p,
+		log:           log,
+		limiter:       limiter,
+		perMin:        perMinute,
+		settings:      settings,
+		state:         CircuitClosed,
+	}
+}
+
+func (g *guardedProvider) Quote(ctx context.Context, symbol string) (*StockQuote, error) {
+	if !g.supports(symbol) {
+		return nil, ErrNotSupported
+	}
+	var quote *StockQuote
+	err := g.do(ctx, func() error {
+		var err error
+		quote, err = g.QuoteProvider.Quote(ctx, symbol)
+		return err
+	})
+	return quote, err
+}
+
+func (g *guardedProvider) History(ctx context.Context, symbol, interval string, from, to time.Time) ([]HistoricalData, error) {
+	if !g.supports(symbol) {
+		return nil, ErrNotSupported
+	}
+	var history []HistoricalData
+	err := g.do(ctx, func() error {
+		var err error
+		history, err = g.QuoteProvider.History(ctx, symbol, interval, from, to)
+		return err
+	})
+	return history, err
+}
+
+func (g *guardedProvider) Indices(ctx context.Context, symbols []string) ([]IndexQuote, error) {
+	var indices []IndexQuote
+	err := g.do(ctx, func() error {
+		var err error
+		indices, err = g.QuoteProvider.Indices(ctx, symbols)
+		return err
+	})
+	return indices, err
+}
+
+// Overview forwards to the wrapped vendor when it publishes fundamentals.
+func (g *guardedProvider) Overview(ctx context.Context, symbol string) (*CompanyOverview, error) {
+	inner, ok := g.QuoteProvider.(OverviewProvider)
+	if !ok || !g.supports(symbol) {
+		return nil, ErrNotSupported
+	}
+	var overview *CompanyOverview
+	err := g.do(ctx, func() error {
+		var err error
+		overview, err = inner.Overview(ctx, symbol)
+		return err
+	})
+	return overview, err
+}
+
+// Actions forwards to the wrapped vendor when it publishes corporate actions.
+func (g *guardedProvider) Actions(ctx context.Context, symbol string, from, to time.Time) ([]CorporateAction, error) {
+	inner, ok := g.QuoteProvider.(ActionsProvider)
+	if !ok || !g.supports(symbol) {
+		return nil, ErrNotSupported
+	}
+	var actions []CorporateAction
+	err := g.do(ctx, func() error {
+		var err error
+		actions, err = inner.Actions(ctx, symbol, from, to)
+		return err
+	})
+	return actions, err
+}
+
+// supports reports whether the wrapped vendor covers symbol at all.
+func (g *guardedProvider) supports(symbol string) bool {
+	filter, ok := g.QuoteProvider.(SymbolFilter)
+	return !ok || filter.Supports(symbol)
+}
+
+// do runs call when the breaker and the rate limiter both allow it.
+func (g *guardedProvider) do(ctx context.Context, call func() error) error {
+	if err := g.admit(time.Now()); err != nil {
+		return err
+	}
+
+	if err := g.wait(ctx); err != nil {
+		g.release()
+		return err
+	}
+
+	err := call()
+	g.record(err)
+	return err
+}
+
+// wait takes a token, queueing briefly if one is due soon.
+func (g *guardedProvider) wait(ctx context.Context) error {
+	reservation := g.limiter.Reserve()
+	delay := reservation.Delay()
+	if delay == 0 {
+		return nil
+	}
+	if delay > maxRateWait {
+		reservation.Cancel()
+		return fmt.Errorf("%s: %w", g.Name(), ErrRateLimited)
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		reservation.Cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// admit rejects calls while the circuit is open and lets a single probe
+// through once the cooldown has passed.
+func (g *guardedProvider) admit(now time.Time) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	switch g.state {
+	case CircuitOpen:
+		if now.Before(g.openUntil) {
+			return fmt.Errorf("%s: %w", g.Name(), ErrCircuitOpen)
+		}
+		g.transition(CircuitHalfOpen)
+		g.probing = true
+	case CircuitHalfOpen:
+		if g.probing {
+			return fmt.Errorf("%s: %w", g.Name(), ErrCircuitOpen)
+		}
+		g.probing = true
+	}
+	return nil
+}
+
+// release hands back a probe slot that never reached the vendor.
+func (g *guardedProvider) release() {
+	g.mu.Lock()
+	g.probing = false
+	g.mu.Unlock()
+}
+
+func (g *guardedProvider) record(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.probing = false
+
+	// Unsupported calls, unknown symbols and cancelled requests say nothing
+	// about vendor health.
+	if err == nil || errors.Is(err, ErrNotSupported) || errors.Is(err, ErrNoData) || errors.Is(err, context.Canceled) {
+		if err == nil && g.state != CircuitClosed {
+			g.transition(CircuitClosed)
+		}
+		if err == nil {
+			g.failures = 0
+		}
+		return
+	}
+
+	g.failures++
+	g.lastErr = err
+	g.lastFailure = time.Now()
+
+	if g.state == CircuitHalfOpen || g.failures >= g.settings.FailureThreshold {
+		g.openUntil = g.lastFailure.Add(g.settings.Cooldown)
+		if g.state != CircuitOpen {
+			g.transition(CircuitOpen)
+		}
+	}
+}
+
+// transition must be called with mu held.
+func (g *guardedProvider) transition(state string) {
+	g.log.Warn("quote provider circuit changed",
+		slog.String("provider", g.Name()),
+		slog.String("from", g.state),
+		slog.String("to", state),
+		slog.Int("failures", g.failures),
+		slog.Any("last_err", g.lastErr),
+	)
+	g.state = state
+}
+
+func (g *guardedProvider) health() ProviderHealth {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	h := ProviderHealth{
+		Name:          g.Name(),
+		State:         g.state,
+		Failures:      g.failures,
+		LastFailureAt: g.lastFailure,
+		RatePerMinute: g.perMin,
+	}
+	if g.lastErr != nil {
+		h.LastError = g.lastErr.Error()
+	}
+	if g.state == CircuitOpen {
+		h.OpenUntil = g.openUntil
+	}
+	return h
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultYahooBaseURL = "https://query1.finance.yahoo.com"
+
+// YahooProvider serves quotes, history and indices from Yahoo Finance (unofficial).
+type YahooProvider struct {
+	client  *http.Client
+	baseURL string
+}
+
+func NewYahooProvider(client *http.Client, baseURL string) *YahooProvider {
+	if baseURL == "" {
+		baseURL = defaultYahooBaseURL
+	}
+	return &YahooProvider{client: client, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+func (p *YahooProvider) Name() string {
+	return "yahoo"
+}
+
+// yahooChart mirrors the subset of the v8 chart payload we consume
+type yahooChart struct {
+	Chart struct {
+		Result []struct {
+			Meta struct {
+				Symbol             string  `json:"symbol"`
+				RegularMarketPrice float64 `json:"regularMarketPrice"`
+				PreviousClose      float64 `json:"previousClose"`
+				Exchange           string  `json:"exchangeName"`
+				Currency           string  `json:"currency"`
+			} `json:"meta"`
+			Timestamp  []int64 `json:"timestamp"`
+			Indicators struct {
+				Quote []struct {
+					Open   []float64 `json:"open"`
+					High   []float64 `json:"high"`
+					Low    []float64 `json:"low"`
+					Close  []float64 `json:"close"`
+					Volume []int64   `json:"volume"`
+				} `json:"quote"`
+			} `json:"indicators"`
+			// Events are only present when requested with the events parameter
+			Events struct {
+				Dividends map[string]struct {
+					Amount float64 `json:"amount"`
+					Date   int64   `json:"date"`
+				} `json:"dividends"`
+				Splits map[string]struct {
+					Date        int64   `json:"date"`
+					Numerator   float64 `json:"numerator"`
+					Denominator float64 `json:"denominator"`
+				} `json:"splits"`
+			} `json:"events"`
+		} `json:"result"`
+		Error interface{} `json:"error"`
+	} `json:"chart"`
+}
+
+func (p *YahooProvider) chart(ctx context.Context, symbol string, params url.Values) (*yahooChart, error) {
+	endpoint := fmt.Sprintf("%s/v8/finance/chart/%s?%s", p.baseURL, url.PathEscape(symbol), params.Encode())
+
+	var data yahooChart
+	if err := getJSON(ctx, p.client, endpoint, map[string]string{"User-Agent": "Mozilla/5.0"}, &data); err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+// Quote fetches the latest session from the chart endpoint
+func (p *YahooProvider) Quote(ctx context.Context, symbol string) (*StockQuote, error) {
+	params := url.Values{}
+	params.Set("interval", "1d")
+	params.Set("range", "1d")
+
+	data, err := p.chart(ctx, symbol, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data.Chart.Result) == 0 {
+		return nil, fmt.Errorf("%s: %w", symbol, ErrNoData)
+	}
+
+	result := data.Chart.Result[0]
+	meta := result.Meta
+	price := meta.RegularMarketPrice
+	prevClose := meta.PreviousClose
+	change := price - prevClose
+	changePercent := 0.0
+	if prevClose > 0 {
+		changePercent = (change / prevClose) * 100
+	}
+
+	var open, high, low float64
+	var volume int64
+	if len(result.Indicators.Quote) > 0 && len(result.Indicators.Quote[0].Open) > 0 {
+		q := result.Indicators.Quote[0]
+		open = q.Open[0]
+		high = q.High[0]
+		low = q.Low[0]
+		if len(q.Volume) > 0 {
+			volume = q.Volume[0]
+		}
+	}
+
+	return &StockQuote{
+		Symbol:        symbol,
+		Price:         price,
+		Change:        change,
+		ChangePercent: changePercent,
+		Open:          open,
+		High:          high,
+		Low:           low,
+		PrevClose:     prevClose,
+		Volume:        volume,
+		Exchange:      meta.Exchange,
+		Currency:      meta.Currency,
+		UpdatedAt:     time.Now(),
+	}, nil
+}
+
+// History fetches bars between from and to at the given interval
+func (p *YahooProvider) History(ctx context.Context, symbol, interval string, from, to time.Time) ([]HistoricalData, error) {
+	params := url.Values{}
+	params.Set("interval", interval)
+	params.Set("period1", strconv.FormatInt(from.Unix(), 10))
+	params.Set("period2", strconv.FormatInt(to.Unix(), 10))
+
+	data, err := p.chart(ctx, symbol, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data.Chart.Result) == 0 {
+		return nil, fmt.Errorf("no historical data for %s", symbol)
+	}
+
+	result := data.Chart.Result[0]
+	var history []HistoricalData
+
+	if len(result.Indicators.Quote) > 0 {
+		q := result.Indicators.Quote[0]
+		for i, ts := range result.Timestamp {
+			if i < len(q.Close) && i < len(q.Open) && i < len(q.High) && i < len(q.Low) && i < len(q.Volume) {
+				history = append(history, HistoricalData{
+					Date:      barLabel(interval, time.Unix(ts, 0)),
+					Timestamp: time.Unix(ts, 0).UTC(),
+					Open:      q.Open[i],
+					High:      q.High[i],
+					Low:       q.Low[i],
+					Close:     q.Close[i],
+					Volume:    q.Volume[i],
+				})
+			}
+		}
+	}
+
+	return history, nil
+}
+
+// Actions fetches split and dividend events between from and to
+func (p *YahooProvider) Actions(ctx context.Context, symbol string, from, to time.Time) ([]CorporateAction, error) {
+	params := url.Values{}
+	params.Set("interval", "1d")
+	params.Set("period1", strconv.FormatInt(from.Unix(), 10))
+	params.Set("period2", strconv.FormatInt(to.Unix(), 10))
+	params.Set("events", "div,splits")
+
+	data, err := p.chart(ctx, symbol, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data.Chart.Result) == 0 {
+		return nil, fmt.Errorf("no event data for %s", symbol)
+	}
+
+	events := data.Chart.Result[0].Events
+	var actions []CorporateAction
+	for _, split := range events.Splits {
+		if split.Numerator <= 0 || split.Denominator <= 0 {
+			continue
+		}
+		actions = append(actions, CorporateAction{
+			Symbol: symbol,
+			Type:   ActionSplit,
+			ExDate: actionDate(time.Unix(split.Date, 0)),
+			Ratio:  split.Numerator / split.Denominator,
+		})
+	}
+	for _, div := range events.Dividends {
+		if div.Amount <= 0 {
+			continue
+		}
+		actions = append(actions, CorporateAction{
+			Symbol: symbol,
+			Type:   ActionDividend,
+			ExDate: actionDate(time.Unix(div.Date, 0)),
+			Amount: div.Amount,
+		})
+	}
+	sortActions(actions)
+
+	return actions, nil
+}
+
+// Indices quotes each index symbol, skipping any that fail
+func (p *YahooProvider) Indices(ctx context.Context, symbols []string) ([]IndexQuote, error) {
+	var indices []IndexQuote
+	for _, symbol := range symbols {
+		quote, err := p.Quote(ctx, symbol)
+		if err != nil {
+			continue
+		}
+		indices = append(indices, IndexQuote{
+			Symbol:        symbol,
+			Name:          indexNames[symbol],
+			Price:         quote.Price,
+			Change:        quote.Change,
+			ChangePercent: quote.ChangePercent,
+			Currency:      quote.Currency,
+		})
+	}
+
+	if len(indices) == 0 {
+		return nil, fmt.Errorf("no index data returned")
+	}
+
+	return indices, nil
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+)
+
+// QuoteBatch is the per-symbol outcome of a multi-symbol quote lookup. Each
+// quote records the provider that served it in Source and whether it came
+// from an expired cache entry in Stale.
+type QuoteBatch struct {
+	Symbols []string
+	Quotes  map[string]*StockQuote
+	Errors  map[string]error
+}
+
+// QuoteCoverage summarizes how much of a requested symbol list is on screen.
+type QuoteCoverage struct {
+	Requested   int      `json:"requested"`
+	Unavailable []string `json:"unavailable"`
+}
+
+// Ordered returns the quotes that were served, in request order.
+func (b *QuoteBatch) Ordered() []StockQuote {
+	out := make([]StockQuote, 0, len(b.Quotes))
+	for _, symbol := range b.Symbols {
+		if q, ok := b.Quotes[symbol]; ok {
+			out = append(out, *q)
+		}
+	}
+	return out
+}
+
+// Unavailable lists the symbols no provider could quote, in request order.
+func (b *QuoteBatch) Unavailable() []string {
+	var out []string
+	for _, symbol := range b.Symbols {
+		if _, ok := b.Errors[symbol]; ok {
+			out = append(out, symbol)
+		}
+	}
+	return out
+}
+
+// Stale lists the symbols answered from an expired cache entry.
+func (b *QuoteBatch) Stale() []string {
+	var out []string
+	for _, symbol := range b.Symbols {
+		if q, ok := b.Quotes[symbol]; ok && q.Stale {
+			out = append(out, symbol)
+		}
+	}
+	return out
+}
+
+// Coverage reports requested vs unavailable symbols for display.
+func (b *QuoteBatch) Coverage() QuoteCoverage {
+	return QuoteCoverage{Requested: len(b.Symbols), Unavailable: b.Unavailable()}
+}
+
+// Err is non-nil only when every requested symbol failed.
+func (b *QuoteBatch) Err() error {
+	if len(b.Symbols) == 0 || len(b.Quotes) > 0 {
+		return nil
+	}
+
+	errs := make([]error, 0, len(b.Errors))
+	for _, symbol := range b.Symbols {
+		if err, ok := b.Errors[symbol]; ok {
+			errs = append(errs, err)
+		}
+	}
+	return fmt.Errorf("no quotes for %d symbols: %w", len(b.Symbols), errors.Join(errs...))
+}
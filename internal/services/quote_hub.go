@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/marketcalendar"
+)
+
+// QuoteHub polls each subscribed symbol once per interval, however many
+// clients are watching it, and fans changed quotes out to every subscriber.
+// Each symbol keeps its own pace, so a watched coin does not speed up
+// polling of equities while their market is closed.
+type QuoteHub struct {
+	log            *slog.Logger
+	market         *MarketDataService
+	openInterval   time.Duration
+	closedInterval time.Duration
+
+	mu   sync.Mutex
+	subs map[*QuoteSubscription]struct{}
+	refs map[string]int
+	last map[string]StockQuote
+	// due is when each symbol is next polled; symbols without an entry are
+	// polled on the next pass.
+	due  map[string]time.Time
+	wake chan struct{}
+}
+
+// QuoteSubscription receives quotes for a fixed symbol set. Updates that
+// arrive faster than the client reads them are coalesced per symbol.
+type QuoteSubscription struct {
+	symbols []string
+	notify  chan struct{}
+
+	mu      sync.Mutex
+	pending map[string]StockQuote
+}
+
+// NewQuoteHub polls every openInterval during the regular session and every
+// closedInterval otherwise; coins, which trade around the clock, are always
+// polled every openInterval.
+func NewQuoteHub(log *slog.Logger, market *MarketDataService, openInterval, closedInterval time.Duration) *QuoteHub {
+	return &QuoteHub{
+		log:            log,
+		market:         market,
+		openInterval:   openInterval,
+		closedInterval: closedInterval,
+		subs:           map[*QuoteSubscription]struct{}{},
+		refs:           map[string]int{},
+		last:           map[string]StockQuote{},
+		due:            map[string]time.Time{},
+		wake:           make(chan struct{}, 1),
+	}
+}
+
+// Subscribe registers interest in symbols. The last known quotes are
+// delivered immediately; symbols nobody watched before are polled right away.
+func (h *QuoteHub) Subscribe(symbols []string) *QuoteSubscription {
+	sub := &QuoteSubscription{
+		symbols: symbols,
+		notify:  make(chan struct{}, 1),
+		pending: map[string]StockQuote{},
+	}
+
+	h.mu.Lock()
+	unseen := false
+	h.subs[sub] = struct{}{}
+	for _, symbol := range symbols {
+		h.refs[symbol]++
+		if quote, ok := h.last[symbol]; ok {
+			sub.push(symbol, quote)
+		} else {
+			unseen = true
+		}
+	}
+	h.mu.Unlock()
+
+	if unseen {
+		select {
+		case h.wake <- struct{}{}:
+		default:
+		}
+	}
+
+	return sub
+}
+
+// Unsubscribe stops deliveries to sub and drops symbols nobody watches anymore.
+func (h *QuoteHub) Unsubscribe(sub *QuoteSubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subs[sub]; !ok {
+		return
+	}
+	delete(h.subs, sub)
+	for _, symbol := range sub.symbols {
+		h.refs[symbol]--
+		if h.refs[symbol] <= 0 {
+			delete(h.refs, symbol)
+			delete(h.last, symbol)
+			delete(h.due, symbol)
+		}
+	}
+}
+
+// Run polls until ctx is cancelled.
+func (h *QuoteHub) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		case <-h.wake:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		}
+
+		h.poll(ctx)
+		timer.Reset(h.untilDue())
+	}
+}
+
+// pace is how often symbol is polled.
+func (h *QuoteHub) pace(symbol string, open bool) time.Duration {
+	if open || IsCrypto(symbol) {
+		return h.openInterval
+	}
+	return h.closedInterval
+}
+
+// untilDue is the wait until the next symbol is due. A symbol is never
+// left waiting longer than its current pace, so equities speed up as soon
+// as the market opens. With nothing watched the hub sleeps until a
+// subscription wakes it.
+func (h *QuoteHub) untilDue() time.Duration {
+	now := time.Now()
+	open := h.market.GetMarketStatus() == marketcalendar.StatusOpen
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	wait := h.closedInterval
+	for symbol := range h.refs {
+		due, ok := h.due[symbol]
+		if !ok {
+			return 0
+		}
+		wait = min(wait, due.Sub(now), h.pace(symbol, open))
+	}
+	return max(wait, 0)
+}
+
+// poll quotes the symbols that are due, grouped by pace. Each group reads
+// through the quote cache when its entries are under half the pace old,
+// so a 15 second stream is not held to the cache's minute.
+func (h *QuoteHub) poll(ctx context.Context) {
+	now := time.Now()
+	open := h.market.GetMarketStatus() == marketcalendar.StatusOpen
+
+	h.mu.Lock()
+	groups := map[time.Duration][]string{}
+	for symbol := range h.refs {
+		pace := h.pace(symbol, open)
+		if due, ok := h.due[symbol]; ok && now.Before(due) && due.Sub(now) < pace {
+			continue
+		}
+		groups[pace] = append(groups[pace], symbol)
+		h.due[symbol] = now.Add(pace)
+	}
+	h.mu.Unlock()
+
+	for pace, symbols := range groups {
+		batch, err := h.market.GetFreshQuotes(ctx, symbols, pace/2)
+		if err != nil {
+			h.log.Warn("quote stream poll failed", slog.Any("err", err))
+		}
+		h.publish(batch)
+	}
+}
+
+// publish records the batch's quotes and pushes the ones that changed.
+func (h *QuoteHub) publish(batch *QuoteBatch) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	changed := make(map[string]StockQuote)
+	for symbol, quote := range batch.Quotes {
+		if _, watched := h.refs[symbol]; !watched {
+			continue
+		}
+		// Subscribers match on the symbol they asked for, not the vendor's spelling.
+		next := *quote
+		next.Symbol = symbol
+		if prev, ok := h.last[symbol]; ok && !quoteChanged(prev, next) {
+			continue
+		}
+		h.last[symbol] = next
+		changed[symbol] = next
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	for sub := range h.subs {
+		for _, symbol := range sub.symbols {
+			if quote, ok := changed[symbol]; ok {
+				sub.push(symbol, quote)
+			}
+		}
+	}
+}
+
+// quoteChanged ignores fields that only move when the quote itself does.
+func quoteChanged(prev, next StockQuote) bool {
+	return prev.Price != next.Price ||
+		prev.Change != next.Change ||
+		prev.Volume != next.Volume ||
+		prev.Source != next.Source
+}
+
+func (s *QuoteSubscription) push(symbol string, quote StockQuote) {
+	s.mu.Lock()
+	s.pending[symbol] = quote
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Ready is signalled whenever Drain has quotes to return.
+func (s *QuoteSubscription) Ready() <-chan struct{} {
+	return s.notify
+}
+
+// Drain returns and clears the quotes received since the last call.
+func (s *QuoteSubscription) Drain() []StockQuote {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]StockQuote, 0, len(s.pending))
+	for _, symbol := range s.symbols {
+		if quote, ok := s.pending[symbol]; ok {
+			out = append(out, quote)
+		}
+	}
+	clear(s.pending)
+	return out
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotSupported is returned by a provider that cannot serve a request type.
+	ErrNotSupported = errors.New("operation not supported by provider")
+	// ErrNoData is returned when a provider answered but has nothing for the
+	// symbol, such as an unknown ticker.
+	ErrNoData = errors.New("no data returned by provider")
+)
+
+// QuoteProvider is a single market data vendor. MarketDataService walks its
+// providers in priority order until one of them answers.
+type QuoteProvider interface {
+	Name() string
+	Quote(ctx context.Context, symbol string) (*StockQuote, error)
+	History(ctx context.Context, symbol, interval string, from, to time.Time) ([]HistoricalData, error)
+	Indices(ctx context.Context, symbols []string) ([]IndexQuote, error)
+}
+
+// OverviewProvider is implemented by vendors that publish company
+// fundamentals separately from their quote endpoint.
+type OverviewProvider interface {
+	Overview(ctx context.Context, symbol string) (*CompanyOverview, error)
+}
+
+// ActionsProvider is implemented by vendors that publish split and dividend
+// events alongside their price history.
+type ActionsProvider interface {
+	Actions(ctx context.Context, symbol string, from, to time.Time) ([]CorporateAction, error)
+}
+
+// SymbolFilter is implemented by vendors that only cover some symbols, such
+// as equities or coins; the rest are skipped without spending their budget.
+type SymbolFilter interface {
+	Supports(symbol string) bool
+}
+
+// CompanyOverview holds slow-moving fundamentals used to fill in quotes
+// that arrive without them.
+type CompanyOverview struct {
+	Symbol        string  `json:"symbol"`
+	Name          string  `json:"name"`
+	Exchange      string  `json:"exchange"`
+	Currency      string  `json:"currency"`
+	Sector        string  `json:"sector"`
+	Industry      string  `json:"industry"`
+	MarketCap     int64   `json:"marketCap"`
+	PE            float64 `json:"pe"`
+	EPS           float64 `json:"eps"`
+	Beta          float64 `json:"beta"`
+	Dividend      float64 `json:"dividend"`
+	DividendYield float64 `json:"dividendYield"`
+	Week52High    float64 `json:"week52High"`
+	Week52Low     float64 `json:"week52Low"`
+}
+
+// ProviderSettings holds per-vendor credentials, endpoint overrides and quota.
+type ProviderSettings struct {
+	APIKey  string
+	BaseURL string
+	// RatePerMinute caps calls to the vendor; zero means unlimited.
+	RatePerMinute float64
+	Burst         int
+}
+
+// NewQuoteProviders builds providers by name in the given priority order,
+// each behind its own rate limiter and circuit breaker.
+func NewQuoteProviders(log *slog.Logger, client *http.Client, order []string, settings map[string]ProviderSettings, breaker BreakerSettings) ([]QuoteProvider, error) {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	providers := make([]QuoteProvider, 0, len(order))
+	for _, name := range order {
+		opts := settings[strings.ToLower(name)]
+		if requiresAPIKey(name) && opts.APIKey == "" {
+			log.Warn("quote provider disabled", slog.String("provider", name), slog.String("reason", "missing api key"))
+			continue
+		}
+
+		var provider QuoteProvider
+		switch strings.ToLower(name) {
+		case "finnhub":
+			provider = NewFinnhubProvider(client, opts.APIKey, opts.BaseURL)
+		case "alphavantage":
+			provider = NewAlphaVantageProvider(client, opts.APIKey, opts.BaseURL)
+		case "yahoo":
+			provider = NewYahooProvider(client, opts.BaseURL)
+		case "coinbase":
+			provider = NewCoinbaseProvider(client, opts.BaseURL)
+		case "demo":
+			provider = NewDemoProvider()
+		default:
+			return nil, fmt.Errorf("unknown quote provider %q", name)
+		}
+		providers = append(providers, newGuardedProvider(log, provider, opts.RatePerMinute, opts.Burst, breaker))
+	}
+
+	return providers, nil
+}
+
+// requiresAPIKey reports whether a vendor rejects anonymous requests.
+func requiresAPIKey(name string) bool {
+	switch strings.ToLower(name) {
+	case "finnhub", "alphavantage":
+		return true
+	}
+	return false
+}
+
+// getJSON issues a GET request and decodes a JSON response body into out.
+func getJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: status %d from %s", ErrNoData, resp.StatusCode, req.URL.Host)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, req.URL.Host)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
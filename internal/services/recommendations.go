@@ -23,6 +23,23 @@ func (s *RecommendationService) TopPicks(ctx context.Context, limit int32) ([]Re
         return nil, err
     }
 
+    return recommendationsFromRows(rows), nil
+}
+
+// ForSymbol returns the recommendations written for one ticker, newest first.
+func (s *RecommendationService) ForSymbol(ctx context.Context, symbol string, limit int32) ([]Recommendation, error) {
+    rows, err := s.queries.ListRecommendationsBySymbol(ctx, database.ListRecommendationsBySymbolParams{
+        Symbol: symbol,
+        Limit:  int64(limit),
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    return recommendationsFromRows(rows), nil
+}
+
+func recommendationsFromRows(rows []database.Recommendation) []Recommendation {
     recs := make([]Recommendation, 0, len(rows))
     for _, row := range rows {
         recs = append(recs, Recommendation{
@@ -36,5 +53,5 @@ func (s *RecommendationService) TopPicks(ctx context.Context, limit int32) ([]Re
         })
     }
 
-    return recs, nil
+    return recs
 }
@@ -0,0 +1,374 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/database"
+)
+
+// riskWindows are the trailing calendar-day windows stored in risk_stats,
+// matching the stock_snapshots return windows.
+var riskWindows = []int{30, 90, 365}
+
+const (
+	// tradingDaysPerYear annualizes daily statistics.
+	tradingDaysPerYear = 252
+	// minRiskObservations is the fewest daily returns a statistic is
+	// computed from; a 30-day window holds about 20.
+	minRiskObservations = 15
+	// DefaultRollingWindow is about three months of trading days.
+	DefaultRollingWindow = 63
+	// RiskHistoryDays is how much daily history Refresh needs for a year of
+	// rolling points, each with the longest rolling window behind it.
+	RiskHistoryDays = 365 + 252*7/5 + 10
+)
+
+// RollingWindows are the rolling window lengths, in trading days, stored in
+// risk_rolling: about one, three, six and twelve months.
+var RollingWindows = []int{21, 63, 126, 252}
+
+// RiskStats are risk and return statistics over one trailing window.
+// Volatility and MaxDrawdown are percentages; nil ratios could not be
+// computed, e.g. beta when the benchmark has no overlapping bars.
+type RiskStats struct {
+	WindowDays   int       `json:"windowDays"`
+	Benchmark    string    `json:"benchmark"`
+	Observations int       `json:"observations"`
+	Volatility   float64   `json:"volatility"`
+	MaxDrawdown  float64   `json:"maxDrawdown"`
+	Beta         *float64  `json:"beta,omitempty"`
+	Correlation  *float64  `json:"correlation,omitempty"`
+	Sharpe       *float64  `json:"sharpe,omitempty"`
+	Sortino      *float64  `json:"sortino,omitempty"`
+	AsOf         time.Time `json:"asOf"`
+}
+
+// RiskPoint is one step of a rolling window, stamped with its last bar.
+type RiskPoint struct {
+	Time        time.Time `json:"time"`
+	Volatility  float64   `json:"volatility"`
+	MaxDrawdown float64   `json:"maxDrawdown"`
+	Beta        *float64  `json:"beta,omitempty"`
+	Correlation *float64  `json:"correlation,omitempty"`
+	Sharpe      *float64  `json:"sharpe,omitempty"`
+	Sortino     *float64  `json:"sortino,omitempty"`
+}
+
+// RiskService computes risk statistics from split- and dividend-adjusted
+// daily bars in the price store and serves the copies stored alongside
+// stock_snapshots.
+type RiskService struct {
+	log          *slog.Logger
+	db           *sql.DB
+	queries      *database.Queries
+	riskFreeRate float64
+}
+
+// NewRiskService computes Sharpe and Sortino against riskFreeRate, which is
+// annual, e.g. 0.04 for 4%. Each symbol's rolling series are replaced in one
+// transaction.
+func NewRiskService(log *slog.Logger, db *sql.DB, queries *database.Queries, riskFreeRate float64) *RiskService {
+	return &RiskService{
+		log:          log,
+		db:           db,
+		queries:      queries,
+		riskFreeRate: riskFreeRate,
+	}
+}
+
+// Get returns the stored statistics for symbol, shortest window first.
+func (s *RiskService) Get(ctx context.Context, symbol string) ([]RiskStats, error) {
+	rows, err := s.queries.ListRiskStats(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]RiskStats, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, RiskStats{
+			WindowDays:   int(row.WindowDays),
+			Benchmark:    row.Benchmark,
+			Observations: int(row.Observations),
+			Volatility:   row.Volatility,
+			MaxDrawdown:  row.MaxDrawdown,
+			Beta:         nullFloat(row.Beta),
+			Correlation:  nullFloat(row.Correlation),
+			Sharpe:       nullFloat(row.Sharpe),
+			Sortino:      nullFloat(row.Sortino),
+			AsOf:         row.AsOf.UTC(),
+		})
+	}
+	return out, nil
+}
+
+// Refresh recomputes and stores every window ending at the last bar, and
+// the rolling series over the year before it. Bars for symbol and benchmark
+// are daily and in time order; windows without enough history are skipped.
+func (s *RiskService) Refresh(ctx context.Context, symbol string, bars []HistoricalData, benchmark string, benchmarkBars []HistoricalData) error {
+	if len(bars) == 0 {
+		return nil
+	}
+	asOf := bars[len(bars)-1].Timestamp
+	benchCloses := closesByDate(benchmarkBars)
+
+	for _, days := range riskWindows {
+		window, ok := TrailingWindow(bars, asOf.AddDate(0, 0, -days), asOf)
+		if !ok {
+			continue
+		}
+		point, n, ok := riskOver(window, benchCloses, s.riskFreeRate)
+		if !ok {
+			continue
+		}
+
+		err := s.queries.UpsertRiskStat(ctx, database.UpsertRiskStatParams{
+			Symbol:       symbol,
+			WindowDays:   int64(days),
+			Benchmark:    benchmark,
+			Observations: int64(n),
+			Volatility:   point.Volatility,
+			MaxDrawdown:  point.MaxDrawdown,
+			Beta:         sqlFloat(point.Beta),
+			Correlation:  sqlFloat(point.Correlation),
+			Sharpe:       sqlFloat(point.Sharpe),
+			Sortino:      sqlFloat(point.Sortino),
+			AsOf:         asOf.UTC(),
+			UpdatedAt:    time.Now().UTC(),
+		})
+		if err != nil {
+			return fmt.Errorf("store %d-day risk stats: %w", days, err)
+		}
+	}
+
+	if err := s.storeRolling(ctx, symbol, bars, benchmark, benchmarkBars); err != nil {
+		return fmt.Errorf("store rolling risk: %w", err)
+	}
+	return nil
+}
+
+// storeRolling replaces the symbol's rolling series for every window with
+// the points from the year before its last bar.
+func (s *RiskService) storeRolling(ctx context.Context, symbol string, bars []HistoricalData, benchmark string, benchmarkBars []HistoricalData) error {
+	yearAgo := bars[len(bars)-1].Timestamp.AddDate(-1, 0, 0)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	queries := s.queries.WithTx(tx)
+
+	for _, window := range RollingWindows {
+		err := queries.DeleteRiskRolling(ctx, database.DeleteRiskRollingParams{Symbol: symbol, WindowBars: int64(window)})
+		if err != nil {
+			return err
+		}
+		for _, point := range RollingRisk(bars, benchmarkBars, window, s.riskFreeRate) {
+			if point.Time.Before(yearAgo) {
+				continue
+			}
+			err := queries.InsertRiskRolling(ctx, database.InsertRiskRollingParams{
+				Symbol:      symbol,
+				WindowBars:  int64(window),
+				BarTime:     point.Time.UTC(),
+				Benchmark:   benchmark,
+				Volatility:  point.Volatility,
+				MaxDrawdown: point.MaxDrawdown,
+				Beta:        sqlFloat(point.Beta),
+				Correlation: sqlFloat(point.Correlation),
+				Sharpe:      sqlFloat(point.Sharpe),
+				Sortino:     sqlFloat(point.Sortino),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// Rolling returns the stored rolling statistics over window trading days,
+// one of RollingWindows, for the past year of bars, and reports the
+// benchmark they were measured against.
+func (s *RiskService) Rolling(ctx context.Context, symbol string, window int) (string, []RiskPoint, error) {
+	rows, err := s.queries.ListRiskRolling(ctx, database.ListRiskRollingParams{
+		Symbol:     symbol,
+		WindowBars: int64(window),
+		FromTime:   time.Now().UTC().AddDate(-1, 0, -7),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	benchmark := ""
+	points := make([]RiskPoint, 0, len(rows))
+	for _, row := range rows {
+		benchmark = row.Benchmark
+		points = append(points, RiskPoint{
+			Time:        row.BarTime.UTC(),
+			Volatility:  row.Volatility,
+			MaxDrawdown: row.MaxDrawdown,
+			Beta:        nullFloat(row.Beta),
+			Correlation: nullFloat(row.Correlation),
+			Sharpe:      nullFloat(row.Sharpe),
+			Sortino:     nullFloat(row.Sortino),
+		})
+	}
+	return benchmark, points, nil
+}
+
+// Apply fills a quote's beta from the one-year statistics when the quote
+// provider left it empty.
+func (s *RiskService) Apply(ctx context.Context, quote *StockQuote) {
+	if quote.Beta != 0 {
+		return
+	}
+	stats, err := s.Get(ctx, quote.Symbol)
+	if err != nil {
+		s.log.Warn("load risk stats failed", slog.String("symbol", quote.Symbol), slog.Any("err", err))
+		return
+	}
+	for _, st := range stats {
+		if st.WindowDays == 365 && st.Beta != nil {
+			quote.Beta = *st.Beta
+		}
+	}
+}
+
+// RollingRisk evaluates a window of trading days ending at each bar once the
+// first window is full.
+func RollingRisk(bars, benchmarkBars []HistoricalData, window int, riskFreeRate float64) []RiskPoint {
+	if window < 2 || len(bars) <= window {
+		return nil
+	}
+	benchCloses := closesByDate(benchmarkBars)
+
+	out := make([]RiskPoint, 0, len(bars)-window)
+	for end := window; end < len(bars); end++ {
+		if point, _, ok := riskOver(bars[end-window:end+1], benchCloses, riskFreeRate); ok {
+			out = append(out, point)
+		}
+	}
+	return out
+}
+
+// riskOver computes statistics from the close-to-close returns of bars and
+// reports how many returns it used.
+func riskOver(bars []HistoricalData, benchCloses map[string]float64, riskFreeRate float64) (RiskPoint, int, bool) {
+	var returns, assetPaired, benchPaired []float64
+	for i := 1; i < len(bars); i++ {
+		prev := bars[i-1].Close
+		if prev == 0 {
+			continue
+		}
+		r := bars[i].Close/prev - 1
+		returns = append(returns, r)
+
+		b0, ok0 := benchCloses[barDate(bars[i-1])]
+		b1, ok1 := benchCloses[barDate(bars[i])]
+		if ok0 && ok1 && b0 != 0 {
+			assetPaired = append(assetPaired, r)
+			benchPaired = append(benchPaired, b1/b0-1)
+		}
+	}
+	if len(returns) < minRiskObservations {
+		return RiskPoint{}, len(returns), false
+	}
+
+	annualize := math.Sqrt(tradingDaysPerYear)
+	mean, sd := meanStdDev(returns)
+	point := RiskPoint{
+		Time:        bars[len(bars)-1].Timestamp,
+		Volatility:  sd * annualize * 100,
+		MaxDrawdown: maxDrawdown(bars) * 100,
+	}
+
+	dailyRiskFree := riskFreeRate / tradingDaysPerYear
+	excess := mean - dailyRiskFree
+	if sd > 0 {
+		point.Sharpe = ptr(excess / sd * annualize)
+	}
+	var downside float64
+	for _, r := range returns {
+		if d := r - dailyRiskFree; d < 0 {
+			downside += d * d
+		}
+	}
+	if downside > 0 {
+		point.Sortino = ptr(excess / math.Sqrt(downside/float64(len(returns))) * annualize)
+	}
+
+	if len(assetPaired) >= minRiskObservations {
+		assetMean, assetSD := meanStdDev(assetPaired)
+		benchMean, benchSD := meanStdDev(benchPaired)
+		var cov float64
+		for i := range assetPaired {
+			cov += (assetPaired[i] - assetMean) * (benchPaired[i] - benchMean)
+		}
+		cov /= float64(len(assetPaired) - 1)
+		if benchSD > 0 {
+			point.Beta = ptr(cov / (benchSD * benchSD))
+			if assetSD > 0 {
+				point.Correlation = ptr(cov / (assetSD * benchSD))
+			}
+		}
+	}
+
+	return point, len(returns), true
+}
+
+// meanStdDev returns the mean and sample standard deviation.
+func meanStdDev(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	if len(values) < 2 {
+		return mean, 0
+	}
+	var sq float64
+	for _, v := range values {
+		sq += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(sq / float64(len(values)-1))
+}
+
+// maxDrawdown is the largest peak-to-trough fall in close as a negative
+// fraction, or zero when price never fell below a prior high.
+func maxDrawdown(bars []HistoricalData) float64 {
+	var peak, worst float64
+	for _, bar := range bars {
+		peak = max(peak, bar.Close)
+		if peak > 0 {
+			worst = min(worst, bar.Close/peak-1)
+		}
+	}
+	return worst
+}
+
+func closesByDate(bars []HistoricalData) map[string]float64 {
+	out := make(map[string]float64, len(bars))
+	for _, bar := range bars {
+		out[barDate(bar)] = bar.Close
+	}
+	return out
+}
+
+// barDate keys a daily bar by its calendar date; the price store keeps
+// daily bars at midnight UTC.
+func barDate(bar HistoricalData) string {
+	return bar.Timestamp.UTC().Format("2006-01-02")
+}
+
+func sqlFloat(v *float64) sql.NullFloat64 {
+	if v == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *v, Valid: true}
+}
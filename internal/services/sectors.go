@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// SectorETF is a sector fund tracked for rotation.
+type SectorETF struct {
+	Symbol string
+	Sector string
+}
+
+// SectorETFs are the SPDR funds covering the eleven GICS sectors, in display order.
+var SectorETFs = []SectorETF{
+	{"XLK", "Technology"},
+	{"XLC", "Communication Services"},
+	{"XLY", "Consumer Cyclical"},
+	{"XLF", "Financials"},
+	{"XLV", "Healthcare"},
+	{"XLI", "Industrials"},
+	{"XLP", "Consumer Defensive"},
+	{"XLE", "Energy"},
+	{"XLB", "Materials"},
+	{"XLU", "Utilities"},
+	{"XLRE", "Real Estate"},
+}
+
+// SectorWindows are the return windows in SectorStats, shortest first.
+var SectorWindows = []string{"1W", "1M", "3M", "YTD", "1Y"}
+
+// Rotation quadrants, named as on a relative rotation graph.
+const (
+	QuadrantLeading   = "Leading"
+	QuadrantWeakening = "Weakening"
+	QuadrantLagging   = "Lagging"
+	QuadrantImproving = "Improving"
+)
+
+const (
+	// rsRatioPeriod smooths relative strength over about ten weeks.
+	rsRatioPeriod = 50
+	// rsMomentumLag compares the RS-Ratio with two weeks earlier.
+	rsMomentumLag = 10
+	// rotationTailPoints is how many weekly points trail each sector.
+	rotationTailPoints = 8
+)
+
+// SectorStats are one sector fund's trailing returns and rotation position.
+// Returns and Relative are in percent keyed by SectorWindows; Relative is
+// the return relative to the benchmark, (1+sector)/(1+benchmark)-1. Windows
+// without enough stored history are absent.
+type SectorStats struct {
+	Symbol   string             `json:"symbol"`
+	Sector   string             `json:"sector"`
+	Returns  map[string]float64 `json:"returns"`
+	Relative map[string]float64 `json:"relative"`
+	// RSRatio and RSMomentum are centered on 100; zero when there is too
+	// little history to place the sector.
+	RSRatio    float64         `json:"rsRatio"`
+	RSMomentum float64         `json:"rsMomentum"`
+	Quadrant   string          `json:"quadrant,omitempty"`
+	Tail       []RotationPoint `json:"tail,omitempty"`
+}
+
+// RotationPoint is a sector's position on the rotation graph at one date.
+type RotationPoint struct {
+	Time       time.Time `json:"time"`
+	RSRatio    float64   `json:"rsRatio"`
+	RSMomentum float64   `json:"rsMomentum"`
+}
+
+// SectorRotation is every sector fund measured against the benchmark.
+type SectorRotation struct {
+	Benchmark string        `json:"benchmark"`
+	AsOf      time.Time     `json:"asOf"`
+	Sectors   []SectorStats `json:"sectors"`
+}
+
+// SectorService measures sector funds against a benchmark from the adjusted
+// daily bars in the price store.
+type SectorService struct {
+	log       *slog.Logger
+	market    *MarketDataService
+	benchmark string
+}
+
+func NewSectorService(log *slog.Logger, market *MarketDataService, benchmark string) *SectorService {
+	return &SectorService{log: log, market: market, benchmark: benchmark}
+}
+
+// Symbols lists the funds and benchmark whose history must be backfilled.
+func (s *SectorService) Symbols() []string {
+	out := make([]string, 0, len(SectorETFs)+1)
+	for _, etf := range SectorETFs {
+		out = append(out, etf.Symbol)
+	}
+	return append(out, s.benchmark)
+}
+
+// Rotation computes returns and rotation-graph positions as of the latest
+// stored benchmark bar. Sectors with no stored bars are left out.
+func (s *SectorService) Rotation(ctx context.Context) (*SectorRotation, error) {
+	to := time.Now().UTC()
+	from := to.AddDate(-1, 0, -10)
+
+	benchBars, err := s.market.AdjustedHistory(ctx, s.benchmark, "1d", from, to)
+	if err != nil {
+		return nil, fmt.Errorf("read benchmark %s: %w", s.benchmark, err)
+	}
+	rotation := &SectorRotation{Benchmark: s.benchmark}
+	if len(benchBars) == 0 {
+		return rotation, nil
+	}
+	asOf := benchBars[len(benchBars)-1].Timestamp
+	rotation.AsOf = asOf
+	benchReturns := windowReturns(benchBars, asOf)
+
+	for _, etf := range SectorETFs {
+		bars, err := s.market.AdjustedHistory(ctx, etf.Symbol, "1d", from, to)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", etf.Symbol, err)
+		}
+		if len(bars) == 0 {
+			continue
+		}
+
+		stats := SectorStats{
+			Symbol:   etf.Symbol,
+			Sector:   etf.Sector,
+			Returns:  windowReturns(bars, asOf),
+			Relative: map[string]float64{},
+		}
+		for window, r := range stats.Returns {
+			if b, ok := benchReturns[window]; ok {
+				stats.Relative[window] = ((1+r/100)/(1+b/100) - 1) * 100
+			}
+		}
+
+		path := rotationPath(bars, benchBars)
+		if len(path) > 0 {
+			head := path[len(path)-1]
+			stats.RSRatio, stats.RSMomentum = head.RSRatio, head.RSMomentum
+			stats.Quadrant = RotationQuadrant(head.RSRatio, head.RSMomentum)
+			stats.Tail = weeklyTail(path)
+		}
+		rotation.Sectors = append(rotation.Sectors, stats)
+	}
+
+	return rotation, nil
+}
+
+// RotationQuadrant places a point on the rotation graph: above 100 on both
+// axes is leading, and sectors usually cycle clockwise through weakening,
+// lagging and improving.
+func RotationQuadrant(rsRatio, rsMomentum float64) string {
+	switch {
+	case rsRatio >= 100 && rsMomentum >= 100:
+		return QuadrantLeading
+	case rsRatio >= 100:
+		return QuadrantWeakening
+	case rsMomentum >= 100:
+		return QuadrantImproving
+	}
+	return QuadrantLagging
+}
+
+// windowReturns computes each SectorWindows return ending at the last bar on
+// or before asOf. YTD starts from the last close of the previous year.
+func windowReturns(bars []HistoricalData, asOf time.Time) map[string]float64 {
+	starts := map[string]time.Time{
+		"1W":  asOf.AddDate(0, 0, -7),
+		"1M":  asOf.AddDate(0, -1, 0),
+		"3M":  asOf.AddDate(0, -3, 0),
+		"YTD": time.Date(asOf.Year(), 1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Second),
+		"1Y":  asOf.AddDate(-1, 0, 0),
+	}
+
+	out := map[string]float64{}
+	for _, window := range SectorWindows {
+		if r, ok := TrailingReturn(bars, starts[window], asOf); ok {
+			out[window] = r
+		}
+	}
+	return out
+}
+
+// rotationPath follows the JdK-style construction: relative strength is the
+// sector's close over the benchmark's, RS-Ratio is that line over its
+// rsRatioPeriod-day average and RS-Momentum is the RS-Ratio over its value
+// rsMomentumLag days earlier, both scaled so 100 means no change.
+func rotationPath(bars, benchBars []HistoricalData) []RotationPoint {
+	benchCloses := closesByDate(benchBars)
+
+	var times []time.Time
+	var rs []float64
+	for _, bar := range bars {
+		b, ok := benchCloses[barDate(bar)]
+		if !ok || b == 0 {
+			continue
+		}
+		times = append(times, bar.Timestamp)
+		rs = append(rs, bar.Close/b*100)
+	}
+
+	if len(rs) < rsRatioPeriod+rsMomentumLag {
+		return nil
+	}
+
+	ratio := make([]float64, len(rs))
+	var sum float64
+	for i, v := range rs {
+		sum += v
+		if i >= rsRatioPeriod {
+			sum -= rs[i-rsRatioPeriod]
+		}
+		if i >= rsRatioPeriod-1 {
+			ratio[i] = 100 * v / (sum / rsRatioPeriod)
+		}
+	}
+
+	var path []RotationPoint
+	for i := rsRatioPeriod - 1 + rsMomentumLag; i < len(rs); i++ {
+		path = append(path, RotationPoint{
+			Time:       times[i],
+			RSRatio:    ratio[i],
+			RSMomentum: 100 * ratio[i] / ratio[i-rsMomentumLag],
+		})
+	}
+	return path
+}
+
+// weeklyTail keeps every fifth point back from the latest, oldest first.
+func weeklyTail(path []RotationPoint) []RotationPoint {
+	var tail []RotationPoint
+	for i := len(path) - 1; i >= 0 && len(tail) < rotationTailPoints; i -= 5 {
+		tail = append([]RotationPoint{path[i]}, tail...)
+	}
+	return tail
+}
@@ -2,6 +2,8 @@ package services
 
 import (
     "context"
+    "database/sql"
+    "errors"
 
     "github.com/loganlanou/Financing-101/internal/database"
     "log/slog"
@@ -25,23 +27,45 @@ func (s *StockService) Leaders(ctx context.Context, limit int32) ([]StockSnapsho
 
     out := make([]StockSnapshot, 0, len(rows))
     for _, row := range rows {
-        out = append(out, StockSnapshot{
-            ID:          row.ID,
-            Symbol:      row.Symbol,
-            Name:        row.Name,
-            Sector:      row.Sector.String,
-            Industry:    row.Industry.String,
-            Change30:    row.Change30,
-            Change90:    row.Change90,
-            Change365:   row.Change365,
-            VsSP500_30:  row.VsSp50030,
-            VsSP500_90:  row.VsSp50090,
-            VsSP500_365: row.VsSp500365,
-            Conviction:  row.Conviction,
-            Thesis:      row.Thesis,
-            UpdatedAt:   row.UpdatedAt,
-        })
+        out = append(out, snapshotFromRow(row))
     }
 
     return out, nil
 }
+
+// Snapshot returns the stored snapshot for symbol, or nil when there is none.
+func (s *StockService) Snapshot(ctx context.Context, symbol string) (*StockSnapshot, error) {
+    row, err := s.queries.GetStockSnapshotBySymbol(ctx, symbol)
+    if errors.Is(err, sql.ErrNoRows) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, err
+    }
+
+    snapshot := snapshotFromRow(row)
+    return &snapshot, nil
+}
+
+func snapshotFromRow(row database.StockSnapshot) StockSnapshot {
+    return StockSnapshot{
+        ID:           row.ID,
+        Symbol:       row.Symbol,
+        Name:         row.Name,
+        Sector:       row.Sector.String,
+        Industry:     row.Industry.String,
+        Change30:     row.Change30,
+        Change90:     row.Change90,
+        Change365:    row.Change365,
+        VsSP500_30:   row.VsSp50030,
+        VsSP500_90:   row.VsSp50090,
+        VsSP500_365:  row.VsSp500365,
+        Conviction:   row.Conviction,
+        Thesis:       row.Thesis,
+        UpdatedAt:    row.UpdatedAt,
+        Currency:     row.Currency,
+        USDChange30:  nullFloat(row.UsdChange30),
+        USDChange90:  nullFloat(row.UsdChange90),
+        USDChange365: nullFloat(row.UsdChange365),
+    }
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/loganlanou/Financing-101/internal/database"
+)
+
+// symbolCacheTTL bounds how stale search results can be after an import.
+const symbolCacheTTL = 10 * time.Minute
+
+// SymbolInfo is an entry in the symbol master.
+type SymbolInfo struct {
+	Symbol   string   `json:"symbol"`
+	Name     string   `json:"name"`
+	Exchange string   `json:"exchange"`
+	Type     string   `json:"type"`
+	Sector   string   `json:"sector,omitempty"`
+	Aliases  []string `json:"aliases,omitempty"`
+}
+
+// SymbolService searches the symbols table. The active list is held in
+// memory so autocomplete never scans the table per keystroke.
+type SymbolService struct {
+	log     *slog.Logger
+	queries *database.Queries
+
+	mu       sync.RWMutex
+	symbols  []SymbolInfo
+	loadedAt time.Time
+}
+
+func NewSymbolService(log *slog.Logger, queries *database.Queries) *SymbolService {
+	return &SymbolService{log: log, queries: queries}
+}
+
+// Invalidate drops the in-memory list so the next lookup reloads it.
+func (s *SymbolService) Invalidate() {
+	s.mu.Lock()
+	s.symbols = nil
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+// All returns every active symbol.
+func (s *SymbolService) All(ctx context.Context) ([]SymbolInfo, error) {
+	s.mu.RLock()
+	if s.symbols != nil && time.Since(s.loadedAt) < symbolCacheTTL {
+		defer s.mu.RUnlock()
+		return s.symbols, nil
+	}
+	s.mu.RUnlock()
+
+	rows, err := s.queries.ListActiveSymbols(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := make([]SymbolInfo, 0, len(rows))
+	for _, row := range rows {
+		symbols = append(symbols, SymbolInfo{
+			Symbol:   row.Symbol,
+			Name:     row.Name,
+			Exchange: row.Exchange,
+			Type:     row.Type,
+			Sector:   row.Sector.String,
+			Aliases:  splitAliases(row.Aliases),
+		})
+	}
+
+	s.mu.Lock()
+	s.symbols = symbols
+	s.loadedAt = time.Now()
+	s.mu.Unlock()
+
+	return symbols, nil
+}
+
+// Search ranks symbols against query: exact ticker, ticker prefix, name or
+// alias prefix, word prefix, substring, then names within a small edit
+// distance so "nvidea" still finds NVDA.
+func (s *SymbolService) Search(ctx context.Context, query string, limit int) ([]SymbolInfo, error) {
+	q := normalizeSearchText(query)
+	if q == "" || limit <= 0 {
+		return []SymbolInfo{}, nil
+	}
+
+	symbols, err := s.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type match struct {
+		info  SymbolInfo
+		score int
+	}
+	var matches []match
+	for _, info := range symbols {
+		if score := scoreSymbol(info, q); score > 0 {
+			matches = append(matches, match{info: info, score: score})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		if len(a.info.Symbol) != len(b.info.Symbol) {
+			return len(a.info.Symbol) < len(b.info.Symbol)
+		}
+		return a.info.Symbol < b.info.Symbol
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	out := make([]SymbolInfo, 0, len(matches))
+	for _, m := range matches {
+		out = append(out, m.info)
+	}
+	return out, nil
+}
+
+// Resolve maps free text to a ticker: the symbol itself when it exists,
+// otherwise the best search match.
+func (s *SymbolService) Resolve(ctx context.Context, query string) (string, bool) {
+	matches, err := s.Search(ctx, query, 1)
+	if err != nil {
+		s.log.Warn("symbol search failed", slog.String("query", query), slog.Any("err", err))
+		return "", false
+	}
+	if len(matches) == 0 {
+		return "", false
+	}
+	return matches[0].Symbol, true
+}
+
+// Lookup returns the symbol master entry for an exact ticker, including
+// delisted ones, or nil when the ticker is unknown.
+func (s *SymbolService) Lookup(ctx context.Context, symbol string) (*SymbolInfo, error) {
+	row, err := s.queries.GetSymbol(ctx, symbol)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &SymbolInfo{
+		Symbol:   row.Symbol,
+		Name:     row.Name,
+		Exchange: row.Exchange,
+		Type:     row.Type,
+		Sector:   row.Sector.String,
+		Aliases:  splitAliases(row.Aliases),
+	}, nil
+}
+
+func scoreSymbol(info SymbolInfo, q string) int {
+	ticker := strings.ToLower(info.Symbol)
+	switch {
+	case ticker == q:
+		return 1000
+	case strings.HasPrefix(ticker, q):
+		return 900
+	}
+
+	best := 0
+	names := append([]string{info.Name}, info.Aliases...)
+	for i, raw := range names {
+		name := normalizeSearchText(raw)
+		if name == "" {
+			continue
+		}
+		// Aliases are curated, so an exact alias beats a name prefix.
+		if i > 0 && name == q {
+			best = max(best, 850)
+		}
+		switch {
+		case strings.HasPrefix(name, q):
+			best = max(best, 800)
+		case hasWordPrefix(name, q):
+			best = max(best, 700)
+		case strings.Contains(name, q):
+			best = max(best, 500)
+		}
+		if best == 0 && len(q) >= 3 {
+			if d := closestWord(name, q); d <= fuzzyBudget(q) {
+				best = max(best, 300-50*d)
+			}
+		}
+	}
+	return best
+}
+
+func hasWordPrefix(name, q string) bool {
+	for _, word := range strings.Fields(name) {
+		if strings.HasPrefix(word, q) {
+			return true
+		}
+	}
+	return false
+}
+
+// fuzzyBudget allows one typo in short queries and two in longer ones.
+func fuzzyBudget(q string) int {
+	if len(q) <= 5 {
+		return 1
+	}
+	return 2
+}
+
+// closestWord is the smallest edit distance between q and any word in
+// name, comparing against the word's leading len(q) characters so partial
+// input like "microsft" still matches "microsoft".
+func closestWord(name, q string) int {
+	best := len(q)
+	for _, word := range strings.Fields(name) {
+		if len(word) > len(q)+1 {
+			word = word[:len(q)+1]
+		}
+		best = min(best, editDistance(word, q))
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// normalizeSearchText lower-cases and collapses punctuation other than the
+// characters tickers use ("BRK.B", "^GSPC") into single spaces.
+func normalizeSearchText(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), unicode.IsSpace(r):
+			return unicode.ToLower(r)
+		case r == '.' || r == '^' || r == '-':
+			return r
+		}
+		return ' '
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func splitAliases(raw string) []string {
+	var out []string
+	for _, alias := range strings.Split(raw, ",") {
+		if alias = strings.TrimSpace(alias); alias != "" {
+			out = append(out, alias)
+		}
+	}
+	return out
+}
@@ -23,6 +23,23 @@ func (s *TradeService) Recent(ctx context.Context, limit int32) ([]Trade, error)
         return nil, err
     }
 
+    return tradesFromRows(rows), nil
+}
+
+// ForSymbol returns the most recent disclosed trades in one ticker.
+func (s *TradeService) ForSymbol(ctx context.Context, symbol string, limit int32) ([]Trade, error) {
+    rows, err := s.queries.ListTradesBySymbol(ctx, database.ListTradesBySymbolParams{
+        Symbol: symbol,
+        Limit:  int64(limit),
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    return tradesFromRows(rows), nil
+}
+
+func tradesFromRows(rows []database.CongressTrade) []Trade {
     trades := make([]Trade, 0, len(rows))
     for _, row := range rows {
         trades = append(trades, Trade{
@@ -40,5 +57,5 @@ func (s *TradeService) Recent(ctx context.Context, limit int32) ([]Trade, error)
         })
     }
 
-    return trades, nil
+    return trades
 }
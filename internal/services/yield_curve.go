@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/loganlanou/Financing-101/internal/database"
+)
+
+// YieldPoint is one tenor's par yield, in percent.
+type YieldPoint struct {
+	Tenor  string  `json:"tenor"`
+	Months float64 `json:"months"`
+	Yield  float64 `json:"yield"`
+}
+
+// YieldCurve is the Treasury par yield curve on one business day, shortest
+// tenor first.
+type YieldCurve struct {
+	Date   time.Time    `json:"date"`
+	Points []YieldPoint `json:"points"`
+}
+
+// Yield returns the curve's yield at a tenor, if published that day.
+func (c *YieldCurve) Yield(months float64) (float64, bool) {
+	for _, p := range c.Points {
+		if p.Months == months {
+			return p.Yield, true
+		}
+	}
+	return 0, false
+}
+
+// CurveSpread is a watched long-minus-short spread on the curve.
+type CurveSpread struct {
+	ID          string
+	Label       string
+	ShortMonths float64
+	LongMonths  float64
+}
+
+// CurveSpreads are the inversion signals flagged on pages: the 2s10s spread
+// markets quote most and the 3m10y spread the Fed's research favors.
+var CurveSpreads = []CurveSpread{
+	{ID: "2s10s", Label: "2s10s", ShortMonths: 24, LongMonths: 120},
+	{ID: "3m10y", Label: "3m10y", ShortMonths: 3, LongMonths: 120},
+}
+
+// CurveSignal is a spread's latest reading. Spread is in percentage points,
+// negative when inverted.
+type CurveSignal struct {
+	ID       string    `json:"id"`
+	Label    string    `json:"label"`
+	Short    string    `json:"short"`
+	Long     string    `json:"long"`
+	Spread   float64   `json:"spread"`
+	Inverted bool      `json:"inverted"`
+	AsOf     time.Time `json:"asOf"`
+	// Since is the first day of the current inversion or normal stretch in
+	// stored history.
+	Since time.Time `json:"since"`
+}
+
+// Summary describes the signal in a sentence for pages that reference it.
+func (s CurveSignal) Summary() string {
+	state := "positive"
+	if s.Inverted {
+		state = "inverted"
+	}
+	return fmt.Sprintf("The %s Treasury spread (%s minus %s) is %s at %+.2f points, since %s.",
+		s.Label, s.Long, s.Short, state, s.Spread, s.Since.Format("Jan 2, 2006"))
+}
+
+// YieldCurveView is the latest curve with comparison curves and signals.
+type YieldCurveView struct {
+	Current  *YieldCurve   `json:"current"`
+	MonthAgo *YieldCurve   `json:"monthAgo,omitempty"`
+	YearAgo  *YieldCurve   `json:"yearAgo,omitempty"`
+	Signals  []CurveSignal `json:"signals"`
+}
+
+// signalHistory bounds how far back an inversion's start is looked for.
+const signalHistory = 5 * 365 * 24 * time.Hour
+
+// YieldCurveService stores Treasury par yield curves and derives inversion signals.
+type YieldCurveService struct {
+	log     *slog.Logger
+	queries *database.Queries
+}
+
+func NewYieldCurveService(log *slog.Logger, queries *database.Queries) *YieldCurveService {
+	return &YieldCurveService{log: log, queries: queries}
+}
+
+// TenorLabel names a tenor the way the curve is quoted: 3M, 1.5M, 2Y, 30Y.
+func TenorLabel(months float64) string {
+	if months >= 12 && int(months)%12 == 0 {
+		return strconv.Itoa(int(months)/12) + "Y"
+	}
+	return strconv.FormatFloat(months, 'f', -1, 64) + "M"
+}
+
+// StoreCurves upserts every point of each curve.
+func (s *YieldCurveService) StoreCurves(ctx context.Context, source string, curves []YieldCurve) error {
+	for _, curve := range curves {
+		for _, p := range curve.Points {
+			err := s.queries.UpsertTreasuryYield(ctx, database.UpsertTreasuryYieldParams{
+				CurveDate:   curve.Date.UTC(),
+				TenorMonths: p.Months,
+				Yield:       p.Yield,
+				Source:      source,
+			})
+			if err != nil {
+				return fmt.Errorf("store %s curve: %w", curve.Date.Format("2006-01-02"), err)
+			}
+		}
+	}
+	return nil
+}
+
+// CurveAsOf returns the latest stored curve on or before t, or nil when
+// nothing that old is stored.
+func (s *YieldCurveService) CurveAsOf(ctx context.Context, t time.Time) (*YieldCurve, error) {
+	rows, err := s.queries.ListTreasuryCurveAsOf(ctx, t.UTC())
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	curve := &YieldCurve{Date: rows[0].CurveDate.UTC(), Points: make([]YieldPoint, 0, len(rows))}
+	for _, row := range rows {
+		curve.Points = append(curve.Points, YieldPoint{
+			Tenor:  TenorLabel(row.TenorMonths),
+			Months: row.TenorMonths,
+			Yield:  row.Yield,
+		})
+	}
+	return curve, nil
+}
+
+// View returns the latest curve, the curves a month and a year before it,
+// and the CurveSpreads signals. Current is nil when no curve is stored.
+func (s *YieldCurveService) View(ctx context.Context) (*YieldCurveView, error) {
+	current, err := s.CurveAsOf(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	view := &YieldCurveView{Current: current}
+	if current == nil {
+		return view, nil
+	}
+
+	if view.MonthAgo, err = s.CurveAsOf(ctx, current.Date.AddDate(0, -1, 0)); err != nil {
+		return nil, err
+	}
+	if view.YearAgo, err = s.CurveAsOf(ctx, current.Date.AddDate(-1, 0, 0)); err != nil {
+		return nil, err
+	}
+	if view.Signals, err = s.signals(ctx, current); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// Signals returns the latest CurveSpreads readings, for pages that only
+// reference the inversion flags.
+func (s *YieldCurveService) Signals(ctx context.Context) ([]CurveSignal, error) {
+	current, err := s.CurveAsOf(ctx, time.Now())
+	if err != nil || current == nil {
+		return nil, err
+	}
+	return s.signals(ctx, current)
+}
+
+// BillRate returns the latest stored 3-month Treasury yield in percent, the
+// usual stand-in for the risk-free rate; ok is false when none is stored.
+func (s *YieldCurveService) BillRate(ctx context.Context) (rate float64, ok bool, err error) {
+	current, err := s.CurveAsOf(ctx, time.Now())
+	if err != nil || current == nil {
+		return 0, false, err
+	}
+	rate, ok = current.Yield(3)
+	return rate, ok, nil
+}
+
+// signals reads each spread's history to date its current stretch. A spread
+// whose tenors are missing from the current curve is left out.
+func (s *YieldCurveService) signals(ctx context.Context, current *YieldCurve) ([]CurveSignal, error) {
+	from := current.Date.Add(-signalHistory)
+
+	var out []CurveSignal
+	for _, spread := range CurveSpreads {
+		short, okShort := current.Yield(spread.ShortMonths)
+		long, okLong := current.Yield(spread.LongMonths)
+		if !okShort || !okLong {
+			continue
+		}
+
+		history, err := s.spreadHistory(ctx, spread, from)
+		if err != nil {
+			return nil, err
+		}
+
+		signal := CurveSignal{
+			ID:       spread.ID,
+			Label:    spread.Label,
+			Short:    TenorLabel(spread.ShortMonths),
+			Long:     TenorLabel(spread.LongMonths),
+			Spread:   long - short,
+			Inverted: long < short,
+			AsOf:     current.Date,
+			Since:    current.Date,
+		}
+		for i := len(history) - 1; i >= 0; i-- {
+			if (history[i].spread < 0) != signal.Inverted {
+				break
+			}
+			signal.Since = history[i].date
+		}
+		out = append(out, signal)
+	}
+	return out, nil
+}
+
+// spreadPoint is a spread's value on one curve date.
+type spreadPoint struct {
+	date   time.Time
+	spread float64
+}
+
+// spreadHistory pairs the two tenors' stored yields by date, oldest first.
+func (s *YieldCurveService) spreadHistory(ctx context.Context, spread CurveSpread, from time.Time) ([]spreadPoint, error) {
+	shortRows, err := s.queries.ListTreasuryTenorYields(ctx, database.ListTreasuryTenorYieldsParams{
+		TenorMonths: spread.ShortMonths,
+		FromTime:    from.UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	longRows, err := s.queries.ListTreasuryTenorYields(ctx, database.ListTreasuryTenorYieldsParams{
+		TenorMonths: spread.LongMonths,
+		FromTime:    from.UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	shortByDate := make(map[time.Time]float64, len(shortRows))
+	for _, row := range shortRows {
+		shortByDate[row.CurveDate.UTC()] = row.Yield
+	}
+	var out []spreadPoint
+	for _, row := range longRows {
+		if short, ok := shortByDate[row.CurveDate.UTC()]; ok {
+			out = append(out, spreadPoint{date: row.CurveDate.UTC(), spread: row.Yield - short})
+		}
+	}
+	return out, nil
+}
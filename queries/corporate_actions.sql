@@ -0,0 +1,21 @@
+-- name: ListCorporateActions :many
+SELECT symbol, type, ex_date, ratio, amount, new_symbol, source, updated_at
+FROM corporate_actions
+WHERE symbol = sqlc.arg('symbol')
+ORDER BY ex_date;
+
+-- name: ListSymbolChangesTo :many
+SELECT symbol, type, ex_date, ratio, amount, new_symbol, source, updated_at
+FROM corporate_actions
+WHERE type = 'symbol_change' AND new_symbol = sqlc.arg('new_symbol')
+ORDER BY ex_date;
+
+-- name: UpsertCorporateAction :exec
+INSERT INTO corporate_actions (symbol, type, ex_date, ratio, amount, new_symbol, source, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(symbol, type, ex_date) DO UPDATE SET
+    ratio=excluded.ratio,
+    amount=excluded.amount,
+    new_symbol=excluded.new_symbol,
+    source=excluded.source,
+    updated_at=excluded.updated_at;
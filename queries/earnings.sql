@@ -0,0 +1,37 @@
+-- name: ListEarningsHistory :many
+SELECT symbol, report_date, timing, fiscal_period, eps_estimate, eps_actual,
+       revenue_estimate, revenue_actual, source, updated_at
+FROM earnings_events
+WHERE symbol = sqlc.arg('symbol') AND report_date < sqlc.arg('before')
+ORDER BY report_date DESC
+LIMIT sqlc.arg('limit');
+
+-- name: GetNextEarningsEvent :one
+SELECT symbol, report_date, timing, fiscal_period, eps_estimate, eps_actual,
+       revenue_estimate, revenue_actual, source, updated_at
+FROM earnings_events
+WHERE symbol = sqlc.arg('symbol') AND report_date >= sqlc.arg('from_time')
+ORDER BY report_date
+LIMIT 1;
+
+-- name: ListEarningsCalendar :many
+SELECT symbol, report_date, timing, fiscal_period, eps_estimate, eps_actual,
+       revenue_estimate, revenue_actual, source, updated_at
+FROM earnings_events
+WHERE report_date >= sqlc.arg('from_time') AND report_date < sqlc.arg('to_time')
+ORDER BY report_date, symbol;
+
+-- name: UpsertEarningsEvent :exec
+INSERT INTO earnings_events (
+    symbol, report_date, timing, fiscal_period, eps_estimate, eps_actual,
+    revenue_estimate, revenue_actual, source, updated_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(symbol, report_date) DO UPDATE SET
+    timing=COALESCE(NULLIF(excluded.timing, ''), timing),
+    fiscal_period=COALESCE(NULLIF(excluded.fiscal_period, ''), fiscal_period),
+    eps_estimate=COALESCE(excluded.eps_estimate, eps_estimate),
+    eps_actual=COALESCE(excluded.eps_actual, eps_actual),
+    revenue_estimate=COALESCE(excluded.revenue_estimate, revenue_estimate),
+    revenue_actual=COALESCE(excluded.revenue_actual, revenue_actual),
+    source=excluded.source,
+    updated_at=excluded.updated_at;
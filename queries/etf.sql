@@ -0,0 +1,22 @@
+-- name: ListETFHoldings :many
+SELECT etf_symbol, position, symbol, name, sector, asset_class, weight, shares,
+       market_value, as_of, source
+FROM etf_holdings
+WHERE etf_symbol = sqlc.arg('etf_symbol')
+ORDER BY weight DESC, position;
+
+-- name: ListETFs :many
+SELECT etf_symbol, as_of, COUNT(*) AS holdings
+FROM etf_holdings
+GROUP BY etf_symbol, as_of
+ORDER BY etf_symbol;
+
+-- name: DeleteETFHoldings :exec
+DELETE FROM etf_holdings
+WHERE etf_symbol = sqlc.arg('etf_symbol');
+
+-- name: InsertETFHolding :exec
+INSERT INTO etf_holdings (
+    etf_symbol, position, symbol, name, sector, asset_class, weight, shares,
+    market_value, as_of, source
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
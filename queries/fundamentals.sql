@@ -0,0 +1,51 @@
+-- name: GetCompanyProfile :one
+SELECT symbol, cik, name, sector, industry, source, updated_at
+FROM company_profiles
+WHERE symbol = sqlc.arg('symbol');
+
+-- name: UpsertCompanyProfile :exec
+INSERT INTO company_profiles (symbol, cik, name, sector, industry, source, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(symbol) DO UPDATE SET
+    cik=excluded.cik,
+    name=excluded.name,
+    sector=COALESCE(excluded.sector, company_profiles.sector),
+    industry=COALESCE(excluded.industry, company_profiles.industry),
+    source=excluded.source,
+    updated_at=excluded.updated_at;
+
+-- name: ListFundamentals :many
+SELECT symbol, period_end, fiscal_year, fiscal_period, form, filed_at,
+       revenue, gross_profit, operating_income, net_income, eps_diluted,
+       total_assets, total_liabilities, stockholders_equity, cash, long_term_debt, shares_outstanding,
+       operating_cash_flow, capital_expenditures
+FROM fundamentals
+WHERE symbol = sqlc.arg('symbol')
+ORDER BY period_end DESC
+LIMIT sqlc.arg('limit');
+
+-- name: UpsertFundamental :exec
+INSERT INTO fundamentals (
+    symbol, period_end, fiscal_year, fiscal_period, form, filed_at,
+    revenue, gross_profit, operating_income, net_income, eps_diluted,
+    total_assets, total_liabilities, stockholders_equity, cash, long_term_debt, shares_outstanding,
+    operating_cash_flow, capital_expenditures
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(symbol, period_end) DO UPDATE SET
+    fiscal_year=excluded.fiscal_year,
+    fiscal_period=excluded.fiscal_period,
+    form=excluded.form,
+    filed_at=excluded.filed_at,
+    revenue=excluded.revenue,
+    gross_profit=excluded.gross_profit,
+    operating_income=excluded.operating_income,
+    net_income=excluded.net_income,
+    eps_diluted=excluded.eps_diluted,
+    total_assets=excluded.total_assets,
+    total_liabilities=excluded.total_liabilities,
+    stockholders_equity=excluded.stockholders_equity,
+    cash=excluded.cash,
+    long_term_debt=excluded.long_term_debt,
+    shares_outstanding=excluded.shares_outstanding,
+    operating_cash_flow=excluded.operating_cash_flow,
+    capital_expenditures=excluded.capital_expenditures;
@@ -0,0 +1,30 @@
+-- name: GetFXRateOn :one
+SELECT currency, rate_date, rate, source
+FROM fx_rates
+WHERE currency = sqlc.arg('currency') AND rate_date <= sqlc.arg('as_of')
+ORDER BY rate_date DESC
+LIMIT 1;
+
+-- name: ListFXRates :many
+SELECT currency, rate_date, rate, source
+FROM fx_rates
+WHERE currency = sqlc.arg('currency')
+  AND rate_date >= sqlc.arg('from_time')
+  AND rate_date <= sqlc.arg('to_time')
+ORDER BY rate_date;
+
+-- name: ListLatestFXRates :many
+SELECT f.currency, f.rate_date, f.rate, f.source
+FROM fx_rates f
+WHERE f.rate_date = (
+    SELECT MAX(rate_date) FROM fx_rates
+    WHERE currency = f.currency AND rate_date <= sqlc.arg('as_of')
+)
+ORDER BY f.currency;
+
+-- name: UpsertFXRate :exec
+INSERT INTO fx_rates (currency, rate_date, rate, source)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(currency, rate_date) DO UPDATE SET
+    rate=excluded.rate,
+    source=excluded.source;
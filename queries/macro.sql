@@ -0,0 +1,50 @@
+-- name: GetMacroSeries :one
+SELECT id, title, units, frequency, source, updated_at
+FROM macro_series
+WHERE id = sqlc.arg('id');
+
+-- name: UpsertMacroSeries :exec
+INSERT INTO macro_series (id, title, units, frequency, source, updated_at)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET
+    title=excluded.title,
+    units=excluded.units,
+    frequency=excluded.frequency,
+    source=excluded.source,
+    updated_at=excluded.updated_at;
+
+-- name: ListMacroObservations :many
+SELECT series_id, obs_date, value
+FROM macro_observations
+WHERE series_id = sqlc.arg('series_id')
+  AND obs_date >= sqlc.arg('from_time')
+  AND obs_date <= sqlc.arg('to_time')
+ORDER BY obs_date;
+
+-- name: GetLatestMacroObservation :one
+SELECT series_id, obs_date, value
+FROM macro_observations
+WHERE series_id = sqlc.arg('series_id')
+ORDER BY obs_date DESC
+LIMIT 1;
+
+-- name: UpsertMacroObservation :exec
+INSERT INTO macro_observations (series_id, obs_date, value)
+VALUES (?, ?, ?)
+ON CONFLICT(series_id, obs_date) DO UPDATE SET
+    value=excluded.value;
+
+-- name: ListUpcomingMacroReleases :many
+SELECT name, release_at, series_id, period, source
+FROM macro_releases
+WHERE release_at >= sqlc.arg('from_time')
+ORDER BY release_at, name
+LIMIT sqlc.arg('limit');
+
+-- name: UpsertMacroRelease :exec
+INSERT INTO macro_releases (name, release_at, series_id, period, source)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT(name, release_at) DO UPDATE SET
+    series_id=excluded.series_id,
+    period=excluded.period,
+    source=excluded.source;
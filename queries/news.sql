@@ -16,3 +16,10 @@ ON CONFLICT(id) DO UPDATE SET
     tickers=excluded.tickers,
     url=excluded.url,
     published_at=excluded.published_at;
+
+-- name: ListNewsBySymbol :many
+SELECT id, title, source, summary, sentiment_score, trend, tickers, url, published_at
+FROM news_articles
+WHERE ',' || REPLACE(tickers, ' ', '') || ',' LIKE '%,' || sqlc.arg('symbol') || ',%'
+ORDER BY published_at DESC
+LIMIT sqlc.arg('limit');
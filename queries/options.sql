@@ -0,0 +1,31 @@
+-- name: ListOptionExpirations :many
+SELECT DISTINCT expiration
+FROM option_contracts
+WHERE symbol = sqlc.arg('symbol') AND expiration >= sqlc.arg('from_time')
+ORDER BY expiration;
+
+-- name: ListOptionChain :many
+SELECT symbol, expiration, kind, strike, bid, ask, last, volume, open_interest,
+       implied_vol, as_of, source
+FROM option_contracts
+WHERE symbol = sqlc.arg('symbol') AND expiration = sqlc.arg('expiration')
+ORDER BY strike, kind;
+
+-- name: DeleteOptionChain :exec
+DELETE FROM option_contracts
+WHERE symbol = sqlc.arg('symbol') AND expiration = sqlc.arg('expiration');
+
+-- name: UpsertOptionContract :exec
+INSERT INTO option_contracts (
+    symbol, expiration, kind, strike, bid, ask, last, volume, open_interest,
+    implied_vol, as_of, source
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(symbol, expiration, kind, strike) DO UPDATE SET
+    bid=excluded.bid,
+    ask=excluded.ask,
+    last=excluded.last,
+    volume=excluded.volume,
+    open_interest=excluded.open_interest,
+    implied_vol=excluded.implied_vol,
+    as_of=excluded.as_of,
+    source=excluded.source;
@@ -0,0 +1,44 @@
+-- name: ListPriceBars :many
+SELECT symbol, interval, bar_time, open, high, low, close, volume, source
+FROM price_bars
+WHERE symbol = sqlc.arg('symbol')
+  AND interval = sqlc.arg('interval')
+  AND bar_time >= sqlc.arg('from_time')
+  AND bar_time <= sqlc.arg('to_time')
+ORDER BY bar_time;
+
+-- name: GetEarliestPriceBar :one
+SELECT symbol, interval, bar_time, open, high, low, close, volume, source
+FROM price_bars
+WHERE symbol = sqlc.arg('symbol') AND interval = sqlc.arg('interval')
+ORDER BY bar_time ASC
+LIMIT 1;
+
+-- name: GetLatestPriceBar :one
+SELECT symbol, interval, bar_time, open, high, low, close, volume, source
+FROM price_bars
+WHERE symbol = sqlc.arg('symbol') AND interval = sqlc.arg('interval')
+ORDER BY bar_time DESC
+LIMIT 1;
+
+-- name: UpsertPriceBar :exec
+INSERT INTO price_bars (symbol, interval, bar_time, open, high, low, close, volume, source)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(symbol, interval, bar_time) DO UPDATE SET
+    open=excluded.open,
+    high=excluded.high,
+    low=excluded.low,
+    close=excluded.close,
+    volume=excluded.volume,
+    source=excluded.source;
+
+-- name: GetPriceCoverage :one
+SELECT covered_from
+FROM price_coverage
+WHERE symbol = sqlc.arg('symbol') AND interval = sqlc.arg('interval');
+
+-- name: UpsertPriceCoverage :exec
+INSERT INTO price_coverage (symbol, interval, covered_from)
+VALUES (?, ?, ?)
+ON CONFLICT(symbol, interval) DO UPDATE SET
+    covered_from=excluded.covered_from;
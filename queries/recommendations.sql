@@ -14,3 +14,10 @@ ON CONFLICT(id) DO UPDATE SET
     score=excluded.score,
     catalyst=excluded.catalyst,
     created_at=excluded.created_at;
+
+-- name: ListRecommendationsBySymbol :many
+SELECT id, symbol, thesis, conviction, score, catalyst, created_at
+FROM recommendations
+WHERE symbol = sqlc.arg('symbol')
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit');
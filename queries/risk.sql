@@ -0,0 +1,43 @@
+-- name: ListRiskStats :many
+SELECT symbol, window_days, benchmark, observations, volatility, max_drawdown,
+       beta, correlation, sharpe, sortino, as_of, updated_at
+FROM risk_stats
+WHERE symbol = sqlc.arg('symbol')
+ORDER BY window_days;
+
+-- name: UpsertRiskStat :exec
+INSERT INTO risk_stats (
+    symbol, window_days, benchmark, observations, volatility, max_drawdown,
+    beta, correlation, sharpe, sortino, as_of, updated_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(symbol, window_days) DO UPDATE SET
+    benchmark=excluded.benchmark,
+    observations=excluded.observations,
+    volatility=excluded.volatility,
+    max_drawdown=excluded.max_drawdown,
+    beta=excluded.beta,
+    correlation=excluded.correlation,
+    sharpe=excluded.sharpe,
+    sortino=excluded.sortino,
+    as_of=excluded.as_of,
+    updated_at=excluded.updated_at;
+
+
+-- name: ListRiskRolling :many
+SELECT symbol, window_bars, bar_time, benchmark, volatility, max_drawdown,
+       beta, correlation, sharpe, sortino
+FROM risk_rolling
+WHERE symbol = sqlc.arg('symbol')
+  AND window_bars = sqlc.arg('window_bars')
+  AND bar_time >= sqlc.arg('from_time')
+ORDER BY bar_time;
+
+-- name: DeleteRiskRolling :exec
+DELETE FROM risk_rolling
+WHERE symbol = sqlc.arg('symbol') AND window_bars = sqlc.arg('window_bars');
+
+-- name: InsertRiskRolling :exec
+INSERT INTO risk_rolling (
+    symbol, window_bars, bar_time, benchmark, volatility, max_drawdown,
+    beta, correlation, sharpe, sortino
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
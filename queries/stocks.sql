@@ -1,15 +1,26 @@
 -- name: ListStockSnapshots :many
 SELECT id, symbol, name, sector, industry, change_30, change_90, change_365,
-       vs_sp500_30, vs_sp500_90, vs_sp500_365, conviction, thesis, updated_at
+       vs_sp500_30, vs_sp500_90, vs_sp500_365, conviction, thesis, updated_at,
+       currency, usd_change_30, usd_change_90, usd_change_365
 FROM stock_snapshots
 ORDER BY vs_sp500_90 DESC
 LIMIT sqlc.arg('limit');
 
+-- name: GetStockSnapshotBySymbol :one
+SELECT id, symbol, name, sector, industry, change_30, change_90, change_365,
+       vs_sp500_30, vs_sp500_90, vs_sp500_365, conviction, thesis, updated_at,
+       currency, usd_change_30, usd_change_90, usd_change_365
+FROM stock_snapshots
+WHERE symbol = sqlc.arg('symbol')
+ORDER BY updated_at DESC
+LIMIT 1;
+
 -- name: InsertStockSnapshot :exec
 INSERT INTO stock_snapshots (
     id, symbol, name, sector, industry, change_30, change_90, change_365,
-    vs_sp500_30, vs_sp500_90, vs_sp500_365, conviction, thesis, updated_at
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    vs_sp500_30, vs_sp500_90, vs_sp500_365, conviction, thesis, updated_at,
+    currency, usd_change_30, usd_change_90, usd_change_365
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(id) DO UPDATE SET
     symbol=excluded.symbol,
     name=excluded.name,
@@ -23,4 +34,8 @@ ON CONFLICT(id) DO UPDATE SET
     vs_sp500_365=excluded.vs_sp500_365,
     conviction=excluded.conviction,
     thesis=excluded.thesis,
-    updated_at=excluded.updated_at;
+    updated_at=excluded.updated_at,
+    currency=excluded.currency,
+    usd_change_30=excluded.usd_change_30,
+    usd_change_90=excluded.usd_change_90,
+    usd_change_365=excluded.usd_change_365;
@@ -0,0 +1,30 @@
+-- name: ListActiveSymbols :many
+SELECT symbol, name, exchange, type, sector, aliases, active, updated_at
+FROM symbols
+WHERE active = 1
+ORDER BY symbol;
+
+-- name: GetSymbol :one
+SELECT symbol, name, exchange, type, sector, aliases, active, updated_at
+FROM symbols
+WHERE symbol = sqlc.arg('symbol');
+
+-- name: UpsertSymbol :exec
+INSERT INTO symbols (symbol, name, exchange, type, sector, aliases, active, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(symbol) DO UPDATE SET
+    name=excluded.name,
+    exchange=excluded.exchange,
+    type=excluded.type,
+    sector=COALESCE(excluded.sector, symbols.sector),
+    aliases=CASE WHEN excluded.aliases = '' THEN symbols.aliases ELSE excluded.aliases END,
+    active=excluded.active,
+    updated_at=excluded.updated_at;
+
+-- name: DeactivateMissingSymbols :exec
+UPDATE symbols
+SET active = 0
+WHERE exchange = sqlc.arg('exchange') AND updated_at < sqlc.arg('seen_before') AND type != 'index';
+
+-- name: CountSymbols :one
+SELECT COUNT(*) FROM symbols;
@@ -19,3 +19,10 @@ ON CONFLICT(id) DO UPDATE SET
     disclosure_date=excluded.disclosure_date,
     sentiment=excluded.sentiment,
     source_url=excluded.source_url;
+
+-- name: ListTradesBySymbol :many
+SELECT id, member, party, chamber, symbol, action, amount, executed_at, disclosure_date, sentiment, source_url
+FROM congress_trades
+WHERE symbol = sqlc.arg('symbol')
+ORDER BY executed_at DESC
+LIMIT sqlc.arg('limit');
@@ -0,0 +1,21 @@
+-- name: ListTreasuryCurveAsOf :many
+SELECT curve_date, tenor_months, yield, source
+FROM treasury_yields
+WHERE curve_date = (
+    SELECT MAX(curve_date) FROM treasury_yields WHERE curve_date <= sqlc.arg('as_of')
+)
+ORDER BY tenor_months;
+
+-- name: ListTreasuryTenorYields :many
+SELECT curve_date, tenor_months, yield, source
+FROM treasury_yields
+WHERE tenor_months = sqlc.arg('tenor_months')
+  AND curve_date >= sqlc.arg('from_time')
+ORDER BY curve_date;
+
+-- name: UpsertTreasuryYield :exec
+INSERT INTO treasury_yields (curve_date, tenor_months, yield, source)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(curve_date, tenor_months) DO UPDATE SET
+    yield=excluded.yield,
+    source=excluded.source;
@@ -0,0 +1,158 @@
+// Live quotes: elements marked data-quote="SYMBOL" are refreshed from the
+// /stream/quotes Server-Sent Events endpoint. Children marked
+// data-quote-field pick which value they show; data-quote-up/-down name the
+// classes toggled by the sign of the change. A data-quote-currency on any of
+// them streams prices converted to that currency.
+(function () {
+  "use strict";
+
+  var MAX_SYMBOLS = 25;
+
+  function formatVolume(vol) {
+    if (vol >= 1e9) return (vol / 1e9).toFixed(1) + "B";
+    if (vol >= 1e6) return (vol / 1e6).toFixed(1) + "M";
+    if (vol >= 1e3) return (vol / 1e3).toFixed(1) + "K";
+    return String(vol);
+  }
+
+  // Keep in sync with currencySymbols in stocks.templ
+  var CURRENCY_SYMBOLS = {
+    USD: "$", EUR: "€", GBP: "£", JPY: "¥", INR: "₹", KRW: "₩", CAD: "C$", AUD: "A$", HKD: "HK$"
+  };
+
+  function formatMoney(currency, value) {
+    currency = currency || "USD";
+    if (currency === "GBp" || currency === "GBX") return value.toFixed(2) + "p";
+    var amount = Math.abs(value).toFixed(2);
+    var symbol = CURRENCY_SYMBOLS[currency];
+    return (value < 0 ? "-" : "") + (symbol ? symbol + amount : amount + " " + currency);
+  }
+
+  function signed(value) {
+    return (value >= 0 ? "+" : "") + value.toFixed(2);
+  }
+
+  var formatters = {
+    price: function (q) { return formatMoney(q.currency, q.price); },
+    level: function (q) { return q.price.toFixed(2); },
+    changePercent: function (q) { return signed(q.changePercent) + "%"; },
+    changeSummary: function (q) {
+      return (q.change >= 0 ? "↑ " : "↓ ") + signed(q.change) + " (" + q.changePercent.toFixed(2) + "%)";
+    },
+    volume: function (q) { return formatVolume(q.volume); }
+  };
+
+  function applySign(el, quote) {
+    var up = el.getAttribute("data-quote-up");
+    var down = el.getAttribute("data-quote-down");
+    if (up) el.classList.toggle(up, quote.changePercent >= 0);
+    if (down) el.classList.toggle(down, quote.changePercent < 0);
+  }
+
+  function render(root, quote) {
+    applySign(root, quote);
+    root.querySelectorAll("[data-quote-field]").forEach(function (el) {
+      var format = formatters[el.getAttribute("data-quote-field")];
+      if (!format) return;
+      el.textContent = format(quote);
+      applySign(el, quote);
+    });
+  }
+
+  function connect() {
+    var nodes = document.querySelectorAll("[data-quote]");
+    if (!nodes.length || !window.EventSource) return;
+
+    var bySymbol = {};
+    nodes.forEach(function (el) {
+      var symbol = el.getAttribute("data-quote").toUpperCase();
+      (bySymbol[symbol] = bySymbol[symbol] || []).push(el);
+    });
+
+    var symbols = Object.keys(bySymbol).slice(0, MAX_SYMBOLS);
+    var url = "/stream/quotes?symbols=" + encodeURIComponent(symbols.join(","));
+    var currency = document.querySelector("[data-quote-currency]");
+    if (currency) url += "&currency=" + encodeURIComponent(currency.getAttribute("data-quote-currency"));
+    var source = new EventSource(url);
+    source.addEventListener("quote", function (event) {
+      var quote = JSON.parse(event.data);
+      (bySymbol[quote.symbol.toUpperCase()] || []).forEach(function (el) {
+        render(el, quote);
+      });
+    });
+  }
+
+  if (document.readyState === "loading") {
+    document.addEventListener("DOMContentLoaded", connect);
+  } else {
+    connect();
+  }
+})();
+
+// Symbol autocomplete: inputs marked data-symbol-search suggest matches from
+// /api/symbols/search through a <datalist>. Submitting the surrounding form
+// opens the quote, and the server resolves company names to tickers.
+(function () {
+  "use strict";
+
+  var DEBOUNCE_MS = 150;
+  var LIMIT = 8;
+
+  function attach(input, index) {
+    var list = document.createElement("datalist");
+    list.id = "symbol-search-" + index;
+    input.setAttribute("list", list.id);
+    input.parentNode.appendChild(list);
+
+    var timer = null;
+    var pending = null;
+
+    function render(results) {
+      list.textContent = "";
+      results.forEach(function (item) {
+        var option = document.createElement("option");
+        option.value = item.symbol;
+        option.label = item.name + " · " + item.exchange;
+        list.appendChild(option);
+      });
+    }
+
+    function search() {
+      var q = input.value.trim();
+      if (!q) {
+        render([]);
+        return;
+      }
+      if (pending) pending.abort();
+      pending = new AbortController();
+      fetch("/api/symbols/search?limit=" + LIMIT + "&q=" + encodeURIComponent(q), { signal: pending.signal })
+        .then(function (res) { return res.ok ? res.json() : { results: [] }; })
+        .then(function (body) { render(body.results || []); })
+        .catch(function () {});
+    }
+
+    input.addEventListener("input", function () {
+      clearTimeout(timer);
+      timer = setTimeout(search, DEBOUNCE_MS);
+    });
+  }
+
+  function init() {
+    document.querySelectorAll("[data-symbol-search]").forEach(attach);
+
+    // "/" focuses the header search, as its hint suggests.
+    document.addEventListener("keydown", function (event) {
+      if (event.key !== "/" || /^(INPUT|TEXTAREA|SELECT)$/.test(document.activeElement.tagName)) return;
+      var input = document.querySelector(".search-input[data-symbol-search]");
+      if (!input) return;
+      event.preventDefault();
+      input.focus();
+    });
+  }
+
+  if (document.readyState === "loading") {
+    document.addEventListener("DOMContentLoaded", init);
+  } else {
+    init();
+  }
+})();